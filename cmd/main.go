@@ -94,7 +94,8 @@ func loadYAMLConfig(file *os.File) (vibestation.Config, error) {
 
 	// Parse YAML
 	var yamlConfig struct {
-		Transforms string `yaml:"transforms"`
+		Transforms    string `yaml:"transforms"`
+		CaptureErrors bool   `yaml:"capture_errors"`
 	}
 
 	if err := yaml.Unmarshal(content, &yamlConfig); err != nil {
@@ -136,7 +137,8 @@ func loadYAMLConfig(file *os.File) (vibestation.Config, error) {
 	}
 
 	return vibestation.Config{
-		Transforms: transforms,
+		Transforms:    transforms,
+		CaptureErrors: yamlConfig.CaptureErrors,
 	}, nil
 }
 
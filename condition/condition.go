@@ -0,0 +1,74 @@
+// Package condition provides functions for evaluating messages.
+package condition
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/jshlbrd/vibestation/config"
+	"github.com/jshlbrd/vibestation/message"
+)
+
+// Conditioner is the interface implemented by all conditions and
+// provides the ability to evaluate a message.
+type Conditioner interface {
+	Condition(context.Context, *message.Message) (bool, error)
+}
+
+// New is a factory function for returning a configured Conditioner.
+func New(ctx context.Context, cfg config.Config) (Conditioner, error) {
+	switch cfg.Type {
+	case "exists":
+		return newExists(ctx, cfg)
+	case "string_equal_to":
+		return newStringEqualTo(ctx, cfg)
+	case "string_contains":
+		return newStringContains(ctx, cfg)
+	case "number_equal_to":
+		return newNumberEqualTo(ctx, cfg)
+	case "number_greater_than":
+		return newNumberGreaterThan(ctx, cfg)
+	case "number_greater_than_or_equal_to":
+		return newNumberGreaterThanOrEqualTo(ctx, cfg)
+	case "number_less_than":
+		return newNumberLessThan(ctx, cfg)
+	case "number_less_than_or_equal_to":
+		return newNumberLessThanOrEqualTo(ctx, cfg)
+	default:
+		return nil, fmt.Errorf("condition %s: unsupported condition type", cfg.Type)
+	}
+}
+
+// numberValue returns the number at the source path, or the number in the
+// data if the path is empty. Strings are parsed as numbers. The second
+// return value is false if the value is missing or not a finite number.
+func numberValue(msg *message.Message, sourcePath string) (float64, bool) {
+	if sourcePath == "" {
+		return parseFiniteFloat(string(msg.Data()))
+	}
+
+	val := msg.GetValue(sourcePath)
+	if !val.Exists() {
+		return 0, false
+	}
+
+	switch v := val.Value().(type) {
+	case float64, int, int64:
+		return val.Float(), true
+	case string:
+		return parseFiniteFloat(v)
+	}
+	return 0, false
+}
+
+// parseFiniteFloat parses s as a number. NaN and infinite values are rejected.
+func parseFiniteFloat(s string) (float64, bool) {
+	f, err := strconv.ParseFloat(strings.TrimSpace(s), 64)
+	if err != nil || math.IsNaN(f) || math.IsInf(f, 0) {
+		return 0, false
+	}
+	return f, true
+}
@@ -0,0 +1,14 @@
+package condition
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jshlbrd/vibestation/config"
+)
+
+func TestNew_Unsupported(t *testing.T) {
+	if _, err := New(context.Background(), config.Config{Type: "bogus"}); err == nil {
+		t.Fatal("expected error for unsupported condition type, got nil")
+	}
+}
@@ -0,0 +1,65 @@
+package condition
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/jshlbrd/vibestation/config"
+	"github.com/jshlbrd/vibestation/message"
+)
+
+type ExistsConfig struct {
+	ID string `json:"id"`
+}
+
+func (c *ExistsConfig) Decode(in interface{}) error {
+	return config.Decode(in, c)
+}
+
+func newExists(_ context.Context, cfg config.Config) (*Exists, error) {
+	conf := ExistsConfig{}
+	if err := conf.Decode(cfg.Settings); err != nil {
+		return nil, fmt.Errorf("condition exists: %v", err)
+	}
+	if conf.ID == "" {
+		conf.ID = "exists"
+	}
+
+	var sourcePath string
+	if v, ok := cfg.Settings["source"]; ok {
+		if s, ok := v.(string); ok {
+			sourcePath = s
+		}
+	}
+	if sourcePath == "" {
+		return nil, fmt.Errorf("condition %s: source: missing required option", conf.ID)
+	}
+
+	cnd := Exists{
+		conf:       conf,
+		settings:   cfg.Settings,
+		sourcePath: sourcePath,
+	}
+	return &cnd, nil
+}
+
+// Exists matches messages where the source path exists.
+type Exists struct {
+	conf       ExistsConfig
+	settings   map[string]interface{}
+	sourcePath string
+}
+
+func (c *Exists) Condition(ctx context.Context, msg *message.Message) (bool, error) {
+	if msg.IsControl() {
+		return false, nil
+	}
+
+	return msg.GetValue(c.sourcePath).Exists(), nil
+}
+
+func (c *Exists) String() string {
+	b, _ := json.Marshal(c.conf)
+	return string(b)
+}
@@ -0,0 +1,44 @@
+package condition
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jshlbrd/vibestation/config"
+	"github.com/jshlbrd/vibestation/message"
+)
+
+func TestExists(t *testing.T) {
+	tests := []struct {
+		data     string
+		expected bool
+	}{
+		{`{"a":{"b":1}}`, true},
+		{`{"a":{"c":1}}`, false},
+		{`not json`, false},
+	}
+
+	cnd, err := newExists(context.Background(), config.Config{
+		Type:     "exists",
+		Settings: map[string]interface{}{"source": "$.a.b"},
+	})
+	if err != nil {
+		t.Fatalf("failed to create exists condition: %v", err)
+	}
+
+	for _, test := range tests {
+		ok, err := cnd.Condition(context.Background(), message.New().SetData([]byte(test.data)))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if ok != test.expected {
+			t.Errorf("%s: expected %v, got %v", test.data, test.expected, ok)
+		}
+	}
+}
+
+func TestExists_MissingSource(t *testing.T) {
+	if _, err := newExists(context.Background(), config.Config{Type: "exists"}); err == nil {
+		t.Fatal("expected error for missing source, got nil")
+	}
+}
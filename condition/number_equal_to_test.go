@@ -0,0 +1,56 @@
+package condition
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jshlbrd/vibestation/config"
+	"github.com/jshlbrd/vibestation/message"
+)
+
+func TestNumberEqualTo(t *testing.T) {
+	tests := []struct {
+		settings map[string]interface{}
+		data     string
+		expected bool
+	}{
+		{map[string]interface{}{"source": "$.count", "value": 3}, `{"count":3}`, true},
+		{map[string]interface{}{"source": "$.count", "value": 3}, `{"count":3.0}`, true},
+		{map[string]interface{}{"source": "$.count", "value": 3}, `{"count":4}`, false},
+		{map[string]interface{}{"source": "$.count", "value": 0}, `{}`, false},
+		{map[string]interface{}{"value": 3}, `3`, true},
+	}
+
+	for _, test := range tests {
+		cnd, err := newNumberEqualTo(context.Background(), config.Config{
+			Type:     "number_equal_to",
+			Settings: test.settings,
+		})
+		if err != nil {
+			t.Fatalf("failed to create number_equal_to condition: %v", err)
+		}
+
+		ok, err := cnd.Condition(context.Background(), message.New().SetData([]byte(test.data)))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if ok != test.expected {
+			t.Errorf("%s: expected %v, got %v", test.data, test.expected, ok)
+		}
+	}
+}
+
+func TestNumberEqualTo_ControlMessage(t *testing.T) {
+	cnd, err := newNumberEqualTo(context.Background(), config.Config{Type: "number_equal_to"})
+	if err != nil {
+		t.Fatalf("failed to create number_equal_to condition: %v", err)
+	}
+
+	ok, err := cnd.Condition(context.Background(), message.New().AsControl())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if ok {
+		t.Error("expected control message not to match")
+	}
+}
@@ -0,0 +1,65 @@
+package condition
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/jshlbrd/vibestation/config"
+	"github.com/jshlbrd/vibestation/message"
+)
+
+type NumberGreaterThanConfig struct {
+	Value float64 `json:"value"`
+	ID    string  `json:"id"`
+}
+
+func (c *NumberGreaterThanConfig) Decode(in interface{}) error {
+	return config.Decode(in, c)
+}
+
+func newNumberGreaterThan(_ context.Context, cfg config.Config) (*NumberGreaterThan, error) {
+	conf := NumberGreaterThanConfig{}
+	if err := conf.Decode(cfg.Settings); err != nil {
+		return nil, fmt.Errorf("condition number_greater_than: %v", err)
+	}
+	if conf.ID == "" {
+		conf.ID = "number_greater_than"
+	}
+
+	var sourcePath string
+	if v, ok := cfg.Settings["source"]; ok {
+		if s, ok := v.(string); ok {
+			sourcePath = s
+		}
+	}
+
+	cnd := NumberGreaterThan{
+		conf:       conf,
+		settings:   cfg.Settings,
+		sourcePath: sourcePath,
+	}
+	return &cnd, nil
+}
+
+// NumberGreaterThan matches messages where the source (or data) is a number
+// greater than Value. Non-numeric values do not match.
+type NumberGreaterThan struct {
+	conf       NumberGreaterThanConfig
+	settings   map[string]interface{}
+	sourcePath string
+}
+
+func (c *NumberGreaterThan) Condition(ctx context.Context, msg *message.Message) (bool, error) {
+	if msg.IsControl() {
+		return false, nil
+	}
+
+	n, ok := numberValue(msg, c.sourcePath)
+	return ok && n > c.conf.Value, nil
+}
+
+func (c *NumberGreaterThan) String() string {
+	b, _ := json.Marshal(c.conf)
+	return string(b)
+}
@@ -0,0 +1,65 @@
+package condition
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/jshlbrd/vibestation/config"
+	"github.com/jshlbrd/vibestation/message"
+)
+
+type NumberGreaterThanOrEqualToConfig struct {
+	Value float64 `json:"value"`
+	ID    string  `json:"id"`
+}
+
+func (c *NumberGreaterThanOrEqualToConfig) Decode(in interface{}) error {
+	return config.Decode(in, c)
+}
+
+func newNumberGreaterThanOrEqualTo(_ context.Context, cfg config.Config) (*NumberGreaterThanOrEqualTo, error) {
+	conf := NumberGreaterThanOrEqualToConfig{}
+	if err := conf.Decode(cfg.Settings); err != nil {
+		return nil, fmt.Errorf("condition number_greater_than_or_equal_to: %v", err)
+	}
+	if conf.ID == "" {
+		conf.ID = "number_greater_than_or_equal_to"
+	}
+
+	var sourcePath string
+	if v, ok := cfg.Settings["source"]; ok {
+		if s, ok := v.(string); ok {
+			sourcePath = s
+		}
+	}
+
+	cnd := NumberGreaterThanOrEqualTo{
+		conf:       conf,
+		settings:   cfg.Settings,
+		sourcePath: sourcePath,
+	}
+	return &cnd, nil
+}
+
+// NumberGreaterThanOrEqualTo matches messages where the source (or data) is a
+// number greater than or equal to Value. Non-numeric values do not match.
+type NumberGreaterThanOrEqualTo struct {
+	conf       NumberGreaterThanOrEqualToConfig
+	settings   map[string]interface{}
+	sourcePath string
+}
+
+func (c *NumberGreaterThanOrEqualTo) Condition(ctx context.Context, msg *message.Message) (bool, error) {
+	if msg.IsControl() {
+		return false, nil
+	}
+
+	n, ok := numberValue(msg, c.sourcePath)
+	return ok && n >= c.conf.Value, nil
+}
+
+func (c *NumberGreaterThanOrEqualTo) String() string {
+	b, _ := json.Marshal(c.conf)
+	return string(b)
+}
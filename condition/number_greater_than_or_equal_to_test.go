@@ -0,0 +1,59 @@
+package condition
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jshlbrd/vibestation/config"
+	"github.com/jshlbrd/vibestation/message"
+)
+
+func TestNumberGreaterThanOrEqualTo(t *testing.T) {
+	tests := []struct {
+		settings map[string]interface{}
+		data     string
+		expected bool
+	}{
+		{map[string]interface{}{"source": "$.status", "value": 400}, `{"status":400}`, true},
+		{map[string]interface{}{"source": "$.status", "value": 400}, `{"status":404}`, true},
+		{map[string]interface{}{"source": "$.status", "value": 400}, `{"status":399.5}`, false},
+		{map[string]interface{}{"source": "$.status", "value": 400}, `{"status":"400"}`, true},
+		{map[string]interface{}{"source": "$.status", "value": 400}, `{"status":"NaN"}`, false},
+		{map[string]interface{}{"source": "$.status", "value": 400}, `{}`, false},
+		{map[string]interface{}{"value": 1.5}, `1.5`, true},
+		{map[string]interface{}{"value": 1.5}, `Inf`, false},
+	}
+
+	for _, test := range tests {
+		cnd, err := newNumberGreaterThanOrEqualTo(context.Background(), config.Config{
+			Type:     "number_greater_than_or_equal_to",
+			Settings: test.settings,
+		})
+		if err != nil {
+			t.Fatalf("failed to create number_greater_than_or_equal_to condition: %v", err)
+		}
+
+		ok, err := cnd.Condition(context.Background(), message.New().SetData([]byte(test.data)))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if ok != test.expected {
+			t.Errorf("%s: expected %v, got %v", test.data, test.expected, ok)
+		}
+	}
+}
+
+func TestNumberGreaterThanOrEqualTo_ControlMessage(t *testing.T) {
+	cnd, err := newNumberGreaterThanOrEqualTo(context.Background(), config.Config{Type: "number_greater_than_or_equal_to"})
+	if err != nil {
+		t.Fatalf("failed to create number_greater_than_or_equal_to condition: %v", err)
+	}
+
+	ok, err := cnd.Condition(context.Background(), message.New().AsControl())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if ok {
+		t.Error("expected control message not to match")
+	}
+}
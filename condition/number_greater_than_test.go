@@ -0,0 +1,59 @@
+package condition
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jshlbrd/vibestation/config"
+	"github.com/jshlbrd/vibestation/message"
+)
+
+func TestNumberGreaterThan(t *testing.T) {
+	tests := []struct {
+		settings map[string]interface{}
+		data     string
+		expected bool
+	}{
+		{map[string]interface{}{"source": "$.status", "value": 399}, `{"status":404}`, true},
+		{map[string]interface{}{"source": "$.status", "value": 399}, `{"status":200}`, false},
+		{map[string]interface{}{"source": "$.status", "value": 399}, `{"status":"500"}`, true},
+		{map[string]interface{}{"source": "$.status", "value": 399}, `{"status":"error"}`, false},
+		{map[string]interface{}{"source": "$.status", "value": 399}, `{"status":"NaN"}`, false},
+		{map[string]interface{}{"source": "$.status", "value": 399}, `{"status":"+Inf"}`, false},
+		{map[string]interface{}{"source": "$.status", "value": 399}, `{}`, false},
+		{map[string]interface{}{"value": 1.5}, `2`, true},
+	}
+
+	for _, test := range tests {
+		cnd, err := newNumberGreaterThan(context.Background(), config.Config{
+			Type:     "number_greater_than",
+			Settings: test.settings,
+		})
+		if err != nil {
+			t.Fatalf("failed to create number_greater_than condition: %v", err)
+		}
+
+		ok, err := cnd.Condition(context.Background(), message.New().SetData([]byte(test.data)))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if ok != test.expected {
+			t.Errorf("%s: expected %v, got %v", test.data, test.expected, ok)
+		}
+	}
+}
+
+func TestNumberGreaterThan_ControlMessage(t *testing.T) {
+	cnd, err := newNumberGreaterThan(context.Background(), config.Config{Type: "number_greater_than"})
+	if err != nil {
+		t.Fatalf("failed to create number_greater_than condition: %v", err)
+	}
+
+	ok, err := cnd.Condition(context.Background(), message.New().AsControl())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if ok {
+		t.Error("expected control message not to match")
+	}
+}
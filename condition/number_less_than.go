@@ -0,0 +1,65 @@
+package condition
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/jshlbrd/vibestation/config"
+	"github.com/jshlbrd/vibestation/message"
+)
+
+type NumberLessThanConfig struct {
+	Value float64 `json:"value"`
+	ID    string  `json:"id"`
+}
+
+func (c *NumberLessThanConfig) Decode(in interface{}) error {
+	return config.Decode(in, c)
+}
+
+func newNumberLessThan(_ context.Context, cfg config.Config) (*NumberLessThan, error) {
+	conf := NumberLessThanConfig{}
+	if err := conf.Decode(cfg.Settings); err != nil {
+		return nil, fmt.Errorf("condition number_less_than: %v", err)
+	}
+	if conf.ID == "" {
+		conf.ID = "number_less_than"
+	}
+
+	var sourcePath string
+	if v, ok := cfg.Settings["source"]; ok {
+		if s, ok := v.(string); ok {
+			sourcePath = s
+		}
+	}
+
+	cnd := NumberLessThan{
+		conf:       conf,
+		settings:   cfg.Settings,
+		sourcePath: sourcePath,
+	}
+	return &cnd, nil
+}
+
+// NumberLessThan matches messages where the source (or data) is a number
+// less than Value. Non-numeric values do not match.
+type NumberLessThan struct {
+	conf       NumberLessThanConfig
+	settings   map[string]interface{}
+	sourcePath string
+}
+
+func (c *NumberLessThan) Condition(ctx context.Context, msg *message.Message) (bool, error) {
+	if msg.IsControl() {
+		return false, nil
+	}
+
+	n, ok := numberValue(msg, c.sourcePath)
+	return ok && n < c.conf.Value, nil
+}
+
+func (c *NumberLessThan) String() string {
+	b, _ := json.Marshal(c.conf)
+	return string(b)
+}
@@ -0,0 +1,65 @@
+package condition
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/jshlbrd/vibestation/config"
+	"github.com/jshlbrd/vibestation/message"
+)
+
+type NumberLessThanOrEqualToConfig struct {
+	Value float64 `json:"value"`
+	ID    string  `json:"id"`
+}
+
+func (c *NumberLessThanOrEqualToConfig) Decode(in interface{}) error {
+	return config.Decode(in, c)
+}
+
+func newNumberLessThanOrEqualTo(_ context.Context, cfg config.Config) (*NumberLessThanOrEqualTo, error) {
+	conf := NumberLessThanOrEqualToConfig{}
+	if err := conf.Decode(cfg.Settings); err != nil {
+		return nil, fmt.Errorf("condition number_less_than_or_equal_to: %v", err)
+	}
+	if conf.ID == "" {
+		conf.ID = "number_less_than_or_equal_to"
+	}
+
+	var sourcePath string
+	if v, ok := cfg.Settings["source"]; ok {
+		if s, ok := v.(string); ok {
+			sourcePath = s
+		}
+	}
+
+	cnd := NumberLessThanOrEqualTo{
+		conf:       conf,
+		settings:   cfg.Settings,
+		sourcePath: sourcePath,
+	}
+	return &cnd, nil
+}
+
+// NumberLessThanOrEqualTo matches messages where the source (or data) is a
+// number less than or equal to Value. Non-numeric values do not match.
+type NumberLessThanOrEqualTo struct {
+	conf       NumberLessThanOrEqualToConfig
+	settings   map[string]interface{}
+	sourcePath string
+}
+
+func (c *NumberLessThanOrEqualTo) Condition(ctx context.Context, msg *message.Message) (bool, error) {
+	if msg.IsControl() {
+		return false, nil
+	}
+
+	n, ok := numberValue(msg, c.sourcePath)
+	return ok && n <= c.conf.Value, nil
+}
+
+func (c *NumberLessThanOrEqualTo) String() string {
+	b, _ := json.Marshal(c.conf)
+	return string(b)
+}
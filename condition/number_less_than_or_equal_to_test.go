@@ -0,0 +1,58 @@
+package condition
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jshlbrd/vibestation/config"
+	"github.com/jshlbrd/vibestation/message"
+)
+
+func TestNumberLessThanOrEqualTo(t *testing.T) {
+	tests := []struct {
+		settings map[string]interface{}
+		data     string
+		expected bool
+	}{
+		{map[string]interface{}{"source": "$.latency", "value": 100}, `{"latency":100}`, true},
+		{map[string]interface{}{"source": "$.latency", "value": 100}, `{"latency":99.5}`, true},
+		{map[string]interface{}{"source": "$.latency", "value": 100}, `{"latency":100.5}`, false},
+		{map[string]interface{}{"source": "$.latency", "value": 100}, `{"latency":"NaN"}`, false},
+		{map[string]interface{}{"source": "$.latency", "value": 100}, `{}`, false},
+		{map[string]interface{}{"value": 0}, `0`, true},
+		{map[string]interface{}{"value": 0}, `-Inf`, false},
+	}
+
+	for _, test := range tests {
+		cnd, err := newNumberLessThanOrEqualTo(context.Background(), config.Config{
+			Type:     "number_less_than_or_equal_to",
+			Settings: test.settings,
+		})
+		if err != nil {
+			t.Fatalf("failed to create number_less_than_or_equal_to condition: %v", err)
+		}
+
+		ok, err := cnd.Condition(context.Background(), message.New().SetData([]byte(test.data)))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if ok != test.expected {
+			t.Errorf("%s: expected %v, got %v", test.data, test.expected, ok)
+		}
+	}
+}
+
+func TestNumberLessThanOrEqualTo_ControlMessage(t *testing.T) {
+	cnd, err := newNumberLessThanOrEqualTo(context.Background(), config.Config{Type: "number_less_than_or_equal_to"})
+	if err != nil {
+		t.Fatalf("failed to create number_less_than_or_equal_to condition: %v", err)
+	}
+
+	ok, err := cnd.Condition(context.Background(), message.New().AsControl())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if ok {
+		t.Error("expected control message not to match")
+	}
+}
@@ -0,0 +1,56 @@
+package condition
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jshlbrd/vibestation/config"
+	"github.com/jshlbrd/vibestation/message"
+)
+
+func TestNumberLessThan(t *testing.T) {
+	tests := []struct {
+		settings map[string]interface{}
+		data     string
+		expected bool
+	}{
+		{map[string]interface{}{"source": "$.latency", "value": 100}, `{"latency":99.5}`, true},
+		{map[string]interface{}{"source": "$.latency", "value": 100}, `{"latency":100}`, false},
+		{map[string]interface{}{"source": "$.latency", "value": 100}, `{"latency":"fast"}`, false},
+		{map[string]interface{}{"source": "$.latency", "value": 100}, `{}`, false},
+		{map[string]interface{}{"value": 0}, `-1`, true},
+	}
+
+	for _, test := range tests {
+		cnd, err := newNumberLessThan(context.Background(), config.Config{
+			Type:     "number_less_than",
+			Settings: test.settings,
+		})
+		if err != nil {
+			t.Fatalf("failed to create number_less_than condition: %v", err)
+		}
+
+		ok, err := cnd.Condition(context.Background(), message.New().SetData([]byte(test.data)))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if ok != test.expected {
+			t.Errorf("%s: expected %v, got %v", test.data, test.expected, ok)
+		}
+	}
+}
+
+func TestNumberLessThan_ControlMessage(t *testing.T) {
+	cnd, err := newNumberLessThan(context.Background(), config.Config{Type: "number_less_than"})
+	if err != nil {
+		t.Fatalf("failed to create number_less_than condition: %v", err)
+	}
+
+	ok, err := cnd.Condition(context.Background(), message.New().AsControl())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if ok {
+		t.Error("expected control message not to match")
+	}
+}
@@ -0,0 +1,69 @@
+package condition
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/jshlbrd/vibestation/config"
+	"github.com/jshlbrd/vibestation/message"
+)
+
+type StringContainsConfig struct {
+	Value string `json:"value"`
+	ID    string `json:"id"`
+}
+
+func (c *StringContainsConfig) Decode(in interface{}) error {
+	return config.Decode(in, c)
+}
+
+func newStringContains(_ context.Context, cfg config.Config) (*StringContains, error) {
+	conf := StringContainsConfig{}
+	if err := conf.Decode(cfg.Settings); err != nil {
+		return nil, fmt.Errorf("condition string_contains: %v", err)
+	}
+	if conf.ID == "" {
+		conf.ID = "string_contains"
+	}
+
+	var sourcePath string
+	if v, ok := cfg.Settings["source"]; ok {
+		if s, ok := v.(string); ok {
+			sourcePath = s
+		}
+	}
+
+	cnd := StringContains{
+		conf:       conf,
+		settings:   cfg.Settings,
+		sourcePath: sourcePath,
+	}
+	return &cnd, nil
+}
+
+// StringContains matches messages where the source (or data) contains Value.
+type StringContains struct {
+	conf       StringContainsConfig
+	settings   map[string]interface{}
+	sourcePath string
+}
+
+func (c *StringContains) Condition(ctx context.Context, msg *message.Message) (bool, error) {
+	if msg.IsControl() {
+		return false, nil
+	}
+
+	if c.sourcePath == "" {
+		return strings.Contains(string(msg.Data()), c.conf.Value), nil
+	}
+
+	val := msg.GetValue(c.sourcePath)
+	return val.Exists() && strings.Contains(val.String(), c.conf.Value), nil
+}
+
+func (c *StringContains) String() string {
+	b, _ := json.Marshal(c.conf)
+	return string(b)
+}
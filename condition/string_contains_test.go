@@ -0,0 +1,37 @@
+package condition
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jshlbrd/vibestation/config"
+	"github.com/jshlbrd/vibestation/message"
+)
+
+func TestStringContains(t *testing.T) {
+	tests := []struct {
+		data     string
+		expected bool
+	}{
+		{`{"path":"/api/v1/users"}`, true},
+		{`{"path":"/static/app.js"}`, false},
+	}
+
+	cnd, err := newStringContains(context.Background(), config.Config{
+		Type:     "string_contains",
+		Settings: map[string]interface{}{"source": "$.path", "value": "/api/"},
+	})
+	if err != nil {
+		t.Fatalf("failed to create string_contains condition: %v", err)
+	}
+
+	for _, test := range tests {
+		ok, err := cnd.Condition(context.Background(), message.New().SetData([]byte(test.data)))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if ok != test.expected {
+			t.Errorf("%s: expected %v, got %v", test.data, test.expected, ok)
+		}
+	}
+}
@@ -0,0 +1,68 @@
+package condition
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/jshlbrd/vibestation/config"
+	"github.com/jshlbrd/vibestation/message"
+)
+
+type StringEqualToConfig struct {
+	Value string `json:"value"`
+	ID    string `json:"id"`
+}
+
+func (c *StringEqualToConfig) Decode(in interface{}) error {
+	return config.Decode(in, c)
+}
+
+func newStringEqualTo(_ context.Context, cfg config.Config) (*StringEqualTo, error) {
+	conf := StringEqualToConfig{}
+	if err := conf.Decode(cfg.Settings); err != nil {
+		return nil, fmt.Errorf("condition string_equal_to: %v", err)
+	}
+	if conf.ID == "" {
+		conf.ID = "string_equal_to"
+	}
+
+	var sourcePath string
+	if v, ok := cfg.Settings["source"]; ok {
+		if s, ok := v.(string); ok {
+			sourcePath = s
+		}
+	}
+
+	cnd := StringEqualTo{
+		conf:       conf,
+		settings:   cfg.Settings,
+		sourcePath: sourcePath,
+	}
+	return &cnd, nil
+}
+
+// StringEqualTo matches messages where the source (or data) is equal to Value.
+type StringEqualTo struct {
+	conf       StringEqualToConfig
+	settings   map[string]interface{}
+	sourcePath string
+}
+
+func (c *StringEqualTo) Condition(ctx context.Context, msg *message.Message) (bool, error) {
+	if msg.IsControl() {
+		return false, nil
+	}
+
+	if c.sourcePath == "" {
+		return string(msg.Data()) == c.conf.Value, nil
+	}
+
+	val := msg.GetValue(c.sourcePath)
+	return val.Exists() && val.String() == c.conf.Value, nil
+}
+
+func (c *StringEqualTo) String() string {
+	b, _ := json.Marshal(c.conf)
+	return string(b)
+}
@@ -0,0 +1,55 @@
+package condition
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jshlbrd/vibestation/config"
+	"github.com/jshlbrd/vibestation/message"
+)
+
+func TestStringEqualTo(t *testing.T) {
+	tests := []struct {
+		settings map[string]interface{}
+		data     string
+		expected bool
+	}{
+		{map[string]interface{}{"source": "$.level", "value": "error"}, `{"level":"error"}`, true},
+		{map[string]interface{}{"source": "$.level", "value": "error"}, `{"level":"info"}`, false},
+		{map[string]interface{}{"source": "$.level", "value": "error"}, `{}`, false},
+		{map[string]interface{}{"value": "error"}, `error`, true},
+	}
+
+	for _, test := range tests {
+		cnd, err := newStringEqualTo(context.Background(), config.Config{
+			Type:     "string_equal_to",
+			Settings: test.settings,
+		})
+		if err != nil {
+			t.Fatalf("failed to create string_equal_to condition: %v", err)
+		}
+
+		ok, err := cnd.Condition(context.Background(), message.New().SetData([]byte(test.data)))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if ok != test.expected {
+			t.Errorf("%s: expected %v, got %v", test.data, test.expected, ok)
+		}
+	}
+}
+
+func TestStringEqualTo_ControlMessage(t *testing.T) {
+	cnd, err := newStringEqualTo(context.Background(), config.Config{Type: "string_equal_to"})
+	if err != nil {
+		t.Fatalf("failed to create string_equal_to condition: %v", err)
+	}
+
+	ok, err := cnd.Condition(context.Background(), message.New().AsControl())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if ok {
+		t.Error("expected control message not to match")
+	}
+}
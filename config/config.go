@@ -3,6 +3,9 @@ package config
 
 import (
 	"encoding/json"
+	"reflect"
+	"strconv"
+	"strings"
 )
 
 // Config is a template used by Substation interface factories to produce new
@@ -18,3 +21,115 @@ func (c Config) String() string {
 	b, _ := json.Marshal(c)
 	return string(b)
 }
+
+// Decode decodes settings into out, which must be a pointer to a struct, by
+// round-tripping them through JSON. Numbers are accepted for string fields so
+// that unquoted values in SUB scripts, such as key=12345, can be used for
+// string settings.
+func Decode(in interface{}, out interface{}) error {
+	if in == nil {
+		return nil
+	}
+	if m, ok := in.(map[string]interface{}); ok {
+		in = stringifyNumbers(m, out)
+	}
+
+	b, err := json.Marshal(in)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(b, out)
+}
+
+// stringifyNumbers returns a copy of settings where numbers that are decoded
+// into string or string slice fields of out are converted to strings.
+func stringifyNumbers(settings map[string]interface{}, out interface{}) map[string]interface{} {
+	t := reflect.TypeOf(out)
+	for t != nil && t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t == nil || t.Kind() != reflect.Struct {
+		return settings
+	}
+
+	var result map[string]interface{}
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+		if name == "-" || !f.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+
+		v, ok := settings[name]
+		if !ok {
+			continue
+		}
+
+		ft := f.Type
+		if ft.Kind() == reflect.Pointer {
+			ft = ft.Elem()
+		}
+
+		var converted interface{}
+		switch {
+		case ft.Kind() == reflect.String:
+			if s, ok := numberString(v); ok {
+				converted = s
+			}
+		case ft.Kind() == reflect.Slice && ft.Elem().Kind() == reflect.String:
+			arr, ok := v.([]interface{})
+			if !ok {
+				continue
+			}
+			var elems []interface{}
+			for j, e := range arr {
+				if s, ok := numberString(e); ok {
+					if elems == nil {
+						elems = append([]interface{}(nil), arr...)
+					}
+					elems[j] = s
+				}
+			}
+			if elems != nil {
+				converted = elems
+			}
+		}
+		if converted == nil {
+			continue
+		}
+
+		if result == nil {
+			result = make(map[string]interface{}, len(settings))
+			for k, v := range settings {
+				result[k] = v
+			}
+		}
+		result[name] = converted
+	}
+
+	if result == nil {
+		return settings
+	}
+
+	return result
+}
+
+// numberString returns the string form of a numeric value.
+func numberString(v interface{}) (string, bool) {
+	switch n := v.(type) {
+	case int:
+		return strconv.Itoa(n), true
+	case int64:
+		return strconv.FormatInt(n, 10), true
+	case float64:
+		return strconv.FormatFloat(n, 'f', -1, 64), true
+	case json.Number:
+		return n.String(), true
+	}
+
+	return "", false
+}
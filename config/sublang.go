@@ -2,11 +2,21 @@
 package config
 
 import (
+	"errors"
 	"fmt"
+	"regexp"
 	"strconv"
 	"strings"
 )
 
+// intLiteral and floatLiteral match the plain decimal values that are coerced
+// to numbers. Leading zeros, exponents, hex values, and special values such as
+// NaN or Inf are kept as strings.
+var (
+	intLiteral   = regexp.MustCompile(`^-?(0|[1-9][0-9]*)$`)
+	floatLiteral = regexp.MustCompile(`^-?(0|[1-9][0-9]*)\.[0-9]+$`)
+)
+
 // Parser parses SUB sublang configuration
 type Parser struct{}
 
@@ -15,12 +25,28 @@ func NewParser() *Parser {
 	return &Parser{}
 }
 
-// Parse parses SUB sublang and returns a list of transforms
+// Parse parses SUB sublang and returns a list of transforms. Errors report
+// the 1-based line number of the invalid line.
 func (p *Parser) Parse(sublang string) ([]map[string]interface{}, error) {
+	return p.parse(sublang, true)
+}
+
+// ParseAll parses SUB sublang like Parse, but does not stop at the first
+// invalid line. Transforms are returned for every valid line, and the errors
+// for all invalid lines are joined into a single error that reports the
+// 1-based line number of each.
+func (p *Parser) ParseAll(sublang string) ([]map[string]interface{}, error) {
+	return p.parse(sublang, false)
+}
+
+// parse parses each line of sublang. If stop is true, then parsing stops at
+// the first invalid line and no transforms are returned.
+func (p *Parser) parse(sublang string, stop bool) ([]map[string]interface{}, error) {
 	var transforms []map[string]interface{}
+	var errs []error
 	lines := strings.Split(sublang, "\n")
 
-	for _, line := range lines {
+	for i, line := range lines {
 		line = strings.TrimSpace(line)
 		if line == "" || strings.HasPrefix(line, "#") {
 			continue
@@ -28,16 +54,28 @@ func (p *Parser) Parse(sublang string) ([]map[string]interface{}, error) {
 
 		transform, err := p.parseLine(line)
 		if err != nil {
-			return nil, err
+			if stop {
+				return nil, lineError(i+1, err)
+			}
+			errs = append(errs, lineError(i+1, err))
+			continue
 		}
 		transforms = append(transforms, transform...)
 	}
 
-	return transforms, nil
+	return transforms, errors.Join(errs...)
 }
 
-// parseLine parses a single line and returns transforms
+// lineError annotates err with the 1-based line number it occurred on.
+func lineError(line int, err error) error {
+	return fmt.Errorf("line %d: %v", line, err)
+}
+
+// parseLine parses a single line and returns transforms. Surrounding
+// whitespace is trimmed, so nested function calls are handled like lines.
 func (p *Parser) parseLine(line string) ([]map[string]interface{}, error) {
+	line = strings.TrimSpace(line)
+
 	// Handle direct assignment: $.target = $.source
 	if p.isDirectAssignment(line) {
 		return p.parseDirectAssignment(line)
@@ -152,7 +190,9 @@ func (p *Parser) parseFunctionCallWithTarget(line, target string) ([]map[string]
 	for key, value := range settings {
 		if strings.HasPrefix(key, "nested_arg_") {
 			if nestedFunc, ok := value.(string); ok {
-				nested, err := p.Parse(nestedFunc)
+				// Nested functions are parsed as part of the current line,
+				// so errors are reported with the line number of the caller.
+				nested, err := p.parseLine(nestedFunc)
 				if err != nil {
 					return nil, fmt.Errorf("error parsing nested function %s: %v", nestedFunc, err)
 				}
@@ -296,7 +336,7 @@ func (p *Parser) processNamedArgument(arg string, settings map[string]interface{
 		settings[fmt.Sprintf("nested_arg_%d", *nestedArgIndex)] = value
 		*nestedArgIndex++
 	} else {
-		settings[key] = p.unquoteValue(value)
+		settings[key] = p.coerceValue(value)
 	}
 
 	return nil
@@ -352,12 +392,94 @@ func (p *Parser) processPositionalArgument(funcName, arg string, settings map[st
 // isBuiltinTransform checks if function name is a built-in transform
 func (p *Parser) isBuiltinTransform(funcName string) bool {
 	builtins := map[string]bool{
-		"split_string":     true,
-		"decompress_gzip":  true,
-		"send_stdout":      true,
-		"decode_base64":    true,
-		"lowercase_string": true,
-		"delete":           true,
+		"split_string":         true,
+		"decompress_gzip":      true,
+		"send_stdout":          true,
+		"decode_base64":        true,
+		"lowercase_string":     true,
+		"delete":               true,
+		"verify_hmac":          true,
+		"decode_jwt":           true,
+		"split_json_stream":    true,
+		"array_to_ndjson":      true,
+		"rotate_array":         true,
+		"unique_array":         true,
+		"array_length":         true,
+		"prefix_metadata":      true,
+		"on_control":           true,
+		"split_first":          true,
+		"ip_classify":          true,
+		"parse_url":            true,
+		"crc32":                true,
+		"detect_encoding":      true,
+		"latin1_to_utf8":       true,
+		"truncate":             true,
+		"decode_base32":        true,
+		"encode_base32":        true,
+		"apply_json_patch":     true,
+		"merge_patch":          true,
+		"collect_object":       true,
+		"route":                true,
+		"sample":               true,
+		"rate_limit":           true,
+		"parse_clf":            true,
+		"parse_syslog":         true,
+		"field_math":           true,
+		"set_if":               true,
+		"redact_keys":          true,
+		"hash_keys":            true,
+		"debounce":             true,
+		"dedupe_window":        true,
+		"metrics":              true,
+		"parse_duration":       true,
+		"humanize_bytes":       true,
+		"percentile":           true,
+		"pivot":                true,
+		"unpivot":              true,
+		"compress_gzip":        true,
+		"split_csv_records":    true,
+		"flatten_array":        true,
+		"zip_arrays":           true,
+		"reduce_array":         true,
+		"allow_keys":           true,
+		"deny_keys":            true,
+		"format_string":        true,
+		"split_pointer":        true,
+		"assert_json":          true,
+		"envelope":             true,
+		"promote":              true,
+		"split_indented":       true,
+		"normalize_whitespace": true,
+		"yaml_to_json":         true,
+		"json_to_yaml":         true,
+		"ini_to_json":          true,
+		"edit_distance":        true,
+		"parse_time_auto":      true,
+		"send_labeled":         true,
+		"set_ops":              true,
+		"chunk_array":          true,
+		"field_lengths":        true,
+		"sequence_id":          true,
+		"snapshot_to_meta":     true,
+		"restore_from_meta":    true,
+		"diff_previous":        true,
+		"enrich_json":          true,
+		"assert_range":         true,
+		"ensure_array":         true,
+		"drop_blank":           true,
+		"rename_case":          true,
+		"sign_ed25519":         true,
+		"verify_ed25519":       true,
+		"encode_base64":        true,
+		"parse_mime":           true,
+		"ewma":                 true,
+		"trim_string":          true,
+		"replace_string":       true,
+		"require_text":         true,
+		"chunk_text":           true,
+		"canonicalize_json":    true,
+		"cut_field":            true,
+		"normalize_bool":       true,
 	}
 	return builtins[funcName]
 }
@@ -386,6 +508,28 @@ func (p *Parser) processCustomPositionalArgument(arg string, settings map[string
 	return nil
 }
 
+// coerceValue converts an unquoted boolean or plain decimal value to its native
+// type, otherwise it behaves like unquoteValue. Quoted values are always strings.
+func (p *Parser) coerceValue(value string) interface{} {
+	switch value {
+	case "true":
+		return true
+	case "false":
+		return false
+	}
+	if intLiteral.MatchString(value) {
+		if num, err := strconv.Atoi(value); err == nil {
+			return num
+		}
+	}
+	if floatLiteral.MatchString(value) {
+		if num, err := strconv.ParseFloat(value, 64); err == nil {
+			return num
+		}
+	}
+	return p.unquoteValue(value)
+}
+
 // unquoteValue unquotes a value if it's quoted
 func (p *Parser) unquoteValue(value string) interface{} {
 	if len(value) > 1 && ((value[0] == '"' && value[len(value)-1] == '"') || (value[0] == '\'' && value[len(value)-1] == '\'')) {
@@ -420,6 +564,252 @@ func (p *Parser) setDefaultSettings(funcName string, settings map[string]interfa
 		"delete": {
 			"id": "delete",
 		},
+		"verify_hmac": {
+			"id": "verify_hmac",
+		},
+		"decode_jwt": {
+			"id": "decode_jwt",
+		},
+		"split_json_stream": {
+			"id": "split_json_stream",
+		},
+		"array_to_ndjson": {
+			"id": "array_to_ndjson",
+		},
+		"rotate_array": {
+			"id": "rotate_array",
+		},
+		"unique_array": {
+			"id": "unique_array",
+		},
+		"array_length": {
+			"id": "array_length",
+		},
+		"prefix_metadata": {
+			"id": "prefix_metadata",
+		},
+		"on_control": {
+			"id": "on_control",
+		},
+		"split_first": {
+			"id": "split_first",
+		},
+		"ip_classify": {
+			"id": "ip_classify",
+		},
+		"parse_url": {
+			"id": "parse_url",
+		},
+		"crc32": {
+			"id": "crc32",
+		},
+		"detect_encoding": {
+			"id": "detect_encoding",
+		},
+		"latin1_to_utf8": {
+			"id": "latin1_to_utf8",
+		},
+		"truncate": {
+			"id": "truncate",
+		},
+		"decode_base32": {
+			"id": "decode_base32",
+		},
+		"encode_base32": {
+			"id": "encode_base32",
+		},
+		"apply_json_patch": {
+			"id": "apply_json_patch",
+		},
+		"merge_patch": {
+			"id": "merge_patch",
+		},
+		"collect_object": {
+			"id": "collect_object",
+		},
+		"route": {
+			"id": "route",
+		},
+		"sample": {
+			"id": "sample",
+		},
+		"rate_limit": {
+			"id": "rate_limit",
+		},
+		"parse_clf": {
+			"id": "parse_clf",
+		},
+		"parse_syslog": {
+			"id": "parse_syslog",
+		},
+		"field_math": {
+			"id": "field_math",
+		},
+		"set_if": {
+			"id": "set_if",
+		},
+		"redact_keys": {
+			"id": "redact_keys",
+		},
+		"hash_keys": {
+			"id": "hash_keys",
+		},
+		"debounce": {
+			"id": "debounce",
+		},
+		"dedupe_window": {
+			"id": "dedupe_window",
+		},
+		"metrics": {
+			"id": "metrics",
+		},
+		"parse_duration": {
+			"id": "parse_duration",
+		},
+		"humanize_bytes": {
+			"id": "humanize_bytes",
+		},
+		"percentile": {
+			"id": "percentile",
+		},
+		"pivot": {
+			"id": "pivot",
+		},
+		"unpivot": {
+			"id": "unpivot",
+		},
+		"compress_gzip": {
+			"id": "compress_gzip",
+		},
+		"split_csv_records": {
+			"id": "split_csv_records",
+		},
+		"flatten_array": {
+			"id": "flatten_array",
+		},
+		"zip_arrays": {
+			"id": "zip_arrays",
+		},
+		"reduce_array": {
+			"id": "reduce_array",
+		},
+		"allow_keys": {
+			"id": "allow_keys",
+		},
+		"deny_keys": {
+			"id": "deny_keys",
+		},
+		"format_string": {
+			"id": "format_string",
+		},
+		"split_pointer": {
+			"id": "split_pointer",
+		},
+		"assert_json": {
+			"id": "assert_json",
+		},
+		"envelope": {
+			"id": "envelope",
+		},
+		"promote": {
+			"id": "promote",
+		},
+		"split_indented": {
+			"id": "split_indented",
+		},
+		"normalize_whitespace": {
+			"id": "normalize_whitespace",
+		},
+		"yaml_to_json": {
+			"id": "yaml_to_json",
+		},
+		"json_to_yaml": {
+			"id": "json_to_yaml",
+		},
+		"ini_to_json": {
+			"id": "ini_to_json",
+		},
+		"edit_distance": {
+			"id": "edit_distance",
+		},
+		"parse_time_auto": {
+			"id": "parse_time_auto",
+		},
+		"send_labeled": {
+			"id": "send_labeled",
+		},
+		"set_ops": {
+			"id": "set_ops",
+		},
+		"chunk_array": {
+			"id": "chunk_array",
+		},
+		"field_lengths": {
+			"id": "field_lengths",
+		},
+		"sequence_id": {
+			"id": "sequence_id",
+		},
+		"snapshot_to_meta": {
+			"id": "snapshot_to_meta",
+		},
+		"restore_from_meta": {
+			"id": "restore_from_meta",
+		},
+		"diff_previous": {
+			"id": "diff_previous",
+		},
+		"enrich_json": {
+			"id": "enrich_json",
+		},
+		"assert_range": {
+			"id": "assert_range",
+		},
+		"ensure_array": {
+			"id": "ensure_array",
+		},
+		"drop_blank": {
+			"id": "drop_blank",
+		},
+		"rename_case": {
+			"id": "rename_case",
+		},
+		"sign_ed25519": {
+			"id": "sign_ed25519",
+		},
+		"verify_ed25519": {
+			"id": "verify_ed25519",
+		},
+		"encode_base64": {
+			"id": "encode_base64",
+		},
+		"parse_mime": {
+			"id": "parse_mime",
+		},
+		"ewma": {
+			"id": "ewma",
+		},
+		"trim_string": {
+			"id": "trim_string",
+		},
+		"replace_string": {
+			"id": "replace_string",
+		},
+		"require_text": {
+			"id": "require_text",
+		},
+		"chunk_text": {
+			"id": "chunk_text",
+		},
+		"canonicalize_json": {
+			"id": "canonicalize_json",
+		},
+		"cut_field": {
+			"id": "cut_field",
+		},
+		"normalize_bool": {
+			"id": "normalize_bool",
+		},
 	}
 
 	if defaults, ok := defaults[funcName]; ok {
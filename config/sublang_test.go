@@ -1,6 +1,8 @@
 package config
 
 import (
+	"reflect"
+	"strings"
 	"testing"
 )
 
@@ -186,11 +188,103 @@ custom_function("arg1", "arg2", key="value", number=42, boolean=true)`
 	if configs[1]["key"] != "value" {
 		t.Errorf("Expected key 'value', got '%v'", configs[1]["key"])
 	}
-	if configs[1]["number"] != "42" {
-		t.Errorf("Expected number '42', got '%v'", configs[1]["number"])
+	if configs[1]["number"] != 42 {
+		t.Errorf("Expected number 42, got '%v'", configs[1]["number"])
 	}
-	if configs[1]["boolean"] != "true" {
-		t.Errorf("Expected boolean 'true', got '%v'", configs[1]["boolean"])
+	if configs[1]["boolean"] != true {
+		t.Errorf("Expected boolean true, got '%v'", configs[1]["boolean"])
+	}
+}
+
+func TestParserArgumentTypes(t *testing.T) {
+	parser := NewParser()
+	sub := `custom_function(count=3, ratio=0.5, enabled=false, quoted_number="42", quoted_bool="true", path=$.foo)`
+
+	configs, err := parser.Parse(sub)
+	if err != nil {
+		t.Fatalf("Failed to parse SUB: %v", err)
+	}
+
+	expected := map[string]interface{}{
+		"count":         3,
+		"ratio":         0.5,
+		"enabled":       false,
+		"quoted_number": "42",
+		"quoted_bool":   "true",
+		"path":          "$.foo",
+	}
+	for key, want := range expected {
+		if got := configs[0][key]; got != want {
+			t.Errorf("Expected %s to be %#v, got %#v", key, want, got)
+		}
+	}
+}
+
+func TestParserNonDecimalValues(t *testing.T) {
+	parser := NewParser()
+	sub := `custom_function(a=nan, b=inf, c=-Infinity, d=0x1p-2, e=1e3, f=0123)`
+
+	configs, err := parser.Parse(sub)
+	if err != nil {
+		t.Fatalf("Failed to parse SUB: %v", err)
+	}
+
+	expected := map[string]interface{}{
+		"a": "nan",
+		"b": "inf",
+		"c": "-Infinity",
+		"d": "0x1p-2",
+		"e": "1e3",
+		"f": "0123",
+	}
+	for key, want := range expected {
+		if got := configs[0][key]; got != want {
+			t.Errorf("Expected %s to be %#v, got %#v", key, want, got)
+		}
+	}
+
+	// Every value must still be encodable as JSON.
+	var conf struct {
+		A string `json:"a"`
+	}
+	if err := Decode(configs[0], &conf); err != nil {
+		t.Fatalf("Failed to decode settings: %v", err)
+	}
+}
+
+func TestParserNumericStringSetting(t *testing.T) {
+	parser := NewParser()
+	sub := `verify_hmac(key=12345, algorithm="sha256", ratio=0.5)`
+
+	configs, err := parser.Parse(sub)
+	if err != nil {
+		t.Fatalf("Failed to parse SUB: %v", err)
+	}
+
+	var conf struct {
+		Key       string  `json:"key"`
+		Algorithm string  `json:"algorithm"`
+		Ratio     float64 `json:"ratio"`
+	}
+	if err := Decode(configs[0], &conf); err != nil {
+		t.Fatalf("Failed to decode settings: %v", err)
+	}
+	if conf.Key != "12345" {
+		t.Errorf("Expected key %q, got %q", "12345", conf.Key)
+	}
+	if conf.Ratio != 0.5 {
+		t.Errorf("Expected ratio 0.5, got %v", conf.Ratio)
+	}
+
+	// Numbers are also accepted in string slices.
+	var list struct {
+		Values []string `json:"values"`
+	}
+	if err := Decode(map[string]interface{}{"values": []interface{}{1, "b", 2.5}}, &list); err != nil {
+		t.Fatalf("Failed to decode settings: %v", err)
+	}
+	if want := []string{"1", "b", "2.5"}; !reflect.DeepEqual(list.Values, want) {
+		t.Errorf("Expected values %v, got %v", want, list.Values)
 	}
 }
 
@@ -315,6 +409,30 @@ send_stdout(source=$.result)`
 	}
 }
 
+func TestParserEncodeBase64(t *testing.T) {
+	parser := NewParser()
+	configs, err := parser.Parse(`$.out = encode_base64(source=$.in)`)
+	if err != nil {
+		t.Fatalf("Failed to parse SUB: %v", err)
+	}
+	if len(configs) != 1 {
+		t.Fatalf("Expected 1 config, got %d", len(configs))
+	}
+
+	if configs[0]["type"] != "encode_base64" {
+		t.Errorf("Expected type 'encode_base64', got '%s'", configs[0]["type"])
+	}
+	if configs[0]["id"] != "encode_base64" {
+		t.Errorf("Expected id 'encode_base64', got '%v'", configs[0]["id"])
+	}
+	if configs[0]["source"] != "$.in" {
+		t.Errorf("Expected source '$.in', got '%v'", configs[0]["source"])
+	}
+	if configs[0]["target"] != "$.out" {
+		t.Errorf("Expected target '$.out', got '%v'", configs[0]["target"])
+	}
+}
+
 func TestParserRejectsPositionalArgsForBuiltins(t *testing.T) {
 	tests := []string{
 		`split_string("|")`,
@@ -491,3 +609,84 @@ send_stdout()`
 		t.Errorf("Expected type 'send_stdout', got '%s'", configs[1]["type"])
 	}
 }
+
+func TestParserParseAll(t *testing.T) {
+	sub := `lowercase_string()
+invalid_function
+# comment
+truncate(length=5)
+foo =`
+
+	parser := NewParser()
+	transforms, err := parser.ParseAll(sub)
+	if err == nil {
+		t.Fatal("Expected error for invalid lines, got none")
+	}
+
+	for _, want := range []string{"line 2:", "line 5:"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("Expected error to contain %q, got: %v", want, err)
+		}
+	}
+
+	if len(transforms) != 2 {
+		t.Fatalf("Expected 2 transforms for valid lines, got %d", len(transforms))
+	}
+	if transforms[0]["type"] != "lowercase_string" || transforms[1]["type"] != "truncate" {
+		t.Errorf("Unexpected transforms: %v", transforms)
+	}
+}
+
+func TestParserParseAllIndentedNested(t *testing.T) {
+	sub := "  $.result = lowercase_string(source= decode_base64(source=$.encoded_data\t) )\r\n" +
+		"\t\tsend_stdout(source=$.result)\n" +
+		"    # indented comment\n"
+
+	parser := NewParser()
+	want, err := parser.Parse(sub)
+	if err != nil {
+		t.Fatalf("Failed to parse SUB: %v", err)
+	}
+	got, err := parser.ParseAll(sub)
+	if err != nil {
+		t.Fatalf("Failed to parse SUB: %v", err)
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected ParseAll to match Parse\nParse:    %v\nParseAll: %v", want, got)
+	}
+	if len(got) != 3 {
+		t.Fatalf("Expected 3 transforms, got %d: %v", len(got), got)
+	}
+	if got[0]["type"] != "decode_base64" || got[0]["source"] != "$.encoded_data" {
+		t.Errorf("Unexpected nested transform: %v", got[0])
+	}
+	if got[1]["type"] != "lowercase_string" || got[1]["source"] != "$.nested_output" {
+		t.Errorf("Unexpected outer transform: %v", got[1])
+	}
+}
+
+func TestParserErrorLineNumbers(t *testing.T) {
+	testCases := []struct {
+		name string
+		sub  string
+		want string
+	}{
+		{"first line", "invalid_function", "line 1: invalid SUB line format: invalid_function"},
+		{"after comments and blanks", "# comment\n\nlowercase_string()\nfoo =", "line 4:"},
+		{"invalid argument", "lowercase_string()\nsplit_string(bogus)", "line 2:"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			parser := NewParser()
+			_, err := parser.Parse(tc.sub)
+			if err == nil {
+				t.Fatalf("Expected error for '%s', but got none", tc.sub)
+			}
+			if !strings.HasPrefix(err.Error(), tc.want) {
+				t.Errorf("Expected error to start with %q, got: %v", tc.want, err)
+			}
+		})
+	}
+}
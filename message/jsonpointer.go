@@ -0,0 +1,156 @@
+package message
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// NewJSONPointer creates a JSONPath from an RFC 6901 JSON Pointer (e.g.,
+// /foo/bar, /arr/0). The tokens "~1" and "~0" are unescaped to "/" and "~".
+// The empty pointer refers to the whole document.
+//
+// Unlike NewJSONPath, keys that contain "." or "[" are not split, so any key
+// can be addressed.
+func NewJSONPointer(pointer string) (*JSONPath, error) {
+	if pointer == "" {
+		return &JSONPath{parts: []string{}}, nil
+	}
+	if !strings.HasPrefix(pointer, "/") {
+		return nil, fmt.Errorf("invalid JSON pointer %q: must be empty or start with /", pointer)
+	}
+
+	tokens := strings.Split(pointer[1:], "/")
+	for i, t := range tokens {
+		// The order matters: "~01" is "~1", not "/".
+		t = strings.ReplaceAll(t, "~1", "/")
+		tokens[i] = strings.ReplaceAll(t, "~0", "~")
+	}
+
+	return &JSONPath{parts: tokens}, nil
+}
+
+// pointerSet sets value at the location of tokens in doc and returns the
+// updated document. Missing objects along the way are created. For arrays, the
+// index equal to the length of the array or the "-" token appends the value,
+// and an existing index is replaced, or if insert is true, the value is
+// inserted before it as the RFC 6902 "add" operation does.
+func pointerSet(doc interface{}, tokens []string, value interface{}, insert bool) (interface{}, error) {
+	if len(tokens) == 0 {
+		return value, nil
+	}
+
+	tok, rest := tokens[0], tokens[1:]
+	switch v := doc.(type) {
+	case nil:
+		child, err := pointerSet(nil, rest, value, insert)
+		if err != nil {
+			return nil, err
+		}
+		return map[string]interface{}{tok: child}, nil
+	case map[string]interface{}:
+		child, err := pointerSet(v[tok], rest, value, insert)
+		if err != nil {
+			return nil, err
+		}
+		v[tok] = child
+		return v, nil
+	case []interface{}:
+		idx := len(v)
+		if tok != "-" {
+			i, err := pointerIndex(tok)
+			if err != nil {
+				return nil, err
+			}
+			idx = i
+		}
+
+		switch {
+		case idx < len(v) && insert && len(rest) == 0:
+			v = append(v, nil)
+			copy(v[idx+1:], v[idx:])
+			v[idx] = value
+			return v, nil
+		case idx < len(v):
+			child, err := pointerSet(v[idx], rest, value, insert)
+			if err != nil {
+				return nil, err
+			}
+			v[idx] = child
+			return v, nil
+		case idx == len(v) && len(rest) == 0:
+			return append(v, value), nil
+		default:
+			return nil, fmt.Errorf("array index %q out of range", tok)
+		}
+	default:
+		return nil, fmt.Errorf("cannot set %q in non-object/non-array", tok)
+	}
+}
+
+// pointerDelete removes the value at the location of tokens in doc and returns
+// the updated document. Array elements are removed and the elements that
+// follow are shifted down. Missing locations are ignored.
+func pointerDelete(doc interface{}, tokens []string) (interface{}, error) {
+	if len(tokens) == 0 {
+		return doc, nil
+	}
+
+	tok, rest := tokens[0], tokens[1:]
+	switch v := doc.(type) {
+	case map[string]interface{}:
+		child, ok := v[tok]
+		if !ok {
+			return v, nil
+		}
+		if len(rest) == 0 {
+			delete(v, tok)
+			return v, nil
+		}
+
+		child, err := pointerDelete(child, rest)
+		if err != nil {
+			return nil, err
+		}
+		v[tok] = child
+		return v, nil
+	case []interface{}:
+		if tok == "-" {
+			return v, nil
+		}
+		idx, err := pointerIndex(tok)
+		if err != nil {
+			return nil, err
+		}
+		if idx >= len(v) {
+			return v, nil
+		}
+		if len(rest) == 0 {
+			return append(v[:idx], v[idx+1:]...), nil
+		}
+
+		child, err := pointerDelete(v[idx], rest)
+		if err != nil {
+			return nil, err
+		}
+		v[idx] = child
+		return v, nil
+	default:
+		return doc, nil
+	}
+}
+
+// pointerIndex parses an array index token. RFC 6901 only allows "0" or a
+// decimal number without leading zeros.
+func pointerIndex(tok string) (int, error) {
+	if tok == "" || (len(tok) > 1 && tok[0] == '0') {
+		return 0, fmt.Errorf("invalid array index %q", tok)
+	}
+
+	idx, err := strconv.Atoi(tok)
+	if err != nil || idx < 0 {
+		return 0, fmt.Errorf("invalid array index %q", tok)
+	}
+
+	return idx, nil
+}
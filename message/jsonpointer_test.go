@@ -0,0 +1,55 @@
+package message
+
+import (
+	"testing"
+)
+
+func TestJSONPointer_Get(t *testing.T) {
+	data := []byte(`{"a":{"b":[1,2]},"c/d":"slash","e~f":"tilde","g.h":"dot","~1":"literal"}`)
+
+	tests := []struct {
+		pointer  string
+		expected interface{}
+		exists   bool
+	}{
+		{"/a/b/1", float64(2), true},
+		{"/c~1d", "slash", true},
+		{"/e~0f", "tilde", true},
+		{"/g.h", "dot", true},
+		{"/~01", "literal", true},
+		{"/a/x", nil, false},
+	}
+
+	for _, test := range tests {
+		p, err := NewJSONPointer(test.pointer)
+		if err != nil {
+			t.Fatalf("NewJSONPointer(%q) error: %v", test.pointer, err)
+		}
+
+		val, err := p.Get(data)
+		if (err == nil) != test.exists {
+			t.Errorf("Get(%q) exists = %v, want %v", test.pointer, err == nil, test.exists)
+		}
+		if err == nil && val != test.expected {
+			t.Errorf("Get(%q) = %v, want %v", test.pointer, val, test.expected)
+		}
+	}
+}
+
+func TestJSONPointer_Invalid(t *testing.T) {
+	if _, err := NewJSONPointer("a/b"); err == nil {
+		t.Error("expected error for pointer without leading /, got nil")
+	}
+
+	p, err := NewJSONPointer("")
+	if err != nil {
+		t.Fatalf("unexpected error for empty pointer: %v", err)
+	}
+	val, err := p.Get([]byte(`{"a":1}`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, ok := val.(map[string]interface{}); !ok {
+		t.Errorf("expected empty pointer to return the whole document, got %v", val)
+	}
+}
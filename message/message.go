@@ -40,6 +40,10 @@ type Message struct {
 	//
 	// Control messages trigger special behavior in transforms and conditions.
 	ctrl bool
+
+	// ordered is a flag that indicates if object key order is preserved when
+	// values are set or deleted. See WithOrderedKeys.
+	ordered bool
 }
 
 // String returns the message data as a string.
@@ -57,6 +61,23 @@ func New(opts ...func(*Message)) *Message {
 	return msg
 }
 
+// Clone returns a deep copy of the message. Changes to the copy do not affect
+// the original message.
+func (m *Message) Clone() *Message {
+	c := &Message{
+		ctrl:    m.ctrl,
+		ordered: m.ordered,
+	}
+	if m.data != nil {
+		c.data = append([]byte(nil), m.data...)
+	}
+	if m.meta != nil {
+		c.meta = append([]byte(nil), m.meta...)
+	}
+
+	return c
+}
+
 // AsControl sets the message as a control message.
 func (m *Message) AsControl() *Message {
 	m.data = nil
@@ -121,49 +142,50 @@ func isValidJSONPath(path string) bool {
 // - Data: "$.foo", "$.nested.field"
 // - Metadata: "meta.$.foo", "meta.$.nested.field"
 //
-// If the path is not valid, returns a non-existent value.
+// If the path is not valid, returns a non-existent value. The returned value
+// always records the path it was retrieved from (see Value.Path).
 func (m *Message) GetValue(path string) Value {
 	path = strings.TrimSpace(path)
 	if !isValidJSONPath(path) {
-		return Value{value: nil, exists: false}
+		return Value{value: nil, exists: false, path: path}
 	}
 
 	if path == "$" {
 		// Return the entire data object
 		var obj interface{}
 		if err := json.Unmarshal(m.data, &obj); err != nil {
-			return Value{value: nil, exists: false}
+			return Value{value: nil, exists: false, path: path}
 		}
-		return Value{value: obj, exists: true}
+		return Value{value: obj, exists: true, path: path}
 	}
 	if path == "meta.$" {
 		// Return the entire metadata object
 		var obj interface{}
 		if err := json.Unmarshal(m.meta, &obj); err != nil {
-			return Value{value: nil, exists: false}
+			return Value{value: nil, exists: false, path: path}
 		}
-		return Value{value: obj, exists: true}
+		return Value{value: obj, exists: true, path: path}
 	}
 
 	if strings.HasPrefix(path, "meta.$.") {
 		jsonPath := NewJSONPath(path)
 		val, err := jsonPath.Get(m.meta)
 		if err != nil {
-			return Value{value: nil, exists: false}
+			return Value{value: nil, exists: false, path: path}
 		}
-		return Value{value: val, exists: true}
+		return Value{value: val, exists: true, path: path}
 	}
 
 	if strings.HasPrefix(path, "$.") {
 		jsonPath := NewJSONPath(path)
 		val, err := jsonPath.Get(m.data)
 		if err != nil {
-			return Value{value: nil, exists: false}
+			return Value{value: nil, exists: false, path: path}
 		}
-		return Value{value: val, exists: true}
+		return Value{value: val, exists: true, path: path}
 	}
 
-	return Value{value: nil, exists: false}
+	return Value{value: nil, exists: false, path: path}
 }
 
 // SetValue sets a value in the message data or metadata using a JSON path.
@@ -185,6 +207,11 @@ func (m *Message) SetValue(path string, value interface{}) error {
 		if err != nil {
 			return err
 		}
+		if m.ordered {
+			if data, err = reorder(m.data, data); err != nil {
+				return err
+			}
+		}
 		m.data = data
 		return nil
 	}
@@ -194,6 +221,11 @@ func (m *Message) SetValue(path string, value interface{}) error {
 		if err != nil {
 			return err
 		}
+		if m.ordered {
+			if meta, err = reorder(m.meta, meta); err != nil {
+				return err
+			}
+		}
 		m.meta = meta
 		return nil
 	}
@@ -204,6 +236,11 @@ func (m *Message) SetValue(path string, value interface{}) error {
 		if err != nil {
 			return err
 		}
+		if m.ordered {
+			if meta, err = reorder(m.meta, meta); err != nil {
+				return err
+			}
+		}
 		m.meta = meta
 		return nil
 	}
@@ -214,6 +251,11 @@ func (m *Message) SetValue(path string, value interface{}) error {
 		if err != nil {
 			return err
 		}
+		if m.ordered {
+			if data, err = reorder(m.data, data); err != nil {
+				return err
+			}
+		}
 		m.data = data
 		return nil
 	}
@@ -251,6 +293,11 @@ func (m *Message) DeleteValue(path string) error {
 		if err != nil {
 			return err
 		}
+		if m.ordered {
+			if meta, err = reorder(m.meta, meta); err != nil {
+				return err
+			}
+		}
 		m.meta = meta
 		return nil
 	}
@@ -261,6 +308,11 @@ func (m *Message) DeleteValue(path string) error {
 		if err != nil {
 			return err
 		}
+		if m.ordered {
+			if data, err = reorder(m.data, data); err != nil {
+				return err
+			}
+		}
 		m.data = data
 		return nil
 	}
@@ -268,10 +320,113 @@ func (m *Message) DeleteValue(path string) error {
 	return fmt.Errorf("invalid JSONPath: %s", path)
 }
 
+// GetPointer returns a value from the message data using an RFC 6901 JSON
+// Pointer (e.g. "/foo/0"). If the pointer is invalid or the value does not
+// exist, then a non-existent value is returned. Unlike GetValue, the returned
+// value does not record a path.
+func (m *Message) GetPointer(pointer string) Value {
+	p, err := NewJSONPointer(pointer)
+	if err != nil {
+		return Value{}
+	}
+
+	val, err := p.Get(m.data)
+	if err != nil {
+		return Value{}
+	}
+	return Value{value: val, exists: true}
+}
+
+// SetPointer sets a value in the message data using an RFC 6901 JSON
+// Pointer. Missing objects are created. For arrays, an existing index is
+// replaced, and the index equal to the length of the array or the "-" token
+// appends the value. If the pointer is invalid, returns an error.
+func (m *Message) SetPointer(pointer string, value interface{}) error {
+	return m.setPointer(pointer, value, false)
+}
+
+// AddPointer adds a value to the message data using an RFC 6901 JSON Pointer,
+// following the RFC 6902 "add" operation. It behaves like SetPointer, except
+// that a value added at an existing array index is inserted before that
+// element instead of replacing it. If the pointer is invalid, returns an error.
+func (m *Message) AddPointer(pointer string, value interface{}) error {
+	return m.setPointer(pointer, value, true)
+}
+
+func (m *Message) setPointer(pointer string, value interface{}, insert bool) error {
+	p, err := NewJSONPointer(pointer)
+	if err != nil {
+		return err
+	}
+
+	var doc interface{}
+	if len(m.data) > 0 {
+		if err := json.Unmarshal(m.data, &doc); err != nil {
+			return err
+		}
+	}
+	if doc, err = pointerSet(doc, p.parts, value, insert); err != nil {
+		return fmt.Errorf("invalid JSON pointer %q: %v", pointer, err)
+	}
+
+	data, err := json.Marshal(doc)
+	if err != nil {
+		return err
+	}
+	if m.ordered {
+		if data, err = reorder(m.data, data); err != nil {
+			return err
+		}
+	}
+	m.data = data
+	return nil
+}
+
+// DeletePointer deletes a value in the message data using an RFC 6901 JSON
+// Pointer. Array elements are removed and the elements that follow are
+// shifted down. Missing values are ignored. If the pointer is invalid, returns
+// an error.
+func (m *Message) DeletePointer(pointer string) error {
+	p, err := NewJSONPointer(pointer)
+	if err != nil {
+		return err
+	}
+	if pointer == "" {
+		// Delete entire data object
+		m.data = []byte(`{}`)
+		return nil
+	}
+	if len(m.data) == 0 {
+		return nil
+	}
+
+	var doc interface{}
+	if err := json.Unmarshal(m.data, &doc); err != nil {
+		return err
+	}
+	if doc, err = pointerDelete(doc, p.parts); err != nil {
+		return fmt.Errorf("invalid JSON pointer %q: %v", pointer, err)
+	}
+
+	data, err := json.Marshal(doc)
+	if err != nil {
+		return err
+	}
+	if m.ordered {
+		if data, err = reorder(m.data, data); err != nil {
+			return err
+		}
+	}
+	m.data = data
+	return nil
+}
+
 // Value provides access to JSON values returned by GetValue.
 type Value struct {
 	value  interface{}
 	exists bool
+	// path is the JSON path the value was retrieved from.
+	path string
 }
 
 // Value returns the underlying value.
@@ -279,6 +434,12 @@ func (v Value) Value() any {
 	return v.value
 }
 
+// Path returns the JSON path the value was retrieved from. Elements returned
+// by Array and Map extend the path of their parent (e.g. "$.a[0]", "$.a.b").
+func (v Value) Path() string {
+	return v.path
+}
+
 // String returns the value as a string.
 func (v Value) String() string {
 	if v.value == nil {
@@ -379,6 +540,10 @@ func (v Value) Float() float64 {
 		return float64(n)
 	case int64:
 		return float64(n)
+	case uint:
+		return float64(n)
+	case uint64:
+		return float64(n)
 	case string:
 		if f, err := strconv.ParseFloat(n, 64); err == nil {
 			return f
@@ -414,11 +579,16 @@ func (v Value) Array() []Value {
 	case []interface{}:
 		result := make([]Value, len(arr))
 		for i, item := range arr {
-			result[i] = Value{value: item, exists: true}
+			result[i] = Value{value: item, exists: true, path: v.childPath(strconv.Itoa(i))}
 		}
 		return result
 	case []Value:
-		return arr
+		result := make([]Value, len(arr))
+		for i, item := range arr {
+			item.path = v.childPath(strconv.Itoa(i))
+			result[i] = item
+		}
+		return result
 	}
 	return nil
 }
@@ -443,21 +613,133 @@ func (v Value) Map() map[string]Value {
 	switch m := v.value.(type) {
 	case map[string]interface{}:
 		result := make(map[string]Value)
-		for k, v := range m {
-			result[k] = Value{value: v, exists: true}
+		for k, val := range m {
+			result[k] = Value{value: val, exists: true, path: v.childPath(k)}
 		}
 		return result
 	case map[string]Value:
-		return m
+		result := make(map[string]Value, len(m))
+		for k, val := range m {
+			val.path = v.childPath(k)
+			result[k] = val
+		}
+		return result
 	}
 	return nil
 }
 
+// childPath returns the path of an array index or object key within v. Keys
+// that contain path syntax are quoted in brackets (e.g. $["b.c"]).
+func (v Value) childPath(key string) string {
+	if v.path == "" {
+		return ""
+	}
+	if v.IsArray() {
+		return v.path + "[" + key + "]"
+	}
+	if strings.ContainsAny(key, ".[]") {
+		return v.path + "[" + strconv.Quote(key) + "]"
+	}
+
+	return v.path + "." + key
+}
+
 // Exists returns true if the value exists.
 func (v Value) Exists() bool {
 	return v.exists && v.value != nil
 }
 
+// Compare returns -1, 0, or +1 depending on whether v sorts before, equal
+// to, or after other.
+//
+// Numbers are compared numerically and strings are compared lexically.
+// Values of different types are ordered by type: missing and null values,
+// then booleans (false before true), numbers, strings, arrays, and objects.
+// Arrays are compared element by element, and objects are compared by their
+// JSON encoding.
+func (v Value) Compare(other Value) int {
+	rv, ro := v.typeRank(), other.typeRank()
+	if rv != ro {
+		return compareInts(rv, ro)
+	}
+
+	switch rv {
+	case rankBool:
+		return compareInts(boolRank(v.Bool()), boolRank(other.Bool()))
+	case rankNumber:
+		a, b := v.Float(), other.Float()
+		switch {
+		case a < b:
+			return -1
+		case a > b:
+			return 1
+		}
+		return 0
+	case rankString:
+		return strings.Compare(v.String(), other.String())
+	case rankArray:
+		a, b := v.Array(), other.Array()
+		for i := 0; i < len(a) && i < len(b); i++ {
+			if c := a[i].Compare(b[i]); c != 0 {
+				return c
+			}
+		}
+		return compareInts(len(a), len(b))
+	case rankObject:
+		a, _ := json.Marshal(v.value)
+		b, _ := json.Marshal(other.value)
+		return strings.Compare(string(a), string(b))
+	}
+	return 0
+}
+
+// Type ranks used by Compare to order values of different types.
+const (
+	rankNull = iota
+	rankBool
+	rankNumber
+	rankString
+	rankArray
+	rankObject
+)
+
+func (v Value) typeRank() int {
+	if !v.Exists() {
+		return rankNull
+	}
+
+	switch v.value.(type) {
+	case bool:
+		return rankBool
+	case float64, int, int64, uint, uint64:
+		return rankNumber
+	case string:
+		return rankString
+	case []interface{}, []Value:
+		return rankArray
+	case map[string]interface{}, map[string]Value:
+		return rankObject
+	}
+	return rankNull
+}
+
+func boolRank(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+func compareInts(a, b int) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
 func deleteValue(json []byte, key string) ([]byte, error) {
 	if len(json) == 0 {
 		return json, nil
@@ -239,3 +239,316 @@ func TestMessageDebug(t *testing.T) {
 	val = msg.GetValue("$.data_field")
 	t.Logf("GetValue('$.data_field') = %v, exists = %v", val.Value(), val.Exists())
 }
+
+func TestValuePath(t *testing.T) {
+	msg := New()
+	msg.SetData([]byte(`{"a": {"b": [1, {"c": 2}]}}`))
+	msg.SetMetadata([]byte(`{"m": true}`))
+
+	paths := []string{"$", "$.a.b", "$.a.b[1].c", "$.missing", "meta.$.m", "invalid"}
+	for _, path := range paths {
+		if got := msg.GetValue(path).Path(); got != path {
+			t.Errorf("GetValue(%q).Path() = %q, want %q", path, got, path)
+		}
+	}
+
+	arr := msg.GetValue("$.a.b").Array()
+	if got := arr[1].Path(); got != "$.a.b[1]" {
+		t.Errorf("Array()[1].Path() = %q, want %q", got, "$.a.b[1]")
+	}
+
+	m := arr[1].Map()
+	if got := m["c"].Path(); got != "$.a.b[1].c" {
+		t.Errorf("Map()[c].Path() = %q, want %q", got, "$.a.b[1].c")
+	}
+	if got := msg.GetValue(m["c"].Path()).Int(); got != 2 {
+		t.Errorf("GetValue(%q) = %d, want 2", m["c"].Path(), got)
+	}
+
+	// Values that already hold Values also set the path of their children.
+	nested := Value{
+		value: []Value{
+			{value: map[string]Value{"d": {value: 3.0, exists: true}}, exists: true},
+		},
+		exists: true,
+		path:   "$.x",
+	}
+	elem := nested.Array()[0]
+	if got := elem.Path(); got != "$.x[0]" {
+		t.Errorf("Array()[0].Path() = %q, want %q", got, "$.x[0]")
+	}
+	if got := elem.Map()["d"].Path(); got != "$.x[0].d" {
+		t.Errorf("Map()[d].Path() = %q, want %q", got, "$.x[0].d")
+	}
+}
+
+func TestMessageOrderedKeys(t *testing.T) {
+	tests := []struct {
+		name     string
+		data     string
+		apply    func(*Message) error
+		expected string
+	}{
+		{
+			"set existing",
+			`{"z":1,"a":{"y":2,"b":3},"m":[{"q":1,"p":2}]}`,
+			func(m *Message) error { return m.SetValue("$.a.b", 4) },
+			`{"z":1,"a":{"y":2,"b":4},"m":[{"q":1,"p":2}]}`,
+		},
+		{
+			"set new",
+			`{"z":1,"a":2}`,
+			func(m *Message) error { return m.SetValue("$.c", 3) },
+			`{"z":1,"a":2,"c":3}`,
+		},
+		{
+			"delete",
+			`{"z":1,"a":2,"m":3}`,
+			func(m *Message) error { return m.DeleteValue("$.a") },
+			`{"z":1,"m":3}`,
+		},
+		{
+			"set root",
+			`{"z":1,"a":{"y":2,"b":3}}`,
+			func(m *Message) error {
+				return m.SetValue("$", map[string]interface{}{
+					"z": 1,
+					"a": map[string]interface{}{"y": 2, "b": 4},
+					"c": 5,
+				})
+			},
+			`{"z":1,"a":{"y":2,"b":4},"c":5}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			msg := New(WithOrderedKeys()).SetData([]byte(test.data))
+			if err := test.apply(msg); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got := string(msg.Data()); got != test.expected {
+				t.Errorf("expected %s, got %s", test.expected, got)
+			}
+		})
+	}
+
+	// Setting the entire metadata object keeps its key order.
+	msg := New(WithOrderedKeys()).SetMetadata([]byte(`{"z":1,"a":2}`))
+	if err := msg.SetValue("meta.$", map[string]interface{}{"z": 3, "a": 4}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := string(msg.Metadata()); got != `{"z":3,"a":4}` {
+		t.Errorf("expected %s, got %s", `{"z":3,"a":4}`, got)
+	}
+
+	// Without the option, keys are sorted.
+	msg = New().SetData([]byte(`{"z":1,"a":2}`))
+	if err := msg.SetValue("$.c", 3); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := string(msg.Data()); got != `{"a":2,"c":3,"z":1}` {
+		t.Errorf("expected sorted keys, got %s", got)
+	}
+}
+
+func TestMessageClone(t *testing.T) {
+	msg := New().SetData([]byte(`{"a":1}`)).SetMetadata([]byte(`{"b":2}`))
+	clone := msg.Clone()
+
+	if err := clone.SetValue("$.a", 3); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := clone.SetValue("meta.$.b", 4); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got := string(msg.Data()); got != `{"a":1}` {
+		t.Errorf("expected original data to be unchanged, got %s", got)
+	}
+	if got := string(msg.Metadata()); got != `{"b":2}` {
+		t.Errorf("expected original metadata to be unchanged, got %s", got)
+	}
+	if got := clone.GetValue("$.a").Int(); got != 3 {
+		t.Errorf("expected clone data to be updated, got %d", got)
+	}
+
+	ctrl := New().AsControl().Clone()
+	if !ctrl.IsControl() {
+		t.Error("expected clone of control message to be a control message")
+	}
+}
+
+func TestValueCompare(t *testing.T) {
+	msg := New().SetData([]byte(`{"n1":2,"n2":10,"s1":"10","s2":"2","b":true,"a":[1,2],"a2":[1,3],"o":{"x":1},"z":null}`))
+
+	tests := []struct {
+		name     string
+		a, b     string
+		expected int
+	}{
+		{"number less", "$.n1", "$.n2", -1},
+		{"number greater", "$.n2", "$.n1", 1},
+		{"number equal", "$.n1", "$.n1", 0},
+		{"string lexical", "$.s1", "$.s2", -1},
+		{"string equal", "$.s2", "$.s2", 0},
+		{"array elements", "$.a", "$.a2", -1},
+		{"missing before bool", "$.missing", "$.b", -1},
+		{"null equals missing", "$.z", "$.missing", 0},
+		{"bool before number", "$.b", "$.n1", -1},
+		{"number before string", "$.n2", "$.s2", -1},
+		{"string before array", "$.s1", "$.a", -1},
+		{"object after array", "$.o", "$.a", 1},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := msg.GetValue(test.a).Compare(msg.GetValue(test.b)); got != test.expected {
+				t.Errorf("Compare(%s, %s) = %d, want %d", test.a, test.b, got, test.expected)
+			}
+		})
+	}
+
+	// Unsigned integers are compared numerically.
+	u1 := Value{value: uint(1), exists: true}
+	u2 := Value{value: uint64(2), exists: true}
+	if got := u1.Compare(u2); got != -1 {
+		t.Errorf("Compare(uint(1), uint64(2)) = %d, want -1", got)
+	}
+	if got := u2.Compare(Value{value: 1.5, exists: true}); got != 1 {
+		t.Errorf("Compare(uint64(2), 1.5) = %d, want 1", got)
+	}
+	if got := u2.Compare(Value{value: 2, exists: true}); got != 0 {
+		t.Errorf("Compare(uint64(2), 2) = %d, want 0", got)
+	}
+}
+
+func TestMessagePointer(t *testing.T) {
+	msg := New().SetData([]byte(`{"a":{"b":[1,2]},"c/d":"slash","e~f":"tilde"}`))
+
+	if got := msg.GetPointer("/a/b/1").Int(); got != 2 {
+		t.Errorf("GetPointer(/a/b/1) = %d, want 2", got)
+	}
+	if got := msg.GetPointer("/c~1d").String(); got != "slash" {
+		t.Errorf("GetPointer(/c~1d) = %q, want slash", got)
+	}
+	if got := msg.GetPointer("/e~0f").String(); got != "tilde" {
+		t.Errorf("GetPointer(/e~0f) = %q, want tilde", got)
+	}
+	if msg.GetPointer("/missing").Exists() {
+		t.Error("expected missing pointer not to exist")
+	}
+	if msg.GetPointer("invalid").Exists() {
+		t.Error("expected invalid pointer not to exist")
+	}
+
+	if err := msg.SetPointer("/x~1y/z", "new"); err != nil {
+		t.Fatalf("SetPointer() error: %v", err)
+	}
+	if got := msg.GetPointer("/x~1y/z").String(); got != "new" {
+		t.Errorf("GetPointer(/x~1y/z) = %q, want new", got)
+	}
+
+	if err := msg.DeletePointer("/c~1d"); err != nil {
+		t.Fatalf("DeletePointer() error: %v", err)
+	}
+	if err := msg.DeletePointer("/e~0f"); err != nil {
+		t.Fatalf("DeletePointer() error: %v", err)
+	}
+
+	expected := `{"a":{"b":[1,2]},"x/y":{"z":"new"}}`
+	if got := string(msg.Data()); got != expected {
+		t.Errorf("expected %s, got %s", expected, got)
+	}
+
+	if err := msg.SetPointer("no-slash", 1); err == nil {
+		t.Error("expected error for invalid pointer, got nil")
+	}
+}
+
+func TestMessagePointer_Arrays(t *testing.T) {
+	tests := []struct {
+		name     string
+		data     string
+		apply    func(*Message) error
+		expected string
+	}{
+		{
+			"set root array element",
+			`[1,2]`,
+			func(m *Message) error { return m.SetPointer("/0", 3) },
+			`[3,2]`,
+		},
+		{
+			"append with dash",
+			`{"a":[1,2]}`,
+			func(m *Message) error { return m.SetPointer("/a/-", 3) },
+			`{"a":[1,2,3]}`,
+		},
+		{
+			"append with length",
+			`[1]`,
+			func(m *Message) error { return m.SetPointer("/1", 2) },
+			`[1,2]`,
+		},
+		{
+			"set in nested array element",
+			`{"a":[{"b":1}]}`,
+			func(m *Message) error { return m.SetPointer("/a/0/b", 2) },
+			`{"a":[{"b":2}]}`,
+		},
+		{
+			"delete array element",
+			`{"a":[1,2,3]}`,
+			func(m *Message) error { return m.DeletePointer("/a/1") },
+			`{"a":[1,3]}`,
+		},
+		{
+			"delete root array element",
+			`[1,2,3]`,
+			func(m *Message) error { return m.DeletePointer("/0") },
+			`[2,3]`,
+		},
+		{
+			"delete missing element",
+			`{"a":[1]}`,
+			func(m *Message) error { return m.DeletePointer("/a/5") },
+			`{"a":[1]}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			msg := New().SetData([]byte(test.data))
+			if err := test.apply(msg); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got := string(msg.Data()); got != test.expected {
+				t.Errorf("expected %s, got %s", test.expected, got)
+			}
+		})
+	}
+
+	// AddPointer inserts into arrays instead of replacing.
+	msg := New().SetData([]byte(`{"a":[1,2]}`))
+	if err := msg.AddPointer("/a/1", 9); err != nil {
+		t.Fatalf("AddPointer() error: %v", err)
+	}
+	if err := msg.AddPointer("/a/-", 3); err != nil {
+		t.Fatalf("AddPointer() error: %v", err)
+	}
+	if err := msg.AddPointer("/b", true); err != nil {
+		t.Fatalf("AddPointer() error: %v", err)
+	}
+	if got := string(msg.Data()); got != `{"a":[1,9,2,3],"b":true}` {
+		t.Errorf("expected %s, got %s", `{"a":[1,9,2,3],"b":true}`, got)
+	}
+
+	invalid := []string{"/a/5", "/a/01", "/a/x", "/a/-/b"}
+	for _, pointer := range invalid {
+		msg := New().SetData([]byte(`{"a":[1]}`))
+		if err := msg.SetPointer(pointer, 1); err == nil {
+			t.Errorf("SetPointer(%q) expected error, got nil", pointer)
+		}
+	}
+}
@@ -0,0 +1,141 @@
+package message
+
+import (
+	"bytes"
+	"encoding/json"
+	"sort"
+)
+
+// WithOrderedKeys configures the message to preserve the order of object keys
+// when values are set or deleted. Existing keys keep their original position
+// and new keys are appended in sorted order.
+//
+// By default, SetValue and DeleteValue re-encode the data with json.Marshal,
+// which sorts object keys.
+func WithOrderedKeys() func(*Message) {
+	return func(m *Message) {
+		m.ordered = true
+	}
+}
+
+// reorder re-encodes updated so that object keys follow the order they had
+// in orig. If orig is not valid JSON, then updated is returned unchanged.
+func reorder(orig, updated []byte) ([]byte, error) {
+	if !json.Valid(orig) {
+		return updated, nil
+	}
+
+	var v interface{}
+	if err := json.Unmarshal(updated, &v); err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	if err := marshalOrdered(&buf, v, orig); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// marshalOrdered writes v to buf, ordering object keys by their position in
+// the corresponding value of orig.
+func marshalOrdered(buf *bytes.Buffer, v interface{}, orig json.RawMessage) error {
+	switch t := v.(type) {
+	case map[string]interface{}:
+		keys, children := objectKeys(orig)
+
+		// Keys from the original object come first, followed by new keys.
+		var newKeys []string
+		for k := range t {
+			if _, ok := children[k]; !ok {
+				newKeys = append(newKeys, k)
+			}
+		}
+		sort.Strings(newKeys)
+
+		buf.WriteByte('{')
+		first := true
+		for _, k := range append(keys, newKeys...) {
+			val, ok := t[k]
+			if !ok {
+				continue
+			}
+			if !first {
+				buf.WriteByte(',')
+			}
+			first = false
+
+			b, err := json.Marshal(k)
+			if err != nil {
+				return err
+			}
+			buf.Write(b)
+			buf.WriteByte(':')
+			if err := marshalOrdered(buf, val, children[k]); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte('}')
+		return nil
+	case []interface{}:
+		var elems []json.RawMessage
+		_ = json.Unmarshal(orig, &elems)
+
+		buf.WriteByte('[')
+		for i, val := range t {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+
+			var child json.RawMessage
+			if i < len(elems) {
+				child = elems[i]
+			}
+			if err := marshalOrdered(buf, val, child); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte(']')
+		return nil
+	default:
+		b, err := json.Marshal(v)
+		if err != nil {
+			return err
+		}
+		buf.Write(b)
+		return nil
+	}
+}
+
+// objectKeys returns the keys of a JSON object in their original order and
+// the raw value of each key. If raw is not an object, then both are empty.
+func objectKeys(raw json.RawMessage) ([]string, map[string]json.RawMessage) {
+	children := make(map[string]json.RawMessage)
+	if len(raw) == 0 {
+		return nil, children
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	if tok, err := dec.Token(); err != nil || tok != json.Delim('{') {
+		return nil, children
+	}
+
+	var keys []string
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return nil, make(map[string]json.RawMessage)
+		}
+		key, _ := tok.(string)
+
+		var val json.RawMessage
+		if err := dec.Decode(&val); err != nil {
+			return nil, make(map[string]json.RawMessage)
+		}
+		if _, ok := children[key]; !ok {
+			keys = append(keys, key)
+		}
+		children[key] = val
+	}
+	return keys, children
+}
@@ -0,0 +1,141 @@
+package transform
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/jshlbrd/vibestation/config"
+	"github.com/jshlbrd/vibestation/message"
+)
+
+type AllowKeysConfig struct {
+	// Keys is the list of keys that are kept.
+	Keys []string `json:"keys"`
+	// Recursive also filters keys in nested objects and arrays of objects.
+	// Otherwise only top-level keys are filtered.
+	Recursive bool   `json:"recursive"`
+	ID        string `json:"id"`
+}
+
+func (c *AllowKeysConfig) Decode(in interface{}) error {
+	return config.Decode(in, c)
+}
+
+func (c *AllowKeysConfig) Validate() error {
+	if len(c.Keys) == 0 {
+		return fmt.Errorf("keys: missing required option")
+	}
+	return nil
+}
+
+func newAllowKeys(_ context.Context, cfg config.Config) (*AllowKeys, error) {
+	conf := AllowKeysConfig{}
+	if err := conf.Decode(cfg.Settings); err != nil {
+		return nil, fmt.Errorf("transform allow_keys: %v", err)
+	}
+	if conf.ID == "" {
+		conf.ID = "allow_keys"
+	}
+	if err := conf.Validate(); err != nil {
+		return nil, fmt.Errorf("transform %s: %v", conf.ID, err)
+	}
+
+	var sourcePath string
+	if v, ok := cfg.Settings["source"]; ok {
+		if s, ok := v.(string); ok {
+			sourcePath = s
+		}
+	}
+
+	allowed := make(map[string]bool, len(conf.Keys))
+	for _, k := range conf.Keys {
+		allowed[k] = true
+	}
+
+	tf := AllowKeys{
+		conf:       conf,
+		settings:   cfg.Settings,
+		sourcePath: sourcePath,
+		allowed:    allowed,
+	}
+	return &tf, nil
+}
+
+// AllowKeys removes every key that is not in Keys from the data, or from the
+// object at the source path. This is the inverse of deny_keys.
+type AllowKeys struct {
+	conf       AllowKeysConfig
+	settings   map[string]interface{}
+	sourcePath string
+	allowed    map[string]bool
+}
+
+func (tf *AllowKeys) Transform(ctx context.Context, msg *message.Message) ([]*message.Message, error) {
+	if msg.IsControl() {
+		return []*message.Message{msg}, nil
+	}
+
+	var inputData []byte
+	if tf.sourcePath != "" {
+		val := msg.GetValue(tf.sourcePath)
+		if !val.Exists() {
+			return nil, fmt.Errorf("transform %s: source %s not found", tf.conf.ID, tf.sourcePath)
+		}
+		inputData = val.Bytes()
+	} else {
+		inputData = msg.Data()
+	}
+
+	data, err := decodeJSONNumber(inputData)
+	if err != nil {
+		return nil, fmt.Errorf("transform %s: %v", tf.conf.ID, err)
+	}
+	if _, ok := data.(map[string]interface{}); !ok {
+		return nil, fmt.Errorf("transform %s: input is not an object", tf.conf.ID)
+	}
+	data = tf.allow(data, true)
+
+	if tf.sourcePath != "" {
+		if err := msg.SetValue(tf.sourcePath, data); err != nil {
+			return nil, fmt.Errorf("transform %s: failed to set source: %v", tf.conf.ID, err)
+		}
+	} else {
+		b, err := json.Marshal(data)
+		if err != nil {
+			return nil, fmt.Errorf("transform %s: %v", tf.conf.ID, err)
+		}
+		msg.SetData(b)
+	}
+
+	return []*message.Message{msg}, nil
+}
+
+// allow removes keys that are not allowed from v. Nested values are only
+// filtered if the transform is recursive.
+func (tf *AllowKeys) allow(v interface{}, top bool) interface{} {
+	if !top && !tf.conf.Recursive {
+		return v
+	}
+
+	switch t := v.(type) {
+	case map[string]interface{}:
+		for k, val := range t {
+			if !tf.allowed[k] {
+				delete(t, k)
+				continue
+			}
+			t[k] = tf.allow(val, false)
+		}
+	case []interface{}:
+		for i, val := range t {
+			t[i] = tf.allow(val, false)
+		}
+	}
+	return v
+}
+
+func (tf *AllowKeys) String() string {
+	b, _ := json.Marshal(tf.conf)
+	return string(b)
+}
@@ -0,0 +1,73 @@
+package transform
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jshlbrd/vibestation/config"
+	"github.com/jshlbrd/vibestation/message"
+)
+
+func TestAllowKeys(t *testing.T) {
+	data := `{"id":1,"user":{"name":"alice","password":"x"},"token":"y"}`
+
+	tests := []struct {
+		name      string
+		recursive bool
+		expected  string
+	}{
+		{"top level", false, `{"id":1,"user":{"name":"alice","password":"x"}}`},
+		{"recursive", true, `{"id":1,"user":{}}`},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cfg := config.Config{
+				Type: "allow_keys",
+				Settings: map[string]interface{}{
+					"keys":      []interface{}{"id", "user"},
+					"recursive": test.recursive,
+				},
+			}
+			tf, err := newAllowKeys(context.Background(), cfg)
+			if err != nil {
+				t.Fatalf("failed to create allow_keys transform: %v", err)
+			}
+
+			results, err := tf.Transform(context.Background(), message.New().SetData([]byte(data)))
+			if err != nil {
+				t.Fatalf("transform failed: %v", err)
+			}
+
+			if got := string(results[0].Data()); got != test.expected {
+				t.Errorf("expected %s, got %s", test.expected, got)
+			}
+		})
+	}
+}
+
+func TestAllowKeys_RecursiveNested(t *testing.T) {
+	cfg := config.Config{
+		Type: "allow_keys",
+		Settings: map[string]interface{}{
+			"keys":      []interface{}{"user", "name"},
+			"recursive": true,
+			"source":    "$.event",
+		},
+	}
+	tf, err := newAllowKeys(context.Background(), cfg)
+	if err != nil {
+		t.Fatalf("failed to create allow_keys transform: %v", err)
+	}
+
+	data := `{"event":{"user":[{"name":"alice","password":"x"}],"secret":"y"},"other":true}`
+	results, err := tf.Transform(context.Background(), message.New().SetData([]byte(data)))
+	if err != nil {
+		t.Fatalf("transform failed: %v", err)
+	}
+
+	expected := `{"event":{"user":[{"name":"alice"}]},"other":true}`
+	if got := string(results[0].Data()); got != expected {
+		t.Errorf("expected %s, got %s", expected, got)
+	}
+}
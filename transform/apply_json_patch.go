@@ -0,0 +1,132 @@
+package transform
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/jshlbrd/vibestation/config"
+	"github.com/jshlbrd/vibestation/message"
+)
+
+// ApplyJSONPatchOperation is a single RFC 6902 operation. Path may be either
+// a JSON Pointer (e.g. "/a/b") or a JSONPath (e.g. "$.a.b").
+type ApplyJSONPatchOperation struct {
+	Op    string      `json:"op"`
+	Path  string      `json:"path"`
+	Value interface{} `json:"value"`
+}
+
+type ApplyJSONPatchConfig struct {
+	Operations []ApplyJSONPatchOperation `json:"operations"`
+	ID         string                    `json:"id"`
+}
+
+func (c *ApplyJSONPatchConfig) Decode(in interface{}) error {
+	return config.Decode(in, c)
+}
+
+func (c *ApplyJSONPatchConfig) Validate() error {
+	if len(c.Operations) == 0 {
+		return fmt.Errorf("operations: missing required option")
+	}
+	for i, op := range c.Operations {
+		switch op.Op {
+		case "add", "remove", "replace":
+		default:
+			return fmt.Errorf("operations[%d]: unsupported op %q", i, op.Op)
+		}
+		if op.Path == "" {
+			return fmt.Errorf("operations[%d]: missing path", i)
+		}
+	}
+	return nil
+}
+
+func newApplyJSONPatch(_ context.Context, cfg config.Config) (*ApplyJSONPatch, error) {
+	conf := ApplyJSONPatchConfig{}
+	if err := conf.Decode(cfg.Settings); err != nil {
+		return nil, fmt.Errorf("transform apply_json_patch: %v", err)
+	}
+	if conf.ID == "" {
+		conf.ID = "apply_json_patch"
+	}
+	if err := conf.Validate(); err != nil {
+		return nil, fmt.Errorf("transform %s: %v", conf.ID, err)
+	}
+
+	tf := ApplyJSONPatch{
+		conf:     conf,
+		settings: cfg.Settings,
+	}
+	return &tf, nil
+}
+
+// ApplyJSONPatch applies a sequence of RFC 6902 "add", "remove", and
+// "replace" operations to the message data. "remove" and "replace" return an
+// error if the path does not exist. For JSON Pointers, "add" inserts into
+// arrays and the "-" token appends to them.
+type ApplyJSONPatch struct {
+	conf     ApplyJSONPatchConfig
+	settings map[string]interface{}
+}
+
+func (tf *ApplyJSONPatch) Transform(ctx context.Context, msg *message.Message) ([]*message.Message, error) {
+	if msg.IsControl() {
+		return []*message.Message{msg}, nil
+	}
+
+	for _, op := range tf.conf.Operations {
+		var err error
+		if strings.HasPrefix(op.Path, "/") {
+			err = applyJSONPatchPointer(msg, op)
+		} else {
+			err = applyJSONPatchPath(msg, op)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("transform %s: %s %s: %v", tf.conf.ID, op.Op, op.Path, err)
+		}
+	}
+
+	return []*message.Message{msg}, nil
+}
+
+func (tf *ApplyJSONPatch) String() string {
+	b, _ := json.Marshal(tf.conf)
+	return string(b)
+}
+
+// applyJSONPatchPointer applies an operation whose path is a JSON Pointer.
+func applyJSONPatchPointer(msg *message.Message, op ApplyJSONPatchOperation) error {
+	if op.Op != "add" && !msg.GetPointer(op.Path).Exists() {
+		return fmt.Errorf("path does not exist")
+	}
+
+	switch op.Op {
+	case "add":
+		return msg.AddPointer(op.Path, op.Value)
+	case "replace":
+		return msg.SetPointer(op.Path, op.Value)
+	case "remove":
+		return msg.DeletePointer(op.Path)
+	}
+
+	return nil
+}
+
+// applyJSONPatchPath applies an operation whose path is a JSONPath.
+func applyJSONPatchPath(msg *message.Message, op ApplyJSONPatchOperation) error {
+	if op.Op != "add" && !msg.GetValue(op.Path).Exists() {
+		return fmt.Errorf("path does not exist")
+	}
+
+	switch op.Op {
+	case "add", "replace":
+		return msg.SetValue(op.Path, op.Value)
+	case "remove":
+		return msg.DeleteValue(op.Path)
+	}
+
+	return nil
+}
@@ -0,0 +1,136 @@
+package transform
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jshlbrd/vibestation/config"
+	"github.com/jshlbrd/vibestation/message"
+)
+
+func TestApplyJSONPatch(t *testing.T) {
+	cfg := config.Config{
+		Type: "apply_json_patch",
+		Settings: map[string]interface{}{
+			"operations": []interface{}{
+				map[string]interface{}{"op": "add", "path": "/user/role", "value": "admin"},
+				map[string]interface{}{"op": "remove", "path": "/debug"},
+				map[string]interface{}{"op": "replace", "path": "$.user.name", "value": "bob"},
+			},
+		},
+	}
+	tf, err := newApplyJSONPatch(context.Background(), cfg)
+	if err != nil {
+		t.Fatalf("failed to create apply_json_patch transform: %v", err)
+	}
+
+	msg := message.New().SetData([]byte(`{"user":{"name":"alice"},"debug":true}`))
+	results, err := tf.Transform(context.Background(), msg)
+	if err != nil {
+		t.Fatalf("transform failed: %v", err)
+	}
+
+	expected := `{"user":{"name":"bob","role":"admin"}}`
+	if string(results[0].Data()) != expected {
+		t.Errorf("expected %s, got %s", expected, string(results[0].Data()))
+	}
+}
+
+func TestApplyJSONPatch_Pointer(t *testing.T) {
+	tests := []struct {
+		name     string
+		ops      []interface{}
+		data     string
+		expected string
+	}{
+		{
+			"key with dot and bracket",
+			[]interface{}{
+				map[string]interface{}{"op": "add", "path": "/a.b", "value": 1},
+				map[string]interface{}{"op": "add", "path": "/c[0]", "value": 2},
+			},
+			`{}`,
+			`{"a.b":1,"c[0]":2}`,
+		},
+		{
+			"insert into array",
+			[]interface{}{
+				map[string]interface{}{"op": "add", "path": "/arr/0", "value": 9},
+			},
+			`{"arr":[1,2]}`,
+			`{"arr":[9,1,2]}`,
+		},
+		{
+			"append to array",
+			[]interface{}{
+				map[string]interface{}{"op": "add", "path": "/arr/-", "value": 3},
+			},
+			`{"arr":[1,2]}`,
+			`{"arr":[1,2,3]}`,
+		},
+		{
+			"replace and remove array elements",
+			[]interface{}{
+				map[string]interface{}{"op": "replace", "path": "/arr/0", "value": 9},
+				map[string]interface{}{"op": "remove", "path": "/arr/1"},
+			},
+			`{"arr":[1,2,3]}`,
+			`{"arr":[9,3]}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cfg := config.Config{
+				Type:     "apply_json_patch",
+				Settings: map[string]interface{}{"operations": test.ops},
+			}
+			tf, err := newApplyJSONPatch(context.Background(), cfg)
+			if err != nil {
+				t.Fatalf("failed to create apply_json_patch transform: %v", err)
+			}
+
+			results, err := tf.Transform(context.Background(), message.New().SetData([]byte(test.data)))
+			if err != nil {
+				t.Fatalf("transform failed: %v", err)
+			}
+			if got := string(results[0].Data()); got != test.expected {
+				t.Errorf("expected %s, got %s", test.expected, got)
+			}
+		})
+	}
+}
+
+func TestApplyJSONPatch_MissingPath(t *testing.T) {
+	cfg := config.Config{
+		Type: "apply_json_patch",
+		Settings: map[string]interface{}{
+			"operations": []interface{}{
+				map[string]interface{}{"op": "replace", "path": "/missing", "value": 1},
+			},
+		},
+	}
+	tf, err := newApplyJSONPatch(context.Background(), cfg)
+	if err != nil {
+		t.Fatalf("failed to create apply_json_patch transform: %v", err)
+	}
+
+	msg := message.New().SetData([]byte(`{"a":1}`))
+	if _, err := tf.Transform(context.Background(), msg); err == nil {
+		t.Fatal("expected error for missing path, got nil")
+	}
+}
+
+func TestApplyJSONPatch_InvalidOp(t *testing.T) {
+	cfg := config.Config{
+		Type: "apply_json_patch",
+		Settings: map[string]interface{}{
+			"operations": []interface{}{
+				map[string]interface{}{"op": "move", "path": "/a"},
+			},
+		},
+	}
+	if _, err := newApplyJSONPatch(context.Background(), cfg); err == nil {
+		t.Fatal("expected error for unsupported op, got nil")
+	}
+}
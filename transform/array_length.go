@@ -0,0 +1,88 @@
+package transform
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/jshlbrd/vibestation/config"
+	"github.com/jshlbrd/vibestation/message"
+)
+
+type ArrayLengthConfig struct {
+	// Strict returns an error if the source is not an array, otherwise the
+	// length is written as 0.
+	Strict bool   `json:"strict"`
+	ID     string `json:"id"`
+}
+
+func (c *ArrayLengthConfig) Decode(in interface{}) error {
+	return config.Decode(in, c)
+}
+
+func newArrayLength(_ context.Context, cfg config.Config) (*ArrayLength, error) {
+	conf := ArrayLengthConfig{}
+	if err := conf.Decode(cfg.Settings); err != nil {
+		return nil, fmt.Errorf("transform array_length: %v", err)
+	}
+	if conf.ID == "" {
+		conf.ID = "array_length"
+	}
+
+	sourcePath := "$"
+	if v, ok := cfg.Settings["source"]; ok {
+		if s, ok := v.(string); ok && s != "" {
+			sourcePath = s
+		}
+	}
+
+	var targetPath string
+	if v, ok := cfg.Settings["target"]; ok {
+		if s, ok := v.(string); ok {
+			targetPath = s
+		}
+	}
+
+	tf := ArrayLength{
+		conf:       conf,
+		settings:   cfg.Settings,
+		sourcePath: sourcePath,
+		targetPath: targetPath,
+	}
+	return &tf, nil
+}
+
+type ArrayLength struct {
+	conf       ArrayLengthConfig
+	settings   map[string]interface{}
+	sourcePath string
+	targetPath string
+}
+
+func (tf *ArrayLength) Transform(ctx context.Context, msg *message.Message) ([]*message.Message, error) {
+	if msg.IsControl() {
+		return []*message.Message{msg}, nil
+	}
+
+	val := msg.GetValue(tf.sourcePath)
+	if !val.IsArray() && tf.conf.Strict {
+		return nil, fmt.Errorf("transform %s: source %s is not an array", tf.conf.ID, tf.sourcePath)
+	}
+	length := len(val.Array())
+
+	if tf.targetPath != "" {
+		if err := msg.SetValue(tf.targetPath, length); err != nil {
+			return nil, fmt.Errorf("transform %s: failed to set target: %v", tf.conf.ID, err)
+		}
+	} else {
+		msg.SetData([]byte(strconv.Itoa(length)))
+	}
+
+	return []*message.Message{msg}, nil
+}
+
+func (tf *ArrayLength) String() string {
+	b, _ := json.Marshal(tf.conf)
+	return string(b)
+}
@@ -0,0 +1,82 @@
+package transform
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jshlbrd/vibestation/config"
+	"github.com/jshlbrd/vibestation/message"
+)
+
+func TestArrayLength(t *testing.T) {
+	tests := []struct {
+		data     string
+		expected int64
+	}{
+		{`{"events":[]}`, 0},
+		{`{"events":[1]}`, 1},
+		{`{"events":[{"a":1},{"b":2},"c"]}`, 3},
+	}
+
+	cfg := config.Config{
+		Type: "array_length",
+		Settings: map[string]interface{}{
+			"source": "$.events",
+			"target": "$.event_count",
+		},
+	}
+	tf, err := newArrayLength(context.Background(), cfg)
+	if err != nil {
+		t.Fatalf("failed to create array_length transform: %v", err)
+	}
+
+	for _, test := range tests {
+		msg := message.New().SetData([]byte(test.data))
+		results, err := tf.Transform(context.Background(), msg)
+		if err != nil {
+			t.Fatalf("transform failed: %v", err)
+		}
+		if got := results[0].GetValue("$.event_count").Int(); got != test.expected {
+			t.Errorf("%s: expected %d, got %d", test.data, test.expected, got)
+		}
+	}
+}
+
+func TestArrayLength_NotArray(t *testing.T) {
+	tests := []struct {
+		strict  bool
+		wantErr bool
+	}{
+		{false, false},
+		{true, true},
+	}
+
+	for _, test := range tests {
+		cfg := config.Config{
+			Type: "array_length",
+			Settings: map[string]interface{}{
+				"source": "$.events",
+				"target": "$.event_count",
+				"strict": test.strict,
+			},
+		}
+		tf, err := newArrayLength(context.Background(), cfg)
+		if err != nil {
+			t.Fatalf("failed to create array_length transform: %v", err)
+		}
+
+		msg := message.New().SetData([]byte(`{"events":"not an array"}`))
+		results, err := tf.Transform(context.Background(), msg)
+		if (err != nil) != test.wantErr {
+			t.Fatalf("strict %v: expected error %v, got %v", test.strict, test.wantErr, err)
+		}
+		if err != nil {
+			continue
+		}
+
+		val := results[0].GetValue("$.event_count")
+		if !val.Exists() || val.Int() != 0 {
+			t.Errorf("strict %v: expected event_count 0, got %v", test.strict, val.Value())
+		}
+	}
+}
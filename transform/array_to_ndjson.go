@@ -0,0 +1,88 @@
+package transform
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/jshlbrd/vibestation/config"
+	"github.com/jshlbrd/vibestation/message"
+)
+
+type ArrayToNDJSONConfig struct {
+	// FanOut emits one message per array element instead of a single
+	// newline-delimited message.
+	FanOut bool   `json:"fan_out"`
+	ID     string `json:"id"`
+}
+
+func (c *ArrayToNDJSONConfig) Decode(in interface{}) error {
+	return config.Decode(in, c)
+}
+
+func newArrayToNDJSON(_ context.Context, cfg config.Config) (*ArrayToNDJSON, error) {
+	conf := ArrayToNDJSONConfig{}
+	if err := conf.Decode(cfg.Settings); err != nil {
+		return nil, fmt.Errorf("transform array_to_ndjson: %v", err)
+	}
+	if conf.ID == "" {
+		conf.ID = "array_to_ndjson"
+	}
+
+	sourcePath := "$"
+	if v, ok := cfg.Settings["source"]; ok {
+		if s, ok := v.(string); ok && s != "" {
+			sourcePath = s
+		}
+	}
+
+	tf := ArrayToNDJSON{
+		conf:       conf,
+		settings:   cfg.Settings,
+		sourcePath: sourcePath,
+	}
+	return &tf, nil
+}
+
+type ArrayToNDJSON struct {
+	conf       ArrayToNDJSONConfig
+	settings   map[string]interface{}
+	sourcePath string
+}
+
+func (tf *ArrayToNDJSON) Transform(ctx context.Context, msg *message.Message) ([]*message.Message, error) {
+	if msg.IsControl() {
+		return []*message.Message{msg}, nil
+	}
+
+	val := msg.GetValue(tf.sourcePath)
+	if !val.IsArray() {
+		return nil, fmt.Errorf("transform %s: source %s is not an array", tf.conf.ID, tf.sourcePath)
+	}
+
+	var lines [][]byte
+	for _, item := range val.Array() {
+		b, err := json.Marshal(item.Value())
+		if err != nil {
+			return nil, fmt.Errorf("transform %s: %v", tf.conf.ID, err)
+		}
+		lines = append(lines, b)
+	}
+
+	if tf.conf.FanOut {
+		var result []*message.Message
+		for _, line := range lines {
+			result = append(result, message.New().SetData(line))
+		}
+		return result, nil
+	}
+
+	msg.SetData(bytes.Join(lines, []byte("\n")))
+	return []*message.Message{msg}, nil
+}
+
+func (tf *ArrayToNDJSON) String() string {
+	b, _ := json.Marshal(tf.conf)
+	return string(b)
+}
@@ -0,0 +1,72 @@
+package transform
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jshlbrd/vibestation/config"
+	"github.com/jshlbrd/vibestation/message"
+)
+
+func TestArrayToNDJSON_Joined(t *testing.T) {
+	tf, err := newArrayToNDJSON(context.Background(), config.Config{Type: "array_to_ndjson"})
+	if err != nil {
+		t.Fatalf("failed to create array_to_ndjson transform: %v", err)
+	}
+
+	msg := message.New().SetData([]byte(`[{"a":1},{"b":2}]`))
+	results, err := tf.Transform(context.Background(), msg)
+	if err != nil {
+		t.Fatalf("transform failed: %v", err)
+	}
+	if len(results) != 1 {
+		t.Fatalf("expected 1 result, got %d", len(results))
+	}
+
+	expected := "{\"a\":1}\n{\"b\":2}"
+	if string(results[0].Data()) != expected {
+		t.Errorf("expected %q, got %q", expected, string(results[0].Data()))
+	}
+}
+
+func TestArrayToNDJSON_FanOut(t *testing.T) {
+	cfg := config.Config{
+		Type: "array_to_ndjson",
+		Settings: map[string]interface{}{
+			"source":  "$.events",
+			"fan_out": true,
+		},
+	}
+	tf, err := newArrayToNDJSON(context.Background(), cfg)
+	if err != nil {
+		t.Fatalf("failed to create array_to_ndjson transform: %v", err)
+	}
+
+	msg := message.New().SetData([]byte(`{"events":[{"a":1},{"b":2},{"c":3}]}`))
+	results, err := tf.Transform(context.Background(), msg)
+	if err != nil {
+		t.Fatalf("transform failed: %v", err)
+	}
+
+	expected := []string{`{"a":1}`, `{"b":2}`, `{"c":3}`}
+	if len(results) != len(expected) {
+		t.Fatalf("expected %d results, got %d", len(expected), len(results))
+	}
+	for i, r := range results {
+		if string(r.Data()) != expected[i] {
+			t.Errorf("expected %s, got %s", expected[i], string(r.Data()))
+		}
+	}
+}
+
+func TestArrayToNDJSON_NotArray(t *testing.T) {
+	tf, err := newArrayToNDJSON(context.Background(), config.Config{Type: "array_to_ndjson"})
+	if err != nil {
+		t.Fatalf("failed to create array_to_ndjson transform: %v", err)
+	}
+
+	msg := message.New().SetData([]byte(`{"a":1}`))
+	if _, err := tf.Transform(context.Background(), msg); err == nil {
+		t.Fatal("expected error for non-array input, got nil")
+	}
+}
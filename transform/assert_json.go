@@ -0,0 +1,78 @@
+package transform
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/jshlbrd/vibestation/config"
+	"github.com/jshlbrd/vibestation/message"
+)
+
+type AssertJSONConfig struct {
+	// Mode determines what happens to messages that are not valid JSON,
+	// either "error" (default) or "drop".
+	Mode string `json:"mode"`
+	ID   string `json:"id"`
+}
+
+func (c *AssertJSONConfig) Decode(in interface{}) error {
+	return config.Decode(in, c)
+}
+
+func (c *AssertJSONConfig) Validate() error {
+	if c.Mode != "error" && c.Mode != "drop" {
+		return fmt.Errorf("mode: unsupported value %q", c.Mode)
+	}
+	return nil
+}
+
+func newAssertJSON(_ context.Context, cfg config.Config) (*AssertJSON, error) {
+	conf := AssertJSONConfig{}
+	if err := conf.Decode(cfg.Settings); err != nil {
+		return nil, fmt.Errorf("transform assert_json: %v", err)
+	}
+	if conf.ID == "" {
+		conf.ID = "assert_json"
+	}
+	if conf.Mode == "" {
+		conf.Mode = "error"
+	}
+	if err := conf.Validate(); err != nil {
+		return nil, fmt.Errorf("transform %s: %v", conf.ID, err)
+	}
+
+	tf := AssertJSON{
+		conf:     conf,
+		settings: cfg.Settings,
+	}
+	return &tf, nil
+}
+
+// AssertJSON guards JSON-dependent transforms by rejecting data messages
+// that are not valid JSON.
+type AssertJSON struct {
+	conf     AssertJSONConfig
+	settings map[string]interface{}
+}
+
+func (tf *AssertJSON) Transform(ctx context.Context, msg *message.Message) ([]*message.Message, error) {
+	if msg.IsControl() {
+		return []*message.Message{msg}, nil
+	}
+
+	if json.Valid(msg.Data()) {
+		return []*message.Message{msg}, nil
+	}
+
+	if tf.conf.Mode == "drop" {
+		return nil, nil
+	}
+
+	return nil, fmt.Errorf("transform %s: invalid JSON", tf.conf.ID)
+}
+
+func (tf *AssertJSON) String() string {
+	b, _ := json.Marshal(tf.conf)
+	return string(b)
+}
@@ -0,0 +1,70 @@
+package transform
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jshlbrd/vibestation/config"
+	"github.com/jshlbrd/vibestation/message"
+)
+
+func TestAssertJSON(t *testing.T) {
+	tests := []struct {
+		name      string
+		mode      string
+		data      string
+		wantCount int
+		wantErr   bool
+	}{
+		{"valid error mode", "error", `{"a":1}`, 1, false},
+		{"invalid error mode", "error", `{"a":`, 0, true},
+		{"valid drop mode", "drop", `[1,2]`, 1, false},
+		{"invalid drop mode", "drop", `not json`, 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := config.Config{
+				Type:     "assert_json",
+				Settings: map[string]interface{}{"mode": tt.mode},
+			}
+			tf, err := newAssertJSON(context.Background(), cfg)
+			if err != nil {
+				t.Fatalf("failed to create assert_json transform: %v", err)
+			}
+
+			results, err := tf.Transform(context.Background(), message.New().SetData([]byte(tt.data)))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("expected error=%v, got %v", tt.wantErr, err)
+			}
+			if len(results) != tt.wantCount {
+				t.Errorf("expected %d messages, got %d", tt.wantCount, len(results))
+			}
+		})
+	}
+}
+
+func TestAssertJSON_Control(t *testing.T) {
+	tf, err := newAssertJSON(context.Background(), config.Config{Type: "assert_json"})
+	if err != nil {
+		t.Fatalf("failed to create assert_json transform: %v", err)
+	}
+
+	results, err := tf.Transform(context.Background(), message.New().AsControl())
+	if err != nil {
+		t.Fatalf("transform failed: %v", err)
+	}
+	if len(results) != 1 || !results[0].IsControl() {
+		t.Error("expected control message to pass")
+	}
+}
+
+func TestAssertJSON_InvalidMode(t *testing.T) {
+	cfg := config.Config{
+		Type:     "assert_json",
+		Settings: map[string]interface{}{"mode": "ignore"},
+	}
+	if _, err := newAssertJSON(context.Background(), cfg); err == nil {
+		t.Fatal("expected error for invalid mode, got nil")
+	}
+}
@@ -0,0 +1,129 @@
+package transform
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/jshlbrd/vibestation/config"
+	"github.com/jshlbrd/vibestation/message"
+)
+
+type AssertRangeConfig struct {
+	// Min is the lower bound. If not set, then there is no lower bound.
+	Min *float64 `json:"min"`
+	// Max is the upper bound. If not set, then there is no upper bound.
+	Max *float64 `json:"max"`
+	// MinExclusive excludes Min from the range. Defaults to false.
+	MinExclusive bool `json:"min_exclusive"`
+	// MaxExclusive excludes Max from the range. Defaults to false.
+	MaxExclusive bool `json:"max_exclusive"`
+	// Mode determines what happens to messages outside the range, either
+	// "error" (default) or "drop".
+	Mode string `json:"mode"`
+	ID   string `json:"id"`
+}
+
+func (c *AssertRangeConfig) Decode(in interface{}) error {
+	return config.Decode(in, c)
+}
+
+func (c *AssertRangeConfig) Validate() error {
+	if c.Min == nil && c.Max == nil {
+		return fmt.Errorf("min or max: missing required option")
+	}
+	if c.Min != nil && c.Max != nil && *c.Min > *c.Max {
+		return fmt.Errorf("min: must not be greater than max")
+	}
+	if c.Mode != "error" && c.Mode != "drop" {
+		return fmt.Errorf("mode: unsupported value %q", c.Mode)
+	}
+	return nil
+}
+
+func newAssertRange(_ context.Context, cfg config.Config) (*AssertRange, error) {
+	conf := AssertRangeConfig{}
+	if err := conf.Decode(cfg.Settings); err != nil {
+		return nil, fmt.Errorf("transform assert_range: %v", err)
+	}
+	if conf.ID == "" {
+		conf.ID = "assert_range"
+	}
+	if conf.Mode == "" {
+		conf.Mode = "error"
+	}
+	if err := conf.Validate(); err != nil {
+		return nil, fmt.Errorf("transform %s: %v", conf.ID, err)
+	}
+
+	var sourcePath string
+	if v, ok := cfg.Settings["source"]; ok {
+		if s, ok := v.(string); ok {
+			sourcePath = s
+		}
+	}
+
+	tf := AssertRange{
+		conf:       conf,
+		settings:   cfg.Settings,
+		sourcePath: sourcePath,
+	}
+	return &tf, nil
+}
+
+// AssertRange rejects data messages whose numeric value is outside a range.
+// Values that are missing or not numbers are treated as outside the range.
+type AssertRange struct {
+	conf       AssertRangeConfig
+	settings   map[string]interface{}
+	sourcePath string
+}
+
+func (tf *AssertRange) Transform(ctx context.Context, msg *message.Message) ([]*message.Message, error) {
+	if msg.IsControl() {
+		return []*message.Message{msg}, nil
+	}
+
+	var (
+		n  float64
+		ok bool
+	)
+	if val := msg.GetValue(tf.sourcePath); tf.sourcePath != "" && val.Exists() {
+		n, ok = numericValue(val)
+	} else {
+		n, ok = parseNumber(strings.TrimSpace(string(msg.Data())))
+	}
+	if ok && tf.inRange(n) {
+		return []*message.Message{msg}, nil
+	}
+
+	if tf.conf.Mode == "drop" {
+		return nil, nil
+	}
+	if !ok {
+		return nil, fmt.Errorf("transform %s: value is not a number", tf.conf.ID)
+	}
+
+	return nil, fmt.Errorf("transform %s: value %v is out of range", tf.conf.ID, n)
+}
+
+func (tf *AssertRange) String() string {
+	b, _ := json.Marshal(tf.conf)
+	return string(b)
+}
+
+func (tf *AssertRange) inRange(n float64) bool {
+	if lo := tf.conf.Min; lo != nil {
+		if n < *lo || (tf.conf.MinExclusive && n == *lo) {
+			return false
+		}
+	}
+	if hi := tf.conf.Max; hi != nil {
+		if n > *hi || (tf.conf.MaxExclusive && n == *hi) {
+			return false
+		}
+	}
+
+	return true
+}
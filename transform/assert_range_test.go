@@ -0,0 +1,110 @@
+package transform
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jshlbrd/vibestation/config"
+	"github.com/jshlbrd/vibestation/message"
+)
+
+func TestAssertRange(t *testing.T) {
+	tests := []struct {
+		name      string
+		settings  map[string]interface{}
+		data      string
+		wantCount int
+		wantErr   bool
+	}{
+		{
+			name:      "in range",
+			settings:  map[string]interface{}{"min": 0, "max": 100},
+			data:      `{"v":50}`,
+			wantCount: 1,
+		},
+		{
+			name:      "inclusive bound",
+			settings:  map[string]interface{}{"min": 0, "max": 100},
+			data:      `{"v":100}`,
+			wantCount: 1,
+		},
+		{
+			name:     "exclusive bound",
+			settings: map[string]interface{}{"min": 0, "max": 100, "max_exclusive": true},
+			data:     `{"v":100}`,
+			wantErr:  true,
+		},
+		{
+			name:     "below min",
+			settings: map[string]interface{}{"min": 0, "max": 100},
+			data:     `{"v":-1}`,
+			wantErr:  true,
+		},
+		{
+			name:     "above max",
+			settings: map[string]interface{}{"min": 0, "max": 100},
+			data:     `{"v":101.5}`,
+			wantErr:  true,
+		},
+		{
+			name:     "above max drop",
+			settings: map[string]interface{}{"max": 100, "mode": "drop"},
+			data:     `{"v":101.5}`,
+		},
+		{
+			name:     "not a number",
+			settings: map[string]interface{}{"min": 0},
+			data:     `{"v":"abc"}`,
+			wantErr:  true,
+		},
+		{
+			name:     "NaN",
+			settings: map[string]interface{}{"min": 0, "max": 100},
+			data:     `{"v":"NaN"}`,
+			wantErr:  true,
+		},
+		{
+			name:     "NaN drop",
+			settings: map[string]interface{}{"min": 0, "max": 100, "mode": "drop"},
+			data:     `{"v":"NaN"}`,
+		},
+		{
+			name:     "infinity",
+			settings: map[string]interface{}{"min": 0},
+			data:     `{"v":"+Inf"}`,
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.settings["source"] = "$.v"
+			tf, err := newAssertRange(context.Background(), config.Config{Type: "assert_range", Settings: tt.settings})
+			if err != nil {
+				t.Fatalf("failed to create assert_range transform: %v", err)
+			}
+
+			results, err := tf.Transform(context.Background(), message.New().SetData([]byte(tt.data)))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("expected error=%v, got %v", tt.wantErr, err)
+			}
+			if len(results) != tt.wantCount {
+				t.Errorf("expected %d messages, got %d", tt.wantCount, len(results))
+			}
+		})
+	}
+}
+
+func TestAssertRange_InvalidConfig(t *testing.T) {
+	tests := []map[string]interface{}{
+		{},
+		{"min": 10, "max": 1},
+		{"min": 0, "mode": "ignore"},
+	}
+
+	for _, settings := range tests {
+		if _, err := newAssertRange(context.Background(), config.Config{Type: "assert_range", Settings: settings}); err == nil {
+			t.Errorf("expected error for settings %v, got nil", settings)
+		}
+	}
+}
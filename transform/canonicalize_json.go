@@ -0,0 +1,85 @@
+package transform
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/jshlbrd/vibestation/config"
+	"github.com/jshlbrd/vibestation/message"
+)
+
+type CanonicalizeJSONConfig struct {
+	// NormalizeNumbers rewrites numbers in their shortest form, so that
+	// 1.0, 1e0, and 1 are all written as 1. Numbers are converted to 64-bit
+	// floats, so very large integers may lose precision.
+	NormalizeNumbers bool   `json:"normalize_numbers"`
+	ID               string `json:"id"`
+}
+
+func (c *CanonicalizeJSONConfig) Decode(in interface{}) error {
+	return config.Decode(in, c)
+}
+
+func newCanonicalizeJSON(_ context.Context, cfg config.Config) (*CanonicalizeJSON, error) {
+	conf := CanonicalizeJSONConfig{}
+	if err := conf.Decode(cfg.Settings); err != nil {
+		return nil, fmt.Errorf("transform canonicalize_json: %v", err)
+	}
+	if conf.ID == "" {
+		conf.ID = "canonicalize_json"
+	}
+
+	tf := CanonicalizeJSON{
+		conf:     conf,
+		settings: cfg.Settings,
+	}
+	return &tf, nil
+}
+
+// CanonicalizeJSON rewrites the message data as compact JSON with object
+// keys in sorted order, so that equivalent documents have identical bytes.
+// HTML characters are not escaped.
+type CanonicalizeJSON struct {
+	conf     CanonicalizeJSONConfig
+	settings map[string]interface{}
+}
+
+func (tf *CanonicalizeJSON) Transform(ctx context.Context, msg *message.Message) ([]*message.Message, error) {
+	if msg.IsControl() {
+		return []*message.Message{msg}, nil
+	}
+
+	if !json.Valid(msg.Data()) {
+		return nil, fmt.Errorf("transform %s: invalid JSON", tf.conf.ID)
+	}
+
+	var v interface{}
+	if tf.conf.NormalizeNumbers {
+		if err := json.Unmarshal(msg.Data(), &v); err != nil {
+			return nil, fmt.Errorf("transform %s: %v", tf.conf.ID, err)
+		}
+	} else {
+		var err error
+		if v, err = decodeJSONNumber(msg.Data()); err != nil {
+			return nil, fmt.Errorf("transform %s: %v", tf.conf.ID, err)
+		}
+	}
+
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(v); err != nil {
+		return nil, fmt.Errorf("transform %s: %v", tf.conf.ID, err)
+	}
+
+	// Encode always appends a newline.
+	msg.SetData(bytes.TrimSuffix(buf.Bytes(), []byte("\n")))
+	return []*message.Message{msg}, nil
+}
+
+func (tf *CanonicalizeJSON) String() string {
+	b, _ := json.Marshal(tf.conf)
+	return string(b)
+}
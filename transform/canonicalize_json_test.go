@@ -0,0 +1,77 @@
+package transform
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jshlbrd/vibestation/config"
+	"github.com/jshlbrd/vibestation/message"
+)
+
+func TestCanonicalizeJSON(t *testing.T) {
+	tf, err := newCanonicalizeJSON(context.Background(), config.Config{Type: "canonicalize_json"})
+	if err != nil {
+		t.Fatalf("failed to create canonicalize_json transform: %v", err)
+	}
+
+	inputs := []string{
+		`{"b": [1, {"y": 2, "x": 1}], "a": "<tag>"}`,
+		"{\n  \"a\": \"<tag>\",\n  \"b\": [1, {\"x\": 1, \"y\": 2}]\n}",
+	}
+
+	var outputs []string
+	for _, input := range inputs {
+		results, err := tf.Transform(context.Background(), message.New().SetData([]byte(input)))
+		if err != nil {
+			t.Fatalf("transform failed: %v", err)
+		}
+		outputs = append(outputs, string(results[0].Data()))
+	}
+
+	expected := `{"a":"<tag>","b":[1,{"x":1,"y":2}]}`
+	for i, got := range outputs {
+		if got != expected {
+			t.Errorf("input %d: expected %s, got %s", i, expected, got)
+		}
+	}
+}
+
+func TestCanonicalizeJSON_NormalizeNumbers(t *testing.T) {
+	tests := []struct {
+		normalize bool
+		expected  string
+	}{
+		{false, `{"a":1.0,"b":1e2}`},
+		{true, `{"a":1,"b":100}`},
+	}
+
+	for _, tt := range tests {
+		cfg := config.Config{
+			Type:     "canonicalize_json",
+			Settings: map[string]interface{}{"normalize_numbers": tt.normalize},
+		}
+		tf, err := newCanonicalizeJSON(context.Background(), cfg)
+		if err != nil {
+			t.Fatalf("failed to create canonicalize_json transform: %v", err)
+		}
+
+		results, err := tf.Transform(context.Background(), message.New().SetData([]byte(`{"b":1e2,"a":1.0}`)))
+		if err != nil {
+			t.Fatalf("transform failed: %v", err)
+		}
+		if got := string(results[0].Data()); got != tt.expected {
+			t.Errorf("normalize=%v: expected %s, got %s", tt.normalize, tt.expected, got)
+		}
+	}
+}
+
+func TestCanonicalizeJSON_Invalid(t *testing.T) {
+	tf, err := newCanonicalizeJSON(context.Background(), config.Config{Type: "canonicalize_json"})
+	if err != nil {
+		t.Fatalf("failed to create canonicalize_json transform: %v", err)
+	}
+
+	if _, err := tf.Transform(context.Background(), message.New().SetData([]byte(`{"a":1} {"b":2}`))); err == nil {
+		t.Fatal("expected error for invalid JSON, got nil")
+	}
+}
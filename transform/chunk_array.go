@@ -0,0 +1,118 @@
+package transform
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/jshlbrd/vibestation/config"
+	"github.com/jshlbrd/vibestation/message"
+)
+
+type ChunkArrayConfig struct {
+	// Size is the maximum number of elements in each chunk.
+	Size int    `json:"size"`
+	ID   string `json:"id"`
+}
+
+func (c *ChunkArrayConfig) Decode(in interface{}) error {
+	return config.Decode(in, c)
+}
+
+func (c *ChunkArrayConfig) Validate() error {
+	if c.Size <= 0 {
+		return fmt.Errorf("size: must be greater than 0")
+	}
+	return nil
+}
+
+func newChunkArray(_ context.Context, cfg config.Config) (*ChunkArray, error) {
+	conf := ChunkArrayConfig{}
+	if err := conf.Decode(cfg.Settings); err != nil {
+		return nil, fmt.Errorf("transform chunk_array: %v", err)
+	}
+	if conf.ID == "" {
+		conf.ID = "chunk_array"
+	}
+	if err := conf.Validate(); err != nil {
+		return nil, fmt.Errorf("transform %s: %v", conf.ID, err)
+	}
+
+	sourcePath := "$"
+	if v, ok := cfg.Settings["source"]; ok {
+		if s, ok := v.(string); ok && s != "" {
+			sourcePath = s
+		}
+	}
+
+	var targetPath string
+	if v, ok := cfg.Settings["target"]; ok {
+		if s, ok := v.(string); ok {
+			targetPath = s
+		}
+	}
+
+	tf := ChunkArray{
+		conf:       conf,
+		settings:   cfg.Settings,
+		sourcePath: sourcePath,
+		targetPath: targetPath,
+	}
+	return &tf, nil
+}
+
+// ChunkArray splits an array into an array of arrays that each contain at
+// most Size elements. The last chunk may be smaller.
+type ChunkArray struct {
+	conf       ChunkArrayConfig
+	settings   map[string]interface{}
+	sourcePath string
+	targetPath string
+}
+
+func (tf *ChunkArray) Transform(ctx context.Context, msg *message.Message) ([]*message.Message, error) {
+	if msg.IsControl() {
+		return []*message.Message{msg}, nil
+	}
+
+	val := msg.GetValue(tf.sourcePath)
+	if !val.IsArray() {
+		return nil, fmt.Errorf("transform %s: source %s is not an array", tf.conf.ID, tf.sourcePath)
+	}
+
+	chunks := chunkArray(val.Array(), tf.conf.Size)
+
+	if tf.targetPath != "" {
+		if err := msg.SetValue(tf.targetPath, chunks); err != nil {
+			return nil, fmt.Errorf("transform %s: failed to set target: %v", tf.conf.ID, err)
+		}
+	} else {
+		b, err := json.Marshal(chunks)
+		if err != nil {
+			return nil, fmt.Errorf("transform %s: %v", tf.conf.ID, err)
+		}
+		msg.SetData(b)
+	}
+
+	return []*message.Message{msg}, nil
+}
+
+func (tf *ChunkArray) String() string {
+	b, _ := json.Marshal(tf.conf)
+	return string(b)
+}
+
+func chunkArray(items []message.Value, size int) [][]interface{} {
+	chunks := make([][]interface{}, 0, (len(items)+size-1)/size)
+	for start := 0; start < len(items); start += size {
+		end := min(start+size, len(items))
+
+		chunk := make([]interface{}, 0, end-start)
+		for _, item := range items[start:end] {
+			chunk = append(chunk, item.Value())
+		}
+		chunks = append(chunks, chunk)
+	}
+
+	return chunks
+}
@@ -0,0 +1,58 @@
+package transform
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jshlbrd/vibestation/config"
+	"github.com/jshlbrd/vibestation/message"
+)
+
+func TestChunkArray(t *testing.T) {
+	tests := []struct {
+		name     string
+		data     string
+		size     int
+		expected string
+	}{
+		{"exact multiple", `{"items":[1,2,3,4]}`, 2, `[[1,2],[3,4]]`},
+		{"trailing partial", `{"items":[1,2,3,4,5]}`, 2, `[[1,2],[3,4],[5]]`},
+		{"larger than array", `{"items":["a","b"]}`, 10, `[["a","b"]]`},
+		{"empty", `{"items":[]}`, 3, `[]`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := config.Config{
+				Type: "chunk_array",
+				Settings: map[string]interface{}{
+					"source": "$.items",
+					"target": "$.chunks",
+					"size":   tt.size,
+				},
+			}
+			tf, err := newChunkArray(context.Background(), cfg)
+			if err != nil {
+				t.Fatalf("failed to create chunk_array transform: %v", err)
+			}
+
+			results, err := tf.Transform(context.Background(), message.New().SetData([]byte(tt.data)))
+			if err != nil {
+				t.Fatalf("transform failed: %v", err)
+			}
+			if got := results[0].GetValue("$.chunks").String(); got != tt.expected {
+				t.Errorf("expected %s, got %s", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestChunkArray_InvalidSize(t *testing.T) {
+	cfg := config.Config{
+		Type:     "chunk_array",
+		Settings: map[string]interface{}{"size": 0},
+	}
+	if _, err := newChunkArray(context.Background(), cfg); err == nil {
+		t.Fatal("expected error for invalid size, got nil")
+	}
+}
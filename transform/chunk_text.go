@@ -0,0 +1,91 @@
+package transform
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/jshlbrd/vibestation/config"
+	"github.com/jshlbrd/vibestation/message"
+)
+
+type ChunkTextConfig struct {
+	// Size is the number of characters in each chunk.
+	Size int `json:"size"`
+	// Overlap is the number of characters that each chunk shares with the
+	// previous chunk. Must be less than Size.
+	Overlap int    `json:"overlap"`
+	ID      string `json:"id"`
+}
+
+func (c *ChunkTextConfig) Decode(in interface{}) error {
+	return config.Decode(in, c)
+}
+
+func (c *ChunkTextConfig) Validate() error {
+	if c.Size <= 0 {
+		return fmt.Errorf("size: must be greater than 0")
+	}
+	if c.Overlap < 0 || c.Overlap >= c.Size {
+		return fmt.Errorf("overlap: must be at least 0 and less than size")
+	}
+	return nil
+}
+
+func newChunkText(_ context.Context, cfg config.Config) (*ChunkText, error) {
+	conf := ChunkTextConfig{}
+	if err := conf.Decode(cfg.Settings); err != nil {
+		return nil, fmt.Errorf("transform chunk_text: %v", err)
+	}
+	if conf.ID == "" {
+		conf.ID = "chunk_text"
+	}
+	if err := conf.Validate(); err != nil {
+		return nil, fmt.Errorf("transform %s: %v", conf.ID, err)
+	}
+
+	tf := ChunkText{
+		conf:     conf,
+		settings: cfg.Settings,
+	}
+	return &tf, nil
+}
+
+// ChunkText emits the message data as a series of windows of at most Size
+// characters, where each window repeats the last Overlap characters of the
+// one before it. Windows never split a multi-byte character.
+type ChunkText struct {
+	conf     ChunkTextConfig
+	settings map[string]interface{}
+}
+
+func (tf *ChunkText) Transform(ctx context.Context, msg *message.Message) ([]*message.Message, error) {
+	if msg.IsControl() {
+		return []*message.Message{msg}, nil
+	}
+
+	var result []*message.Message
+	for _, chunk := range chunkText([]rune(string(msg.Data())), tf.conf.Size, tf.conf.Overlap) {
+		result = append(result, message.New().SetData([]byte(chunk)))
+	}
+
+	return result, nil
+}
+
+func (tf *ChunkText) String() string {
+	b, _ := json.Marshal(tf.conf)
+	return string(b)
+}
+
+func chunkText(runes []rune, size, overlap int) []string {
+	var chunks []string
+	for start := 0; start < len(runes); start += size - overlap {
+		end := min(start+size, len(runes))
+		chunks = append(chunks, string(runes[start:end]))
+		if end == len(runes) {
+			break
+		}
+	}
+
+	return chunks
+}
@@ -0,0 +1,78 @@
+package transform
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/jshlbrd/vibestation/config"
+	"github.com/jshlbrd/vibestation/message"
+)
+
+func TestChunkText(t *testing.T) {
+	tests := []struct {
+		name     string
+		size     int
+		overlap  int
+		input    string
+		expected []string
+	}{
+		{"non-overlapping", 4, 0, "abcdefghij", []string{"abcd", "efgh", "ij"}},
+		{"overlapping", 4, 2, "abcdefgh", []string{"abcd", "cdef", "efgh"}},
+		{"overlapping partial", 5, 1, "abcdefghijk", []string{"abcde", "efghi", "ijk"}},
+		{"multi-byte", 2, 1, "héllo", []string{"hé", "él", "ll", "lo"}},
+		{"shorter than size", 10, 3, "abc", []string{"abc"}},
+		{"empty", 3, 0, "", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := config.Config{
+				Type: "chunk_text",
+				Settings: map[string]interface{}{
+					"size":    tt.size,
+					"overlap": tt.overlap,
+				},
+			}
+			tf, err := newChunkText(context.Background(), cfg)
+			if err != nil {
+				t.Fatalf("failed to create chunk_text transform: %v", err)
+			}
+
+			results, err := tf.Transform(context.Background(), message.New().SetData([]byte(tt.input)))
+			if err != nil {
+				t.Fatalf("transform failed: %v", err)
+			}
+
+			var got []string
+			for _, r := range results {
+				got = append(got, string(r.Data()))
+			}
+			if !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("expected %q, got %q", tt.expected, got)
+			}
+
+			// Consecutive chunks share exactly the configured overlap.
+			for i := 1; i < len(got); i++ {
+				prev := []rune(got[i-1])
+				if tail, head := string(prev[len(prev)-tt.overlap:]), string([]rune(got[i])[:tt.overlap]); tail != head {
+					t.Errorf("chunk %d: expected overlap %q, got %q", i, tail, head)
+				}
+			}
+		})
+	}
+}
+
+func TestChunkText_InvalidConfig(t *testing.T) {
+	tests := []map[string]interface{}{
+		{"size": 0},
+		{"size": 4, "overlap": 4},
+		{"size": 4, "overlap": -1},
+	}
+
+	for _, settings := range tests {
+		if _, err := newChunkText(context.Background(), config.Config{Type: "chunk_text", Settings: settings}); err == nil {
+			t.Errorf("expected error for settings %v, got nil", settings)
+		}
+	}
+}
@@ -0,0 +1,106 @@
+package transform
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"github.com/jshlbrd/vibestation/config"
+	"github.com/jshlbrd/vibestation/message"
+)
+
+type CollectObjectConfig struct {
+	// Key is the JSON path to the key in each message. Defaults to $.key.
+	Key string `json:"key"`
+	// Value is the JSON path to the value in each message. Defaults to $.value.
+	Value string `json:"value"`
+	ID    string `json:"id"`
+}
+
+func (c *CollectObjectConfig) Decode(in interface{}) error {
+	return config.Decode(in, c)
+}
+
+func newCollectObject(_ context.Context, cfg config.Config) (*CollectObject, error) {
+	conf := CollectObjectConfig{}
+	if err := conf.Decode(cfg.Settings); err != nil {
+		return nil, fmt.Errorf("transform collect_object: %v", err)
+	}
+	if conf.ID == "" {
+		conf.ID = "collect_object"
+	}
+	if conf.Key == "" {
+		conf.Key = "$.key"
+	}
+	if conf.Value == "" {
+		conf.Value = "$.value"
+	}
+
+	var targetPath string
+	if v, ok := cfg.Settings["target"]; ok {
+		if s, ok := v.(string); ok {
+			targetPath = s
+		}
+	}
+
+	tf := CollectObject{
+		conf:       conf,
+		settings:   cfg.Settings,
+		targetPath: targetPath,
+		object:     make(map[string]interface{}),
+	}
+	return &tf, nil
+}
+
+// CollectObject buffers key-value messages into a single object. The object
+// is emitted when a control message is received, followed by the control
+// message. Later values overwrite earlier values with the same key.
+type CollectObject struct {
+	conf       CollectObjectConfig
+	settings   map[string]interface{}
+	targetPath string
+
+	mu     sync.Mutex
+	object map[string]interface{}
+}
+
+func (tf *CollectObject) Transform(ctx context.Context, msg *message.Message) ([]*message.Message, error) {
+	tf.mu.Lock()
+	defer tf.mu.Unlock()
+
+	if !msg.IsControl() {
+		key := msg.GetValue(tf.conf.Key)
+		if !key.Exists() {
+			return nil, fmt.Errorf("transform %s: key %s not found", tf.conf.ID, tf.conf.Key)
+		}
+
+		tf.object[key.String()] = msg.GetValue(tf.conf.Value).Value()
+		return nil, nil
+	}
+
+	if len(tf.object) == 0 {
+		return []*message.Message{msg}, nil
+	}
+
+	out := message.New()
+	if tf.targetPath != "" {
+		if err := out.SetValue(tf.targetPath, tf.object); err != nil {
+			return nil, fmt.Errorf("transform %s: failed to set target: %v", tf.conf.ID, err)
+		}
+	} else {
+		b, err := json.Marshal(tf.object)
+		if err != nil {
+			return nil, fmt.Errorf("transform %s: %v", tf.conf.ID, err)
+		}
+		out.SetData(b)
+	}
+
+	tf.object = make(map[string]interface{})
+	return []*message.Message{out, msg}, nil
+}
+
+func (tf *CollectObject) String() string {
+	b, _ := json.Marshal(tf.conf)
+	return string(b)
+}
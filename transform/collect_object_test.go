@@ -0,0 +1,60 @@
+package transform
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jshlbrd/vibestation/config"
+	"github.com/jshlbrd/vibestation/message"
+)
+
+func TestCollectObject(t *testing.T) {
+	tf, err := newCollectObject(context.Background(), config.Config{Type: "collect_object"})
+	if err != nil {
+		t.Fatalf("failed to create collect_object transform: %v", err)
+	}
+
+	// These are the messages produced by fanning out {"a":1,"b":{"c":"d"}}.
+	msgs := []*message.Message{
+		message.New().SetData([]byte(`{"key":"a","value":1}`)),
+		message.New().SetData([]byte(`{"key":"b","value":{"c":"d"}}`)),
+		message.New().AsControl(),
+	}
+
+	results, err := Apply(context.Background(), []Transformer{tf}, msgs...)
+	if err != nil {
+		t.Fatalf("transform failed: %v", err)
+	}
+	if len(results) != 2 {
+		t.Fatalf("expected 2 results, got %d", len(results))
+	}
+
+	expected := `{"a":1,"b":{"c":"d"}}`
+	if string(results[0].Data()) != expected {
+		t.Errorf("expected %s, got %s", expected, string(results[0].Data()))
+	}
+	if !results[1].IsControl() {
+		t.Error("expected control message after collected object")
+	}
+
+	// The buffer is reset after a flush.
+	results, err = tf.Transform(context.Background(), message.New().AsControl())
+	if err != nil {
+		t.Fatalf("transform failed: %v", err)
+	}
+	if len(results) != 1 || !results[0].IsControl() {
+		t.Errorf("expected only the control message after reset, got %d messages", len(results))
+	}
+}
+
+func TestCollectObject_MissingKey(t *testing.T) {
+	tf, err := newCollectObject(context.Background(), config.Config{Type: "collect_object"})
+	if err != nil {
+		t.Fatalf("failed to create collect_object transform: %v", err)
+	}
+
+	msg := message.New().SetData([]byte(`{"value":1}`))
+	if _, err := tf.Transform(context.Background(), msg); err == nil {
+		t.Fatal("expected error for missing key, got nil")
+	}
+}
@@ -0,0 +1,115 @@
+package transform
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/jshlbrd/vibestation/config"
+	"github.com/jshlbrd/vibestation/message"
+)
+
+func TestCompressGzipTransform_MinSize(t *testing.T) {
+	cfg := config.Config{
+		Type: "compress_gzip",
+		Settings: map[string]interface{}{
+			"min_size": 64,
+		},
+	}
+
+	tf, err := newCompressGzip(context.Background(), cfg)
+	if err != nil {
+		t.Fatalf("failed to create compress_gzip transform: %v", err)
+	}
+
+	tests := []struct {
+		name       string
+		data       string
+		compressed bool
+	}{
+		{"below threshold", "tiny", false},
+		{"above threshold", strings.Repeat("a", 128), true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			msg := message.New()
+			msg.SetData([]byte(test.data))
+
+			msgs, err := tf.Transform(context.Background(), msg)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if got := msgs[0].GetValue("meta.$.compressed").Bool(); got != test.compressed {
+				t.Errorf("expected compressed flag %v, got %v", test.compressed, got)
+			}
+
+			if !test.compressed {
+				if string(msgs[0].Data()) != test.data {
+					t.Errorf("expected data to be unchanged, got %q", string(msgs[0].Data()))
+				}
+				return
+			}
+
+			decompressed, err := decompressGzip(msgs[0].Data())
+			if err != nil {
+				t.Fatalf("failed to decompress: %v", err)
+			}
+			if string(decompressed) != test.data {
+				t.Errorf("expected round trip to return the original data, got %q", string(decompressed))
+			}
+		})
+	}
+}
+
+func TestCompressGzipTransform_ControlMessage(t *testing.T) {
+	tf, err := newCompressGzip(context.Background(), config.Config{Type: "compress_gzip"})
+	if err != nil {
+		t.Fatalf("failed to create compress_gzip transform: %v", err)
+	}
+
+	msgs, err := tf.Transform(context.Background(), message.New().AsControl())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(msgs) != 1 || !msgs[0].IsControl() {
+		t.Error("expected control message to pass through")
+	}
+}
+
+func TestCompressGzipTransform_Deterministic(t *testing.T) {
+	data := []byte(`{"a":"payload"}`)
+
+	// Each run uses a new transform, and the second run compresses other data
+	// first, so the output must not depend on transform state or timing.
+	var outputs [][]byte
+	for i := 0; i < 2; i++ {
+		tf, err := newCompressGzip(context.Background(), config.Config{Type: "compress_gzip"})
+		if err != nil {
+			t.Fatalf("failed to create compress_gzip transform: %v", err)
+		}
+
+		if i == 1 {
+			if _, err := tf.Transform(context.Background(), message.New().SetData([]byte("other"))); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		}
+
+		msgs, err := tf.Transform(context.Background(), message.New().SetData(data))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		outputs = append(outputs, msgs[0].Data())
+	}
+
+	if !bytes.Equal(outputs[0], outputs[1]) {
+		t.Errorf("expected identical output, got %x and %x", outputs[0], outputs[1])
+	}
+
+	// Bytes 4-7 of the header are the modification time.
+	if header := outputs[0]; !bytes.Equal(header[4:8], []byte{0, 0, 0, 0}) {
+		t.Errorf("expected zero modification time, got %x", header[4:8])
+	}
+}
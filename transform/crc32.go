@@ -0,0 +1,139 @@
+package transform
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"hash/crc32"
+	"strconv"
+
+	"github.com/jshlbrd/vibestation/config"
+	"github.com/jshlbrd/vibestation/message"
+)
+
+type CRC32Config struct {
+	// Polynomial is one of "ieee" (default), "castagnoli", or "koopman".
+	Polynomial string `json:"polynomial"`
+	// Format is the output format of the checksum, either "hex" (default)
+	// or "decimal".
+	Format string `json:"format"`
+	ID     string `json:"id"`
+}
+
+func (c *CRC32Config) Decode(in interface{}) error {
+	return config.Decode(in, c)
+}
+
+func (c *CRC32Config) Validate() error {
+	if c.Format != "hex" && c.Format != "decimal" {
+		return fmt.Errorf("format: unsupported value %q", c.Format)
+	}
+	return nil
+}
+
+func newCRC32(_ context.Context, cfg config.Config) (*CRC32, error) {
+	conf := CRC32Config{}
+	if err := conf.Decode(cfg.Settings); err != nil {
+		return nil, fmt.Errorf("transform crc32: %v", err)
+	}
+	if conf.ID == "" {
+		conf.ID = "crc32"
+	}
+	if conf.Polynomial == "" {
+		conf.Polynomial = "ieee"
+	}
+	if conf.Format == "" {
+		conf.Format = "hex"
+	}
+	if err := conf.Validate(); err != nil {
+		return nil, fmt.Errorf("transform %s: %v", conf.ID, err)
+	}
+
+	var table *crc32.Table
+	switch conf.Polynomial {
+	case "ieee":
+		table = crc32.IEEETable
+	case "castagnoli":
+		table = crc32.MakeTable(crc32.Castagnoli)
+	case "koopman":
+		table = crc32.MakeTable(crc32.Koopman)
+	default:
+		return nil, fmt.Errorf("transform %s: polynomial: unsupported value %q", conf.ID, conf.Polynomial)
+	}
+
+	var sourcePath string
+	if v, ok := cfg.Settings["source"]; ok {
+		if s, ok := v.(string); ok {
+			sourcePath = s
+		}
+	}
+
+	var targetPath string
+	if v, ok := cfg.Settings["target"]; ok {
+		if s, ok := v.(string); ok {
+			targetPath = s
+		}
+	}
+
+	tf := CRC32{
+		conf:       conf,
+		table:      table,
+		settings:   cfg.Settings,
+		sourcePath: sourcePath,
+		targetPath: targetPath,
+	}
+	return &tf, nil
+}
+
+type CRC32 struct {
+	conf       CRC32Config
+	table      *crc32.Table
+	settings   map[string]interface{}
+	sourcePath string
+	targetPath string
+}
+
+func (tf *CRC32) Transform(ctx context.Context, msg *message.Message) ([]*message.Message, error) {
+	if msg.IsControl() {
+		return []*message.Message{msg}, nil
+	}
+
+	var inputData []byte
+	if tf.sourcePath != "" {
+		val := msg.GetValue(tf.sourcePath)
+		if val.Exists() {
+			inputData = val.Bytes()
+		}
+	}
+	if inputData == nil {
+		inputData = msg.Data()
+	}
+
+	sum := crc32.Checksum(inputData, tf.table)
+
+	var checksum string
+	if tf.conf.Format == "decimal" {
+		checksum = strconv.FormatUint(uint64(sum), 10)
+	} else {
+		checksum = fmt.Sprintf("%08x", sum)
+	}
+
+	if tf.targetPath != "" {
+		var value interface{} = checksum
+		if tf.conf.Format == "decimal" {
+			value = sum
+		}
+		if err := msg.SetValue(tf.targetPath, value); err != nil {
+			return nil, fmt.Errorf("transform %s: failed to set target: %v", tf.conf.ID, err)
+		}
+	} else {
+		msg.SetData([]byte(checksum))
+	}
+
+	return []*message.Message{msg}, nil
+}
+
+func (tf *CRC32) String() string {
+	b, _ := json.Marshal(tf.conf)
+	return string(b)
+}
@@ -0,0 +1,78 @@
+package transform
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jshlbrd/vibestation/config"
+	"github.com/jshlbrd/vibestation/message"
+)
+
+func TestCRC32(t *testing.T) {
+	// Known check values for the input "123456789".
+	tests := []struct {
+		polynomial string
+		format     string
+		expected   string
+	}{
+		{"", "", "cbf43926"},
+		{"ieee", "decimal", "3421780262"},
+		{"castagnoli", "hex", "e3069283"},
+	}
+
+	for _, test := range tests {
+		cfg := config.Config{
+			Type: "crc32",
+			Settings: map[string]interface{}{
+				"polynomial": test.polynomial,
+				"format":     test.format,
+			},
+		}
+		tf, err := newCRC32(context.Background(), cfg)
+		if err != nil {
+			t.Fatalf("failed to create crc32 transform: %v", err)
+		}
+
+		msg := message.New().SetData([]byte("123456789"))
+		results, err := tf.Transform(context.Background(), msg)
+		if err != nil {
+			t.Fatalf("transform failed: %v", err)
+		}
+		if string(results[0].Data()) != test.expected {
+			t.Errorf("%s/%s: expected %s, got %s", test.polynomial, test.format, test.expected, string(results[0].Data()))
+		}
+	}
+}
+
+func TestCRC32_Target(t *testing.T) {
+	cfg := config.Config{
+		Type: "crc32",
+		Settings: map[string]interface{}{
+			"source": "$.payload",
+			"target": "$.checksum",
+		},
+	}
+	tf, err := newCRC32(context.Background(), cfg)
+	if err != nil {
+		t.Fatalf("failed to create crc32 transform: %v", err)
+	}
+
+	msg := message.New().SetData([]byte(`{"payload":"123456789"}`))
+	results, err := tf.Transform(context.Background(), msg)
+	if err != nil {
+		t.Fatalf("transform failed: %v", err)
+	}
+	if got := results[0].GetValue("$.checksum").String(); got != "cbf43926" {
+		t.Errorf("expected %s, got %s", "cbf43926", got)
+	}
+}
+
+func TestCRC32_InvalidPolynomial(t *testing.T) {
+	cfg := config.Config{
+		Type:     "crc32",
+		Settings: map[string]interface{}{"polynomial": "bogus"},
+	}
+	if _, err := newCRC32(context.Background(), cfg); err == nil {
+		t.Fatal("expected error for invalid polynomial, got nil")
+	}
+}
@@ -0,0 +1,124 @@
+package transform
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/jshlbrd/vibestation/config"
+	"github.com/jshlbrd/vibestation/message"
+)
+
+type CutFieldConfig struct {
+	Separator string `json:"separator"`
+	// Index is the 1-based position of the field. Negative values count from
+	// the end, so -1 is the last field.
+	Index int `json:"index"`
+	// Strict returns an error if the index is out of range, otherwise the
+	// message is passed through unchanged.
+	Strict bool   `json:"strict"`
+	ID     string `json:"id"`
+}
+
+func (c *CutFieldConfig) Decode(in interface{}) error {
+	return config.Decode(in, c)
+}
+
+func (c *CutFieldConfig) Validate() error {
+	if c.Separator == "" {
+		return fmt.Errorf("separator: missing required option")
+	}
+	if c.Index == 0 {
+		return fmt.Errorf("index: must not be 0")
+	}
+	return nil
+}
+
+func newCutField(_ context.Context, cfg config.Config) (*CutField, error) {
+	conf := CutFieldConfig{}
+	if err := conf.Decode(cfg.Settings); err != nil {
+		return nil, fmt.Errorf("transform cut_field: %v", err)
+	}
+	if conf.ID == "" {
+		conf.ID = "cut_field"
+	}
+	if err := conf.Validate(); err != nil {
+		return nil, fmt.Errorf("transform %s: %v", conf.ID, err)
+	}
+
+	var sourcePath string
+	if v, ok := cfg.Settings["source"]; ok {
+		if s, ok := v.(string); ok {
+			sourcePath = s
+		}
+	}
+
+	var targetPath string
+	if v, ok := cfg.Settings["target"]; ok {
+		if s, ok := v.(string); ok {
+			targetPath = s
+		}
+	}
+
+	tf := CutField{
+		conf:       conf,
+		settings:   cfg.Settings,
+		sourcePath: sourcePath,
+		targetPath: targetPath,
+	}
+	return &tf, nil
+}
+
+// CutField splits text on a separator and selects one field, like
+// `cut -d, -f3`.
+type CutField struct {
+	conf       CutFieldConfig
+	settings   map[string]interface{}
+	sourcePath string
+	targetPath string
+}
+
+func (tf *CutField) Transform(ctx context.Context, msg *message.Message) ([]*message.Message, error) {
+	if msg.IsControl() {
+		return []*message.Message{msg}, nil
+	}
+
+	var inputData []byte
+	if tf.sourcePath != "" {
+		val := msg.GetValue(tf.sourcePath)
+		if val.Exists() {
+			inputData = val.Bytes()
+		}
+	}
+	if inputData == nil {
+		inputData = msg.Data()
+	}
+
+	fields := strings.Split(string(inputData), tf.conf.Separator)
+	i := tf.conf.Index - 1
+	if tf.conf.Index < 0 {
+		i = len(fields) + tf.conf.Index
+	}
+	if i < 0 || i >= len(fields) {
+		if tf.conf.Strict {
+			return nil, fmt.Errorf("transform %s: index %d out of range for %d fields", tf.conf.ID, tf.conf.Index, len(fields))
+		}
+		return []*message.Message{msg}, nil
+	}
+
+	if tf.targetPath != "" {
+		if err := msg.SetValue(tf.targetPath, fields[i]); err != nil {
+			return nil, fmt.Errorf("transform %s: failed to set target: %v", tf.conf.ID, err)
+		}
+	} else {
+		msg.SetData([]byte(fields[i]))
+	}
+
+	return []*message.Message{msg}, nil
+}
+
+func (tf *CutField) String() string {
+	b, _ := json.Marshal(tf.conf)
+	return string(b)
+}
@@ -0,0 +1,125 @@
+package transform
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jshlbrd/vibestation/config"
+	"github.com/jshlbrd/vibestation/message"
+)
+
+func TestCutField(t *testing.T) {
+	tests := []struct {
+		index    int
+		expected string
+	}{
+		{1, "a"},
+		{3, "c"},
+		{-1, "d"},
+		{-4, "a"},
+	}
+
+	for _, tt := range tests {
+		cfg := config.Config{
+			Type: "cut_field",
+			Settings: map[string]interface{}{
+				"separator": ",",
+				"index":     tt.index,
+			},
+		}
+		tf, err := newCutField(context.Background(), cfg)
+		if err != nil {
+			t.Fatalf("failed to create cut_field transform: %v", err)
+		}
+
+		results, err := tf.Transform(context.Background(), message.New().SetData([]byte("a,b,c,d")))
+		if err != nil {
+			t.Fatalf("transform failed: %v", err)
+		}
+		if got := string(results[0].Data()); got != tt.expected {
+			t.Errorf("index %d: expected %q, got %q", tt.index, tt.expected, got)
+		}
+	}
+}
+
+func TestCutField_SourceTarget(t *testing.T) {
+	cfg := config.Config{
+		Type: "cut_field",
+		Settings: map[string]interface{}{
+			"separator": " ",
+			"index":     2,
+			"source":    "$.line",
+			"target":    "$.user",
+		},
+	}
+	tf, err := newCutField(context.Background(), cfg)
+	if err != nil {
+		t.Fatalf("failed to create cut_field transform: %v", err)
+	}
+
+	results, err := tf.Transform(context.Background(), message.New().SetData([]byte(`{"line":"GET alice /index"}`)))
+	if err != nil {
+		t.Fatalf("transform failed: %v", err)
+	}
+	if got := results[0].GetValue("$.user").String(); got != "alice" {
+		t.Errorf("expected %q, got %q", "alice", got)
+	}
+}
+
+func TestCutField_OutOfRange(t *testing.T) {
+	settings := map[string]interface{}{
+		"separator": ",",
+		"index":     -5,
+	}
+
+	tf, err := newCutField(context.Background(), config.Config{Type: "cut_field", Settings: settings})
+	if err != nil {
+		t.Fatalf("failed to create cut_field transform: %v", err)
+	}
+	results, err := tf.Transform(context.Background(), message.New().SetData([]byte("a,b")))
+	if err != nil {
+		t.Fatalf("transform failed: %v", err)
+	}
+	if got := string(results[0].Data()); got != "a,b" {
+		t.Errorf("expected data to be unchanged, got %q", got)
+	}
+
+	settings["strict"] = true
+	tf, err = newCutField(context.Background(), config.Config{Type: "cut_field", Settings: settings})
+	if err != nil {
+		t.Fatalf("failed to create cut_field transform: %v", err)
+	}
+	if _, err := tf.Transform(context.Background(), message.New().SetData([]byte("a,b"))); err == nil {
+		t.Fatal("expected error in strict mode, got nil")
+	}
+}
+
+func TestCutField_ZeroIndex(t *testing.T) {
+	cfg := config.Config{
+		Type:     "cut_field",
+		Settings: map[string]interface{}{"separator": ","},
+	}
+	if _, err := newCutField(context.Background(), cfg); err == nil {
+		t.Fatal("expected error for zero index, got nil")
+	}
+}
+
+func TestCutField_NumericSeparator(t *testing.T) {
+	// Unquoted SUB values such as separator=1 are parsed as numbers.
+	cfg := config.Config{
+		Type:     "cut_field",
+		Settings: map[string]interface{}{"separator": 1, "index": 2},
+	}
+	tf, err := newCutField(context.Background(), cfg)
+	if err != nil {
+		t.Fatalf("failed to create cut_field transform: %v", err)
+	}
+
+	results, err := tf.Transform(context.Background(), message.New().SetData([]byte("a1b1c")))
+	if err != nil {
+		t.Fatalf("transform failed: %v", err)
+	}
+	if got := string(results[0].Data()); got != "b" {
+		t.Errorf("expected %q, got %q", "b", got)
+	}
+}
@@ -0,0 +1,118 @@
+package transform
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/jshlbrd/vibestation/config"
+	"github.com/jshlbrd/vibestation/message"
+)
+
+type DebounceConfig struct {
+	// Wait is the quiet period, as a Go duration string (e.g. "500ms"), that
+	// must pass without a new message before the latest message is emitted.
+	Wait string `json:"wait"`
+	ID   string `json:"id"`
+}
+
+func (c *DebounceConfig) Decode(in interface{}) error {
+	return config.Decode(in, c)
+}
+
+func newDebounce(_ context.Context, cfg config.Config) (*Debounce, error) {
+	conf := DebounceConfig{}
+	if err := conf.Decode(cfg.Settings); err != nil {
+		return nil, fmt.Errorf("transform debounce: %v", err)
+	}
+	if conf.ID == "" {
+		conf.ID = "debounce"
+	}
+	if conf.Wait == "" {
+		return nil, fmt.Errorf("transform %s: wait: missing required option", conf.ID)
+	}
+
+	wait, err := time.ParseDuration(conf.Wait)
+	if err != nil {
+		return nil, fmt.Errorf("transform %s: wait: %v", conf.ID, err)
+	}
+	if wait <= 0 {
+		return nil, fmt.Errorf("transform %s: wait: must be greater than 0", conf.ID)
+	}
+
+	tf := Debounce{
+		conf:     conf,
+		settings: cfg.Settings,
+		wait:     wait,
+	}
+	return &tf, nil
+}
+
+// Debounce holds the latest data message and emits it only after the wait
+// duration passes without a new message.
+//
+// Transforms only run when a message is applied, so there is no timer that
+// emits on its own. Instead, the held message is emitted by the next call
+// that observes the quiet period:
+//
+//   - A data message that arrives after the quiet period emits the held
+//     message and is held in its place. A data message that arrives sooner
+//     replaces the held message, which is dropped.
+//   - A control message waits (honoring the context) for the remainder of
+//     the quiet period, then emits the held message followed by the control
+//     message.
+//
+// Pipelines that use this transform should send a control message at the end
+// of input to flush the final message.
+type Debounce struct {
+	conf     DebounceConfig
+	settings map[string]interface{}
+	wait     time.Duration
+
+	mu   sync.Mutex
+	held *message.Message
+	last time.Time
+}
+
+func (tf *Debounce) Transform(ctx context.Context, msg *message.Message) ([]*message.Message, error) {
+	tf.mu.Lock()
+	defer tf.mu.Unlock()
+
+	if !msg.IsControl() {
+		var out []*message.Message
+		now := time.Now()
+		if tf.held != nil && now.Sub(tf.last) >= tf.wait {
+			out = append(out, tf.held)
+		}
+
+		tf.held = msg
+		tf.last = now
+		return out, nil
+	}
+
+	if tf.held == nil {
+		return []*message.Message{msg}, nil
+	}
+
+	if remaining := tf.wait - time.Since(tf.last); remaining > 0 {
+		timer := time.NewTimer(remaining)
+		defer timer.Stop()
+
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("transform %s: %v", tf.conf.ID, ctx.Err())
+		case <-timer.C:
+		}
+	}
+
+	held := tf.held
+	tf.held = nil
+	return []*message.Message{held, msg}, nil
+}
+
+func (tf *Debounce) String() string {
+	b, _ := json.Marshal(tf.conf)
+	return string(b)
+}
@@ -0,0 +1,94 @@
+package transform
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jshlbrd/vibestation/config"
+	"github.com/jshlbrd/vibestation/message"
+)
+
+func TestDebounce(t *testing.T) {
+	cfg := config.Config{
+		Type:     "debounce",
+		Settings: map[string]interface{}{"wait": "20ms"},
+	}
+	tf, err := newDebounce(context.Background(), cfg)
+	if err != nil {
+		t.Fatalf("failed to create debounce transform: %v", err)
+	}
+
+	ctx := context.Background()
+
+	// A burst of messages is held, and only the latest is kept.
+	for _, d := range []string{"a", "b"} {
+		results, err := tf.Transform(ctx, message.New().SetData([]byte(d)))
+		if err != nil {
+			t.Fatalf("transform failed: %v", err)
+		}
+		if len(results) != 0 {
+			t.Fatalf("expected no results during burst, got %d", len(results))
+		}
+	}
+
+	// After the quiet period, the next message emits the held message.
+	time.Sleep(30 * time.Millisecond)
+	results, err := tf.Transform(ctx, message.New().SetData([]byte("c")))
+	if err != nil {
+		t.Fatalf("transform failed: %v", err)
+	}
+	if len(results) != 1 || string(results[0].Data()) != "b" {
+		t.Fatalf("expected held message b, got %d results", len(results))
+	}
+
+	// A control message waits out the quiet period and flushes.
+	start := time.Now()
+	results, err = tf.Transform(ctx, message.New().AsControl())
+	if err != nil {
+		t.Fatalf("transform failed: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed < 15*time.Millisecond {
+		t.Errorf("expected control message to wait for the quiet period, waited %v", elapsed)
+	}
+	if len(results) != 2 {
+		t.Fatalf("expected 2 results, got %d", len(results))
+	}
+	if string(results[0].Data()) != "c" {
+		t.Errorf("expected held message c, got %s", string(results[0].Data()))
+	}
+	if !results[1].IsControl() {
+		t.Error("expected control message after held message")
+	}
+}
+
+func TestDebounce_Context(t *testing.T) {
+	cfg := config.Config{
+		Type:     "debounce",
+		Settings: map[string]interface{}{"wait": "1s"},
+	}
+	tf, err := newDebounce(context.Background(), cfg)
+	if err != nil {
+		t.Fatalf("failed to create debounce transform: %v", err)
+	}
+
+	if _, err := tf.Transform(context.Background(), message.New().SetData([]byte("a"))); err != nil {
+		t.Fatalf("transform failed: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := tf.Transform(ctx, message.New().AsControl()); err == nil {
+		t.Fatal("expected error for cancelled context, got nil")
+	}
+}
+
+func TestDebounce_InvalidWait(t *testing.T) {
+	cfg := config.Config{
+		Type:     "debounce",
+		Settings: map[string]interface{}{"wait": "soon"},
+	}
+	if _, err := newDebounce(context.Background(), cfg); err == nil {
+		t.Fatal("expected error for invalid wait, got nil")
+	}
+}
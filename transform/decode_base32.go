@@ -0,0 +1,138 @@
+package transform
+
+import (
+	"context"
+	"encoding/base32"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/jshlbrd/vibestation/config"
+	"github.com/jshlbrd/vibestation/message"
+)
+
+type DecodeBase32Config struct {
+	// NoPadding decodes data that was encoded without padding characters.
+	NoPadding bool   `json:"no_padding"`
+	ID        string `json:"id"`
+}
+
+func (c *DecodeBase32Config) Decode(in interface{}) error {
+	return config.Decode(in, c)
+}
+
+func newDecodeBase32(_ context.Context, cfg config.Config) (*DecodeBase32Transform, error) {
+	conf := DecodeBase32Config{}
+	if err := conf.Decode(cfg.Settings); err != nil {
+		return nil, fmt.Errorf("transform decode_base32: %v", err)
+	}
+
+	// Use settings to determine ID (named only)
+	id := "decode_base32"
+	if v, ok := cfg.Settings["id"]; ok {
+		if s, ok := v.(string); ok && s != "" {
+			id = s
+		}
+	}
+	conf.ID = id
+
+	// Universal source argument (named only)
+	var sourcePath string
+	if v, ok := cfg.Settings["source"]; ok {
+		if s, ok := v.(string); ok {
+			sourcePath = s
+		}
+	}
+
+	// Target path for assignments
+	var targetPath string
+	if v, ok := cfg.Settings["target"]; ok {
+		if s, ok := v.(string); ok {
+			targetPath = s
+		}
+	}
+
+	tf := DecodeBase32Transform{
+		conf:       conf,
+		settings:   cfg.Settings,
+		sourcePath: sourcePath,
+		targetPath: targetPath,
+	}
+
+	return &tf, nil
+}
+
+type DecodeBase32Transform struct {
+	conf       DecodeBase32Config
+	settings   map[string]interface{}
+	sourcePath string
+	targetPath string
+}
+
+func (tf *DecodeBase32Transform) Transform(ctx context.Context, msg *message.Message) ([]*message.Message, error) {
+	if msg.IsControl() {
+		return []*message.Message{msg}, nil
+	}
+
+	// Determine input data
+	var inputData []byte
+	if tf.sourcePath != "" {
+		val := msg.GetValue(tf.sourcePath)
+		if val.Exists() {
+			inputData = val.Bytes()
+		}
+	}
+	if inputData == nil {
+		inputData = msg.Data()
+	}
+
+	decoded, err := decodeBase32(inputData, base32Encoding(tf.conf.NoPadding))
+	if err != nil {
+		return nil, fmt.Errorf("transform %s: %v", tf.conf.ID, err)
+	}
+
+	// If we have a target path, store the result there
+	if tf.targetPath != "" {
+		err := msg.SetValue(tf.targetPath, string(decoded))
+		if err != nil {
+			return nil, fmt.Errorf("transform %s: failed to set target: %v", tf.conf.ID, err)
+		}
+	} else {
+		// Otherwise, set as message data
+		msg.SetData(decoded)
+	}
+
+	return []*message.Message{msg}, nil
+}
+
+func (tf *DecodeBase32Transform) String() string {
+	b, _ := json.Marshal(tf.conf)
+	return string(b)
+}
+
+// decodeBase32 decodes base32-encoded data.
+func decodeBase32(data []byte, enc *base32.Encoding) ([]byte, error) {
+	if len(data) == 0 {
+		return data, nil
+	}
+
+	// Convert to string and trim whitespace
+	input := strings.TrimSpace(string(data))
+
+	// Decode base32
+	decoded, err := enc.DecodeString(input)
+	if err != nil {
+		return nil, fmt.Errorf("base32 decode error: %v", err)
+	}
+
+	return decoded, nil
+}
+
+// base32Encoding returns the standard base32 encoding, optionally without padding.
+func base32Encoding(noPadding bool) *base32.Encoding {
+	if noPadding {
+		return base32.StdEncoding.WithPadding(base32.NoPadding)
+	}
+
+	return base32.StdEncoding
+}
@@ -0,0 +1,63 @@
+package transform
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jshlbrd/vibestation/config"
+	"github.com/jshlbrd/vibestation/message"
+)
+
+func TestDecodeBase32Transform_Valid(t *testing.T) {
+	tests := []struct {
+		noPadding bool
+		input     string
+	}{
+		{false, "ORSXG5BAMRQXIYI="},
+		{true, "ORSXG5BAMRQXIYI"},
+	}
+
+	for _, test := range tests {
+		cfg := config.Config{
+			Type: "decode_base32",
+			Settings: map[string]interface{}{
+				"no_padding": test.noPadding,
+			},
+		}
+		tf, err := newDecodeBase32(context.Background(), cfg)
+		if err != nil {
+			t.Fatalf("failed to create decode_base32 transform: %v", err)
+		}
+
+		msg := message.New().SetData([]byte(test.input))
+		msgs, err := tf.Transform(context.Background(), msg)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if string(msgs[0].Data()) != "test data" {
+			t.Errorf("expected %q, got %q", "test data", string(msgs[0].Data()))
+		}
+	}
+}
+
+func TestDecodeBase32Transform_Invalid(t *testing.T) {
+	cfg := config.Config{
+		Type: "decode_base32",
+		Settings: map[string]interface{}{
+			"source": "$.foo",
+		},
+	}
+	tf, err := newDecodeBase32(context.Background(), cfg)
+	if err != nil {
+		t.Fatalf("failed to create decode_base32 transform: %v", err)
+	}
+
+	msg := message.New().SetData([]byte(`{"foo": "not base32!"}`))
+	msgs, err := tf.Transform(context.Background(), msg)
+	if err == nil {
+		t.Fatal("expected error for invalid base32, got nil")
+	}
+	if msgs != nil {
+		t.Errorf("expected no messages on error, got %v", msgs)
+	}
+}
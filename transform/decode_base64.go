@@ -12,20 +12,14 @@ import (
 )
 
 type DecodeBase64Config struct {
-	ID string `json:"id"`
+	// Encoding is the base64 alphabet, one of std (default), url, rawstd, or
+	// rawurl. The raw variants omit padding.
+	Encoding string `json:"encoding"`
+	ID       string `json:"id"`
 }
 
 func (c *DecodeBase64Config) Decode(in interface{}) error {
-	if in == nil {
-		return nil
-	}
-
-	b, err := json.Marshal(in)
-	if err != nil {
-		return err
-	}
-
-	return json.Unmarshal(b, c)
+	return config.Decode(in, c)
 }
 
 func newDecodeBase64(_ context.Context, cfg config.Config) (*DecodeBase64Transform, error) {
@@ -43,6 +37,14 @@ func newDecodeBase64(_ context.Context, cfg config.Config) (*DecodeBase64Transfo
 	}
 	conf.ID = id
 
+	if conf.Encoding == "" {
+		conf.Encoding = "std"
+	}
+	encoding, err := base64Encoding(conf.Encoding)
+	if err != nil {
+		return nil, fmt.Errorf("transform %s: encoding: %v", conf.ID, err)
+	}
+
 	// Universal source argument (named only)
 	var sourcePath string
 	if v, ok := cfg.Settings["source"]; ok {
@@ -64,16 +66,24 @@ func newDecodeBase64(_ context.Context, cfg config.Config) (*DecodeBase64Transfo
 		settings:   cfg.Settings,
 		sourcePath: sourcePath,
 		targetPath: targetPath,
+		encoding:   encoding,
 	}
 
 	return &tf, nil
 }
 
+// DecodeBase64Transform decodes base64 data from the source (or message data)
+// and writes it to the target (or message data).
+//
+// When source and target are the same path, the field is decoded in place:
+// only that field is replaced and the rest of the object is preserved. In
+// this mode the source must exist, otherwise an error is returned.
 type DecodeBase64Transform struct {
 	conf       DecodeBase64Config
 	settings   map[string]interface{}
 	sourcePath string
 	targetPath string
+	encoding   *base64.Encoding
 }
 
 func (tf *DecodeBase64Transform) Transform(ctx context.Context, msg *message.Message) ([]*message.Message, error) {
@@ -83,6 +93,9 @@ func (tf *DecodeBase64Transform) Transform(ctx context.Context, msg *message.Mes
 
 	// Determine input data
 	var inputData []byte
+	if tf.inPlace() && !msg.GetValue(tf.sourcePath).Exists() {
+		return nil, fmt.Errorf("transform %s: source %s not found", tf.conf.ID, tf.sourcePath)
+	}
 	if tf.sourcePath != "" {
 		val := msg.GetValue(tf.sourcePath)
 		if val.Exists() {
@@ -93,7 +106,7 @@ func (tf *DecodeBase64Transform) Transform(ctx context.Context, msg *message.Mes
 		inputData = msg.Data()
 	}
 
-	decoded, err := decodeBase64(inputData)
+	decoded, err := decodeBase64(inputData, tf.encoding)
 	if err != nil {
 		return nil, fmt.Errorf("transform %s: %v", tf.conf.ID, err)
 	}
@@ -112,13 +125,19 @@ func (tf *DecodeBase64Transform) Transform(ctx context.Context, msg *message.Mes
 	return []*message.Message{msg}, nil
 }
 
+// inPlace returns true if the source field is decoded and written back to
+// the same path.
+func (tf *DecodeBase64Transform) inPlace() bool {
+	return tf.sourcePath != "" && tf.sourcePath == tf.targetPath
+}
+
 func (tf *DecodeBase64Transform) String() string {
 	b, _ := json.Marshal(tf.conf)
 	return string(b)
 }
 
-// decodeBase64 decodes base64-encoded data.
-func decodeBase64(data []byte) ([]byte, error) {
+// decodeBase64 decodes base64-encoded data using the given encoding.
+func decodeBase64(data []byte, encoding *base64.Encoding) ([]byte, error) {
 	if len(data) == 0 {
 		return data, nil
 	}
@@ -127,10 +146,26 @@ func decodeBase64(data []byte) ([]byte, error) {
 	input := strings.TrimSpace(string(data))
 
 	// Decode base64
-	decoded, err := base64.StdEncoding.DecodeString(input)
+	decoded, err := encoding.DecodeString(input)
 	if err != nil {
 		return nil, fmt.Errorf("base64 decode error: %v", err)
 	}
 
 	return decoded, nil
 }
+
+// base64Encoding returns the base64 encoding for the named alphabet.
+func base64Encoding(name string) (*base64.Encoding, error) {
+	switch name {
+	case "std":
+		return base64.StdEncoding, nil
+	case "url":
+		return base64.URLEncoding, nil
+	case "rawstd":
+		return base64.RawStdEncoding, nil
+	case "rawurl":
+		return base64.RawURLEncoding, nil
+	default:
+		return nil, fmt.Errorf("unsupported value %q", name)
+	}
+}
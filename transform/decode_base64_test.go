@@ -97,6 +97,41 @@ func TestDecodeBase64Transform_WithTarget(t *testing.T) {
 	}
 }
 
+func TestDecodeBase64Transform_InPlace(t *testing.T) {
+	cfg := config.Config{
+		Type: "decode_base64",
+		Settings: map[string]interface{}{
+			"source": "$.payload",
+			"target": "$.payload",
+		},
+	}
+
+	tf, err := newDecodeBase64(context.Background(), cfg)
+	if err != nil {
+		t.Fatalf("failed to create decode_base64 transform: %v", err)
+	}
+
+	msg := message.New()
+	msg.SetData([]byte(`{"id":1,"payload":"dGVzdCBkYXRh"}`))
+
+	msgs, err := tf.Transform(context.Background(), msg)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := `{"id":1,"payload":"test data"}`
+	if string(msgs[0].Data()) != expected {
+		t.Errorf("expected %s, got %s", expected, string(msgs[0].Data()))
+	}
+
+	// The source must exist when decoding in place.
+	msg = message.New()
+	msg.SetData([]byte(`{"id":1}`))
+	if _, err := tf.Transform(context.Background(), msg); err == nil {
+		t.Fatal("expected error for missing in-place source, got nil")
+	}
+}
+
 func TestDecodeBase64Transform_NoSource(t *testing.T) {
 	cfg := config.Config{
 		Type:     "decode_base64",
@@ -151,3 +186,60 @@ func TestDecodeBase64Transform_ControlMessage(t *testing.T) {
 		t.Error("expected control message to remain control message")
 	}
 }
+
+func TestDecodeBase64Transform_Encoding(t *testing.T) {
+	payload := "\xfb\xff\xfeo?"
+	tests := []struct {
+		encoding string
+		input    string
+	}{
+		{"std", "+//+bz8="},
+		{"url", "-__-bz8="},
+		{"rawstd", "+//+bz8"},
+		{"rawurl", "-__-bz8"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.encoding, func(t *testing.T) {
+			cfg := config.Config{
+				Type: "decode_base64",
+				Settings: map[string]interface{}{
+					"encoding": test.encoding,
+				},
+			}
+			tf, err := newDecodeBase64(context.Background(), cfg)
+			if err != nil {
+				t.Fatalf("failed to create decode_base64 transform: %v", err)
+			}
+
+			msgs, err := tf.Transform(context.Background(), message.New().SetData([]byte(test.input)))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got := string(msgs[0].Data()); got != payload {
+				t.Errorf("expected %q, got %q", payload, got)
+			}
+		})
+	}
+
+	// The default alphabet rejects URL-safe input.
+	tf, err := newDecodeBase64(context.Background(), config.Config{Type: "decode_base64"})
+	if err != nil {
+		t.Fatalf("failed to create decode_base64 transform: %v", err)
+	}
+	if _, err := tf.Transform(context.Background(), message.New().SetData([]byte("-__-bz8="))); err == nil {
+		t.Error("expected error for URL-safe input with std encoding, got nil")
+	}
+}
+
+func TestDecodeBase64Transform_InvalidEncoding(t *testing.T) {
+	cfg := config.Config{
+		Type: "decode_base64",
+		Settings: map[string]interface{}{
+			"encoding": "base58",
+		},
+	}
+	if _, err := newDecodeBase64(context.Background(), cfg); err == nil {
+		t.Fatal("expected error for unknown encoding, got nil")
+	}
+}
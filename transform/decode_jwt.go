@@ -0,0 +1,124 @@
+package transform
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/jshlbrd/vibestation/config"
+	"github.com/jshlbrd/vibestation/message"
+)
+
+type DecodeJWTConfig struct {
+	ID string `json:"id"`
+}
+
+func (c *DecodeJWTConfig) Decode(in interface{}) error {
+	return config.Decode(in, c)
+}
+
+func newDecodeJWT(_ context.Context, cfg config.Config) (*DecodeJWT, error) {
+	conf := DecodeJWTConfig{}
+	if err := conf.Decode(cfg.Settings); err != nil {
+		return nil, fmt.Errorf("transform decode_jwt: %v", err)
+	}
+	if conf.ID == "" {
+		conf.ID = "decode_jwt"
+	}
+
+	var sourcePath string
+	if v, ok := cfg.Settings["source"]; ok {
+		if s, ok := v.(string); ok {
+			sourcePath = s
+		}
+	}
+
+	var targetPath string
+	if v, ok := cfg.Settings["target"]; ok {
+		if s, ok := v.(string); ok {
+			targetPath = s
+		}
+	}
+
+	tf := DecodeJWT{
+		conf:       conf,
+		settings:   cfg.Settings,
+		sourcePath: sourcePath,
+		targetPath: targetPath,
+	}
+	return &tf, nil
+}
+
+// DecodeJWT extracts the claims from a JSON Web Token.
+//
+// The token's signature is not verified, so the claims must not be trusted
+// for authentication or authorization decisions.
+type DecodeJWT struct {
+	conf       DecodeJWTConfig
+	settings   map[string]interface{}
+	sourcePath string
+	targetPath string
+}
+
+func (tf *DecodeJWT) Transform(ctx context.Context, msg *message.Message) ([]*message.Message, error) {
+	if msg.IsControl() {
+		return []*message.Message{msg}, nil
+	}
+
+	var inputData []byte
+	if tf.sourcePath != "" {
+		val := msg.GetValue(tf.sourcePath)
+		if val.Exists() {
+			inputData = val.Bytes()
+		}
+	}
+	if inputData == nil {
+		inputData = msg.Data()
+	}
+
+	payload, err := decodeJWTPayload(string(inputData))
+	if err != nil {
+		return nil, fmt.Errorf("transform %s: %v", tf.conf.ID, err)
+	}
+
+	if tf.targetPath != "" {
+		var claims interface{}
+		if err := json.Unmarshal(payload, &claims); err != nil {
+			return nil, fmt.Errorf("transform %s: %v", tf.conf.ID, err)
+		}
+		if err := msg.SetValue(tf.targetPath, claims); err != nil {
+			return nil, fmt.Errorf("transform %s: failed to set target: %v", tf.conf.ID, err)
+		}
+	} else {
+		msg.SetData(payload)
+	}
+
+	return []*message.Message{msg}, nil
+}
+
+func (tf *DecodeJWT) String() string {
+	b, _ := json.Marshal(tf.conf)
+	return string(b)
+}
+
+// decodeJWTPayload returns the JSON payload segment of a JWT.
+func decodeJWTPayload(token string) ([]byte, error) {
+	parts := strings.Split(strings.TrimSpace(token), ".")
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("invalid JWT: expected 3 segments, got %d", len(parts))
+	}
+
+	// Segments are base64url encoded without padding, but some encoders
+	// include it anyway.
+	payload, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(parts[1], "="))
+	if err != nil {
+		return nil, fmt.Errorf("invalid JWT payload: %v", err)
+	}
+	if !json.Valid(payload) {
+		return nil, fmt.Errorf("invalid JWT payload: not JSON")
+	}
+
+	return payload, nil
+}
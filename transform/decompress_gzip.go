@@ -17,16 +17,7 @@ type DecompressGzipConfig struct {
 }
 
 func (c *DecompressGzipConfig) Decode(in interface{}) error {
-	if in == nil {
-		return nil
-	}
-
-	b, err := json.Marshal(in)
-	if err != nil {
-		return err
-	}
-
-	return json.Unmarshal(b, c)
+	return config.Decode(in, c)
 }
 
 func newDecompressGzip(_ context.Context, cfg config.Config) (*DecompressGzip, error) {
@@ -112,6 +103,25 @@ func (tf *DecompressGzip) Transform(ctx context.Context, msg *message.Message) (
 	return []*message.Message{msg}, nil
 }
 
+// TransformReader returns a reader that decompresses r incrementally as it
+// is read and is used by ApplyReader. Unlike Transform, the decompressed data
+// is never fully buffered in memory, which makes this suitable for large
+// payloads. The stream is raw data, so the source and target settings are not
+// supported and return an error. The caller is responsible for closing the
+// returned reader.
+func (tf *DecompressGzip) TransformReader(_ context.Context, r io.Reader) (io.ReadCloser, error) {
+	if tf.sourcePath != "" || tf.targetPath != "" {
+		return nil, fmt.Errorf("transform %s: source and target are not supported when streaming", tf.conf.ID)
+	}
+
+	reader, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("transform %s: %v", tf.conf.ID, err)
+	}
+
+	return reader, nil
+}
+
 func (tf *DecompressGzip) String() string {
 	b, _ := json.Marshal(tf.conf)
 	return string(b)
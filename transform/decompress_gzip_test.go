@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"compress/gzip"
 	"context"
+	"io"
 	"testing"
 
 	"github.com/jshlbrd/vibestation/config"
@@ -195,3 +196,143 @@ func TestDecompressGzipTransform_ControlMessage(t *testing.T) {
 		t.Error("expected control message to remain control message")
 	}
 }
+
+func TestDecompressGzipTransform_Reader(t *testing.T) {
+	tf, err := newDecompressGzip(context.Background(), config.Config{Type: "decompress_gzip"})
+	if err != nil {
+		t.Fatalf("failed to create decompress_gzip transform: %v", err)
+	}
+
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	gz.Write([]byte("streamed data"))
+	gz.Close()
+
+	r, err := ApplyReader(context.Background(), []Transformer{tf}, &buf)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer r.Close()
+
+	out, err := io.ReadAll(r)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if string(out) != "streamed data" {
+		t.Errorf("expected %q, got %q", "streamed data", string(out))
+	}
+}
+
+func TestDecompressGzipTransform_ReaderSourceTarget(t *testing.T) {
+	settings := []map[string]interface{}{
+		{"source": "$.compressed"},
+		{"target": "$.decompressed"},
+	}
+
+	for _, s := range settings {
+		tf, err := newDecompressGzip(context.Background(), config.Config{Type: "decompress_gzip", Settings: s})
+		if err != nil {
+			t.Fatalf("failed to create decompress_gzip transform: %v", err)
+		}
+
+		var buf bytes.Buffer
+		gz := gzip.NewWriter(&buf)
+		gz.Write([]byte("streamed data"))
+		gz.Close()
+
+		if _, err := ApplyReader(context.Background(), []Transformer{tf}, &buf); err == nil {
+			t.Errorf("expected error for settings %v, got nil", s)
+		}
+	}
+}
+
+// maxStreamingAllocs bounds the allocations made when streaming the gzip
+// fixture. The streaming path allocates a fixed number of buffers no matter
+// how large the payload is.
+const maxStreamingAllocs = 16
+
+func TestDecompressGzipTransform_ReaderAllocs(t *testing.T) {
+	fixture := gzipFixture(t, 1<<16)
+	tf, err := newDecompressGzip(context.Background(), config.Config{Type: "decompress_gzip"})
+	if err != nil {
+		t.Fatalf("failed to create decompress_gzip transform: %v", err)
+	}
+
+	streaming := testing.AllocsPerRun(5, func() {
+		r, err := ApplyReader(context.Background(), []Transformer{tf}, bytes.NewReader(fixture))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := io.Copy(io.Discard, r); err != nil {
+			t.Fatal(err)
+		}
+		r.Close()
+	})
+	buffered := testing.AllocsPerRun(5, func() {
+		if _, err := tf.Transform(context.Background(), message.New().SetData(fixture)); err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	if streaming > maxStreamingAllocs {
+		t.Errorf("expected at most %d allocs per run when streaming, got %v", maxStreamingAllocs, streaming)
+	}
+	if streaming >= buffered {
+		t.Errorf("expected streaming (%v allocs) to allocate less than buffering (%v allocs)", streaming, buffered)
+	}
+}
+
+// gzipFixture returns a compressed payload of the given number of lines.
+func gzipFixture(tb testing.TB, lines int) []byte {
+	tb.Helper()
+
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	line := []byte("the quick brown fox jumps over the lazy dog\n")
+	for i := 0; i < lines; i++ {
+		gz.Write(line)
+	}
+	if err := gz.Close(); err != nil {
+		tb.Fatal(err)
+	}
+
+	return buf.Bytes()
+}
+
+func BenchmarkDecompressGzip_Buffered(b *testing.B) {
+	fixture := gzipFixture(b, 1<<18)
+	tf, err := newDecompressGzip(context.Background(), config.Config{Type: "decompress_gzip"})
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		msg := message.New().SetData(fixture)
+		if _, err := tf.Transform(context.Background(), msg); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkDecompressGzip_Streaming(b *testing.B) {
+	fixture := gzipFixture(b, 1<<18)
+	tf, err := newDecompressGzip(context.Background(), config.Config{Type: "decompress_gzip"})
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		r, err := ApplyReader(context.Background(), []Transformer{tf}, bytes.NewReader(fixture))
+		if err != nil {
+			b.Fatal(err)
+		}
+		if _, err := io.Copy(io.Discard, r); err != nil {
+			b.Fatal(err)
+		}
+		r.Close()
+	}
+}
@@ -0,0 +1,107 @@
+package transform
+
+import (
+	"container/list"
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"github.com/jshlbrd/vibestation/config"
+	"github.com/jshlbrd/vibestation/message"
+)
+
+type DedupeWindowConfig struct {
+	// Size is the maximum number of keys remembered. When the window is
+	// full, the least recently seen key is forgotten.
+	Size int `json:"size"`
+	// Key is the JSON path to the value used to identify duplicates. If not
+	// set, then the data is used.
+	Key string `json:"key"`
+	ID  string `json:"id"`
+}
+
+func (c *DedupeWindowConfig) Decode(in interface{}) error {
+	return config.Decode(in, c)
+}
+
+func (c *DedupeWindowConfig) Validate() error {
+	if c.Size <= 0 {
+		return fmt.Errorf("size: must be greater than 0")
+	}
+	return nil
+}
+
+func newDedupeWindow(_ context.Context, cfg config.Config) (*DedupeWindow, error) {
+	conf := DedupeWindowConfig{}
+	if err := conf.Decode(cfg.Settings); err != nil {
+		return nil, fmt.Errorf("transform dedupe_window: %v", err)
+	}
+	if conf.ID == "" {
+		conf.ID = "dedupe_window"
+	}
+	if err := conf.Validate(); err != nil {
+		return nil, fmt.Errorf("transform %s: %v", conf.ID, err)
+	}
+
+	tf := DedupeWindow{
+		conf:     conf,
+		settings: cfg.Settings,
+		order:    list.New(),
+		seen:     make(map[[sha256.Size]byte]*list.Element),
+	}
+	return &tf, nil
+}
+
+// DedupeWindow drops messages whose key was seen within the last Size
+// distinct keys. Keys are remembered by their SHA-256 hash in a
+// least-recently-used window, and seeing a duplicate refreshes its position.
+type DedupeWindow struct {
+	conf     DedupeWindowConfig
+	settings map[string]interface{}
+
+	mu    sync.Mutex
+	order *list.List
+	seen  map[[sha256.Size]byte]*list.Element
+}
+
+func (tf *DedupeWindow) Transform(ctx context.Context, msg *message.Message) ([]*message.Message, error) {
+	if msg.IsControl() {
+		return []*message.Message{msg}, nil
+	}
+
+	var key []byte
+	if tf.conf.Key != "" {
+		val := msg.GetValue(tf.conf.Key)
+		if !val.Exists() {
+			return nil, fmt.Errorf("transform %s: key %s not found", tf.conf.ID, tf.conf.Key)
+		}
+		key = val.Bytes()
+	} else {
+		key = msg.Data()
+	}
+	sum := sha256.Sum256(key)
+
+	tf.mu.Lock()
+	defer tf.mu.Unlock()
+
+	if e, ok := tf.seen[sum]; ok {
+		tf.order.MoveToFront(e)
+		return nil, nil
+	}
+
+	tf.seen[sum] = tf.order.PushFront(sum)
+	if tf.order.Len() > tf.conf.Size {
+		oldest := tf.order.Back()
+		tf.order.Remove(oldest)
+		delete(tf.seen, oldest.Value.([sha256.Size]byte))
+	}
+
+	return []*message.Message{msg}, nil
+}
+
+func (tf *DedupeWindow) String() string {
+	b, _ := json.Marshal(tf.conf)
+	return string(b)
+}
@@ -0,0 +1,80 @@
+package transform
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jshlbrd/vibestation/config"
+	"github.com/jshlbrd/vibestation/message"
+)
+
+func TestDedupeWindow(t *testing.T) {
+	cfg := config.Config{
+		Type: "dedupe_window",
+		Settings: map[string]interface{}{
+			"size": 2,
+			"key":  "$.id",
+		},
+	}
+	tf, err := newDedupeWindow(context.Background(), cfg)
+	if err != nil {
+		t.Fatalf("failed to create dedupe_window transform: %v", err)
+	}
+
+	tests := []struct {
+		id   string
+		kept bool
+	}{
+		{"a", true},
+		{"b", true},
+		{"a", false}, // duplicate within the window, refreshes a
+		{"c", true},  // evicts b
+		{"a", false}, // still in the window
+		{"b", true},  // evicted, so no longer a duplicate; evicts c
+		{"c", true},
+	}
+
+	for i, test := range tests {
+		msg := message.New().SetData([]byte(`{"id":"` + test.id + `"}`))
+		results, err := tf.Transform(context.Background(), msg)
+		if err != nil {
+			t.Fatalf("transform failed: %v", err)
+		}
+		if got := len(results) == 1; got != test.kept {
+			t.Errorf("message %d (%s): expected kept=%v, got kept=%v", i, test.id, test.kept, got)
+		}
+	}
+}
+
+func TestDedupeWindow_Data(t *testing.T) {
+	cfg := config.Config{
+		Type:     "dedupe_window",
+		Settings: map[string]interface{}{"size": 10},
+	}
+	tf, err := newDedupeWindow(context.Background(), cfg)
+	if err != nil {
+		t.Fatalf("failed to create dedupe_window transform: %v", err)
+	}
+
+	msgs := []*message.Message{
+		message.New().SetData([]byte("x")),
+		message.New().SetData([]byte("x")),
+		message.New().SetData([]byte("y")),
+		message.New().AsControl(),
+	}
+
+	results, err := Apply(context.Background(), []Transformer{tf}, msgs...)
+	if err != nil {
+		t.Fatalf("transform failed: %v", err)
+	}
+	if len(results) != 3 {
+		t.Fatalf("expected 3 results, got %d", len(results))
+	}
+}
+
+func TestDedupeWindow_InvalidSize(t *testing.T) {
+	cfg := config.Config{Type: "dedupe_window"}
+	if _, err := newDedupeWindow(context.Background(), cfg); err == nil {
+		t.Fatal("expected error for missing size, got nil")
+	}
+}
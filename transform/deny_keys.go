@@ -0,0 +1,89 @@
+package transform
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/jshlbrd/vibestation/config"
+	"github.com/jshlbrd/vibestation/message"
+)
+
+type DenyKeysConfig struct {
+	// Keys is the list of keys that are removed. Nested keys use dotted
+	// paths (e.g. user.password or $.user.password).
+	Keys []string `json:"keys"`
+	ID   string   `json:"id"`
+}
+
+func (c *DenyKeysConfig) Decode(in interface{}) error {
+	return config.Decode(in, c)
+}
+
+func (c *DenyKeysConfig) Validate() error {
+	if len(c.Keys) == 0 {
+		return fmt.Errorf("keys: missing required option")
+	}
+	for i, k := range c.Keys {
+		if k == "" || k == "$" {
+			return fmt.Errorf("keys[%d]: invalid key %q", i, k)
+		}
+	}
+	return nil
+}
+
+func newDenyKeys(_ context.Context, cfg config.Config) (*DenyKeys, error) {
+	conf := DenyKeysConfig{}
+	if err := conf.Decode(cfg.Settings); err != nil {
+		return nil, fmt.Errorf("transform deny_keys: %v", err)
+	}
+	if conf.ID == "" {
+		conf.ID = "deny_keys"
+	}
+	if err := conf.Validate(); err != nil {
+		return nil, fmt.Errorf("transform %s: %v", conf.ID, err)
+	}
+
+	paths := make([]string, len(conf.Keys))
+	for i, k := range conf.Keys {
+		if !strings.HasPrefix(k, "$.") {
+			k = "$." + k
+		}
+		paths[i] = k
+	}
+
+	tf := DenyKeys{
+		conf:     conf,
+		settings: cfg.Settings,
+		paths:    paths,
+	}
+	return &tf, nil
+}
+
+// DenyKeys removes every listed key from the data. Unlike redact_keys, the
+// keys are removed rather than masked. Keys that do not exist are ignored.
+type DenyKeys struct {
+	conf     DenyKeysConfig
+	settings map[string]interface{}
+	paths    []string
+}
+
+func (tf *DenyKeys) Transform(ctx context.Context, msg *message.Message) ([]*message.Message, error) {
+	if msg.IsControl() {
+		return []*message.Message{msg}, nil
+	}
+
+	for _, p := range tf.paths {
+		if err := msg.DeleteValue(p); err != nil {
+			return nil, fmt.Errorf("transform %s: failed to delete %s: %v", tf.conf.ID, p, err)
+		}
+	}
+
+	return []*message.Message{msg}, nil
+}
+
+func (tf *DenyKeys) String() string {
+	b, _ := json.Marshal(tf.conf)
+	return string(b)
+}
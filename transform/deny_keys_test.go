@@ -0,0 +1,39 @@
+package transform
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jshlbrd/vibestation/config"
+	"github.com/jshlbrd/vibestation/message"
+)
+
+func TestDenyKeys(t *testing.T) {
+	cfg := config.Config{
+		Type: "deny_keys",
+		Settings: map[string]interface{}{
+			"keys": []interface{}{"user.password", "$.auth.token", "missing.key"},
+		},
+	}
+	tf, err := newDenyKeys(context.Background(), cfg)
+	if err != nil {
+		t.Fatalf("failed to create deny_keys transform: %v", err)
+	}
+
+	data := `{"id":1,"user":{"name":"alice","password":"x"},"auth":{"token":"y","type":"bearer"}}`
+	results, err := tf.Transform(context.Background(), message.New().SetData([]byte(data)))
+	if err != nil {
+		t.Fatalf("transform failed: %v", err)
+	}
+
+	expected := `{"auth":{"type":"bearer"},"id":1,"user":{"name":"alice"}}`
+	if got := string(results[0].Data()); got != expected {
+		t.Errorf("expected %s, got %s", expected, got)
+	}
+}
+
+func TestDenyKeys_MissingKeys(t *testing.T) {
+	if _, err := newDenyKeys(context.Background(), config.Config{Type: "deny_keys"}); err == nil {
+		t.Fatal("expected error for missing keys, got nil")
+	}
+}
@@ -0,0 +1,106 @@
+package transform
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"unicode/utf8"
+
+	"github.com/jshlbrd/vibestation/config"
+	"github.com/jshlbrd/vibestation/message"
+)
+
+type DetectEncodingConfig struct {
+	ID string `json:"id"`
+}
+
+func (c *DetectEncodingConfig) Decode(in interface{}) error {
+	return config.Decode(in, c)
+}
+
+func newDetectEncoding(_ context.Context, cfg config.Config) (*DetectEncoding, error) {
+	conf := DetectEncodingConfig{}
+	if err := conf.Decode(cfg.Settings); err != nil {
+		return nil, fmt.Errorf("transform detect_encoding: %v", err)
+	}
+	if conf.ID == "" {
+		conf.ID = "detect_encoding"
+	}
+
+	var sourcePath string
+	if v, ok := cfg.Settings["source"]; ok {
+		if s, ok := v.(string); ok {
+			sourcePath = s
+		}
+	}
+
+	targetPath := "meta.$.encoding"
+	if v, ok := cfg.Settings["target"]; ok {
+		if s, ok := v.(string); ok && s != "" {
+			targetPath = s
+		}
+	}
+
+	tf := DetectEncoding{
+		conf:       conf,
+		settings:   cfg.Settings,
+		sourcePath: sourcePath,
+		targetPath: targetPath,
+	}
+	return &tf, nil
+}
+
+// DetectEncoding writes a best-guess label for the encoding of the data
+// ("ascii", "utf-8", or "binary") to the target, which defaults to
+// meta.$.encoding.
+type DetectEncoding struct {
+	conf       DetectEncodingConfig
+	settings   map[string]interface{}
+	sourcePath string
+	targetPath string
+}
+
+func (tf *DetectEncoding) Transform(ctx context.Context, msg *message.Message) ([]*message.Message, error) {
+	if msg.IsControl() {
+		return []*message.Message{msg}, nil
+	}
+
+	var inputData []byte
+	if tf.sourcePath != "" {
+		val := msg.GetValue(tf.sourcePath)
+		if val.Exists() {
+			inputData = val.Bytes()
+		}
+	}
+	if inputData == nil {
+		inputData = msg.Data()
+	}
+
+	if err := msg.SetValue(tf.targetPath, detectEncoding(inputData)); err != nil {
+		return nil, fmt.Errorf("transform %s: failed to set target: %v", tf.conf.ID, err)
+	}
+
+	return []*message.Message{msg}, nil
+}
+
+func (tf *DetectEncoding) String() string {
+	b, _ := json.Marshal(tf.conf)
+	return string(b)
+}
+
+// detectEncoding returns a best-guess charset label for data. Null bytes are
+// valid UTF-8 but are rarely present in text, so they indicate binary data.
+func detectEncoding(data []byte) string {
+	if bytes.IndexByte(data, 0) >= 0 || !utf8.Valid(data) {
+		return "binary"
+	}
+
+	for _, b := range data {
+		if b >= utf8.RuneSelf {
+			return "utf-8"
+		}
+	}
+
+	return "ascii"
+}
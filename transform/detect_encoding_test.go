@@ -0,0 +1,44 @@
+package transform
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jshlbrd/vibestation/config"
+	"github.com/jshlbrd/vibestation/message"
+)
+
+func TestDetectEncoding(t *testing.T) {
+	tests := []struct {
+		name     string
+		data     []byte
+		expected string
+	}{
+		{"ascii", []byte("plain text"), "ascii"},
+		{"utf-8", []byte("café ☕"), "utf-8"},
+		{"invalid utf-8", []byte{0x66, 0xe9, 0x74, 0x65}, "binary"},
+		{"null bytes", []byte{0x1f, 0x8b, 0x00, 0x00}, "binary"},
+	}
+
+	tf, err := newDetectEncoding(context.Background(), config.Config{Type: "detect_encoding"})
+	if err != nil {
+		t.Fatalf("failed to create detect_encoding transform: %v", err)
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			msg := message.New().SetData(test.data)
+			results, err := tf.Transform(context.Background(), msg)
+			if err != nil {
+				t.Fatalf("transform failed: %v", err)
+			}
+
+			if got := results[0].GetValue("meta.$.encoding").String(); got != test.expected {
+				t.Errorf("expected %s, got %s", test.expected, got)
+			}
+			if string(results[0].Data()) != string(test.data) {
+				t.Errorf("expected data to be unchanged")
+			}
+		})
+	}
+}
@@ -0,0 +1,112 @@
+package transform
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"github.com/jshlbrd/vibestation/config"
+	"github.com/jshlbrd/vibestation/message"
+)
+
+type DiffPreviousConfig struct {
+	ID string `json:"id"`
+}
+
+func (c *DiffPreviousConfig) Decode(in interface{}) error {
+	return config.Decode(in, c)
+}
+
+func newDiffPrevious(_ context.Context, cfg config.Config) (*DiffPrevious, error) {
+	conf := DiffPreviousConfig{}
+	if err := conf.Decode(cfg.Settings); err != nil {
+		return nil, fmt.Errorf("transform diff_previous: %v", err)
+	}
+	if conf.ID == "" {
+		conf.ID = "diff_previous"
+	}
+
+	tf := DiffPrevious{
+		conf:     conf,
+		settings: cfg.Settings,
+	}
+	return &tf, nil
+}
+
+// DiffPrevious compares each data message to the one before it and emits an
+// object that contains only the top-level fields that were added or changed.
+// Fields that were removed are set to null. Values are compared by their JSON
+// encoding. The first message is used as the baseline and is not emitted.
+type DiffPrevious struct {
+	conf     DiffPreviousConfig
+	settings map[string]interface{}
+
+	mu   sync.Mutex
+	prev map[string]interface{}
+}
+
+func (tf *DiffPrevious) Transform(ctx context.Context, msg *message.Message) ([]*message.Message, error) {
+	if msg.IsControl() {
+		return []*message.Message{msg}, nil
+	}
+
+	var curr map[string]interface{}
+	if err := json.Unmarshal(msg.Data(), &curr); err != nil {
+		return nil, fmt.Errorf("transform %s: %v", tf.conf.ID, err)
+	}
+
+	tf.mu.Lock()
+	prev := tf.prev
+	tf.prev = curr
+	tf.mu.Unlock()
+
+	if prev == nil {
+		return nil, nil
+	}
+
+	diff, err := diffObjects(prev, curr)
+	if err != nil {
+		return nil, fmt.Errorf("transform %s: %v", tf.conf.ID, err)
+	}
+
+	b, err := json.Marshal(diff)
+	if err != nil {
+		return nil, fmt.Errorf("transform %s: %v", tf.conf.ID, err)
+	}
+
+	return []*message.Message{message.New().SetData(b)}, nil
+}
+
+func (tf *DiffPrevious) String() string {
+	b, _ := json.Marshal(tf.conf)
+	return string(b)
+}
+
+func diffObjects(prev, curr map[string]interface{}) (map[string]interface{}, error) {
+	diff := make(map[string]interface{})
+	for k, v := range curr {
+		old, ok := prev[k]
+		if ok {
+			a, err := json.Marshal(old)
+			if err != nil {
+				return nil, err
+			}
+			b, err := json.Marshal(v)
+			if err != nil {
+				return nil, err
+			}
+			if string(a) == string(b) {
+				continue
+			}
+		}
+		diff[k] = v
+	}
+	for k := range prev {
+		if _, ok := curr[k]; !ok {
+			diff[k] = nil
+		}
+	}
+
+	return diff, nil
+}
@@ -0,0 +1,51 @@
+package transform
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jshlbrd/vibestation/config"
+	"github.com/jshlbrd/vibestation/message"
+)
+
+func TestDiffPrevious(t *testing.T) {
+	tf, err := newDiffPrevious(context.Background(), config.Config{Type: "diff_previous"})
+	if err != nil {
+		t.Fatalf("failed to create diff_previous transform: %v", err)
+	}
+
+	first := `{"host":"a","cpu":10,"tags":{"env":"prod","zone":"1"},"old":true}`
+	second := `{"tags":{"zone":"1","env":"prod"},"cpu":12,"host":"a","new":"x"}`
+
+	results, err := tf.Transform(context.Background(), message.New().SetData([]byte(first)))
+	if err != nil {
+		t.Fatalf("transform failed: %v", err)
+	}
+	if len(results) != 0 {
+		t.Fatalf("expected first message to be dropped, got %d messages", len(results))
+	}
+
+	results, err = tf.Transform(context.Background(), message.New().SetData([]byte(second)))
+	if err != nil {
+		t.Fatalf("transform failed: %v", err)
+	}
+	if len(results) != 1 {
+		t.Fatalf("expected 1 message, got %d", len(results))
+	}
+
+	expected := `{"cpu":12,"new":"x","old":null}`
+	if got := string(results[0].Data()); got != expected {
+		t.Errorf("expected %s, got %s", expected, got)
+	}
+}
+
+func TestDiffPrevious_Invalid(t *testing.T) {
+	tf, err := newDiffPrevious(context.Background(), config.Config{Type: "diff_previous"})
+	if err != nil {
+		t.Fatalf("failed to create diff_previous transform: %v", err)
+	}
+
+	if _, err := tf.Transform(context.Background(), message.New().SetData([]byte(`[1,2]`))); err == nil {
+		t.Fatal("expected error for non-object data, got nil")
+	}
+}
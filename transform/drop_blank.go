@@ -0,0 +1,73 @@
+package transform
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/jshlbrd/vibestation/config"
+	"github.com/jshlbrd/vibestation/message"
+)
+
+type DropBlankConfig struct {
+	ID string `json:"id"`
+}
+
+func (c *DropBlankConfig) Decode(in interface{}) error {
+	return config.Decode(in, c)
+}
+
+func newDropBlank(_ context.Context, cfg config.Config) (*DropBlank, error) {
+	conf := DropBlankConfig{}
+	if err := conf.Decode(cfg.Settings); err != nil {
+		return nil, fmt.Errorf("transform drop_blank: %v", err)
+	}
+	if conf.ID == "" {
+		conf.ID = "drop_blank"
+	}
+
+	var sourcePath string
+	if v, ok := cfg.Settings["source"]; ok {
+		if s, ok := v.(string); ok {
+			sourcePath = s
+		}
+	}
+
+	tf := DropBlank{
+		conf:       conf,
+		settings:   cfg.Settings,
+		sourcePath: sourcePath,
+	}
+	return &tf, nil
+}
+
+// DropBlank drops data messages that are empty or contain only whitespace.
+// If a source is set, then a missing value is treated as blank.
+type DropBlank struct {
+	conf       DropBlankConfig
+	settings   map[string]interface{}
+	sourcePath string
+}
+
+func (tf *DropBlank) Transform(ctx context.Context, msg *message.Message) ([]*message.Message, error) {
+	if msg.IsControl() {
+		return []*message.Message{msg}, nil
+	}
+
+	inputData := msg.Data()
+	if tf.sourcePath != "" {
+		inputData = msg.GetValue(tf.sourcePath).Bytes()
+	}
+
+	if len(bytes.TrimSpace(inputData)) == 0 {
+		return nil, nil
+	}
+
+	return []*message.Message{msg}, nil
+}
+
+func (tf *DropBlank) String() string {
+	b, _ := json.Marshal(tf.conf)
+	return string(b)
+}
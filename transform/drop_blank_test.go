@@ -0,0 +1,81 @@
+package transform
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/jshlbrd/vibestation/config"
+	"github.com/jshlbrd/vibestation/message"
+)
+
+func TestDropBlank(t *testing.T) {
+	tf, err := newDropBlank(context.Background(), config.Config{Type: "drop_blank"})
+	if err != nil {
+		t.Fatalf("failed to create drop_blank transform: %v", err)
+	}
+
+	msgs := []*message.Message{
+		message.New().SetData([]byte("a")),
+		message.New().SetData([]byte("   ")),
+		message.New().SetData([]byte("")),
+		message.New().SetData([]byte("\t\n")),
+		message.New().SetData([]byte(" b ")),
+	}
+	results, err := Apply(context.Background(), []Transformer{tf}, msgs...)
+	if err != nil {
+		t.Fatalf("apply failed: %v", err)
+	}
+
+	var got []string
+	for _, r := range results {
+		got = append(got, string(r.Data()))
+	}
+	if expected := []string{"a", " b "}; !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected %q, got %q", expected, got)
+	}
+}
+
+func TestDropBlank_Source(t *testing.T) {
+	cfg := config.Config{
+		Type:     "drop_blank",
+		Settings: map[string]interface{}{"source": "$.msg"},
+	}
+	tf, err := newDropBlank(context.Background(), cfg)
+	if err != nil {
+		t.Fatalf("failed to create drop_blank transform: %v", err)
+	}
+
+	tests := []struct {
+		data string
+		keep bool
+	}{
+		{`{"msg":"hello"}`, true},
+		{`{"msg":"  "}`, false},
+		{`{"other":"x"}`, false},
+	}
+	for _, tt := range tests {
+		results, err := tf.Transform(context.Background(), message.New().SetData([]byte(tt.data)))
+		if err != nil {
+			t.Fatalf("transform failed: %v", err)
+		}
+		if got := len(results) == 1; got != tt.keep {
+			t.Errorf("%s: expected kept=%v, got kept=%v", tt.data, tt.keep, got)
+		}
+	}
+}
+
+func TestDropBlank_Control(t *testing.T) {
+	tf, err := newDropBlank(context.Background(), config.Config{Type: "drop_blank"})
+	if err != nil {
+		t.Fatalf("failed to create drop_blank transform: %v", err)
+	}
+
+	results, err := tf.Transform(context.Background(), message.New().AsControl())
+	if err != nil {
+		t.Fatalf("transform failed: %v", err)
+	}
+	if len(results) != 1 || !results[0].IsControl() {
+		t.Error("expected control message to pass")
+	}
+}
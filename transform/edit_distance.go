@@ -0,0 +1,120 @@
+package transform
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/jshlbrd/vibestation/config"
+	"github.com/jshlbrd/vibestation/message"
+)
+
+type EditDistanceConfig struct {
+	// Left is the JSON path to the first string.
+	Left string `json:"left"`
+	// Right is the JSON path to the second string.
+	Right string `json:"right"`
+	ID    string `json:"id"`
+}
+
+func (c *EditDistanceConfig) Decode(in interface{}) error {
+	return config.Decode(in, c)
+}
+
+func (c *EditDistanceConfig) Validate() error {
+	if c.Left == "" {
+		return fmt.Errorf("left: missing required option")
+	}
+	if c.Right == "" {
+		return fmt.Errorf("right: missing required option")
+	}
+	return nil
+}
+
+func newEditDistance(_ context.Context, cfg config.Config) (*EditDistance, error) {
+	conf := EditDistanceConfig{}
+	if err := conf.Decode(cfg.Settings); err != nil {
+		return nil, fmt.Errorf("transform edit_distance: %v", err)
+	}
+	if conf.ID == "" {
+		conf.ID = "edit_distance"
+	}
+	if err := conf.Validate(); err != nil {
+		return nil, fmt.Errorf("transform %s: %v", conf.ID, err)
+	}
+
+	var targetPath string
+	if v, ok := cfg.Settings["target"]; ok {
+		if s, ok := v.(string); ok {
+			targetPath = s
+		}
+	}
+
+	tf := EditDistance{
+		conf:       conf,
+		settings:   cfg.Settings,
+		targetPath: targetPath,
+	}
+	return &tf, nil
+}
+
+// EditDistance computes the Levenshtein distance between the strings at two
+// paths. Missing values are treated as empty strings.
+type EditDistance struct {
+	conf       EditDistanceConfig
+	settings   map[string]interface{}
+	targetPath string
+}
+
+func (tf *EditDistance) Transform(ctx context.Context, msg *message.Message) ([]*message.Message, error) {
+	if msg.IsControl() {
+		return []*message.Message{msg}, nil
+	}
+
+	left := msg.GetValue(tf.conf.Left).String()
+	right := msg.GetValue(tf.conf.Right).String()
+	dist := levenshtein(left, right)
+
+	if tf.targetPath != "" {
+		if err := msg.SetValue(tf.targetPath, dist); err != nil {
+			return nil, fmt.Errorf("transform %s: failed to set target: %v", tf.conf.ID, err)
+		}
+	} else {
+		msg.SetData([]byte(strconv.Itoa(dist)))
+	}
+
+	return []*message.Message{msg}, nil
+}
+
+func (tf *EditDistance) String() string {
+	b, _ := json.Marshal(tf.conf)
+	return string(b)
+}
+
+// levenshtein returns the minimum number of single-rune insertions,
+// deletions, and substitutions needed to turn a into b.
+func levenshtein(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+
+	// Only the previous row of the DP table is needed.
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+
+	return prev[len(rb)]
+}
@@ -0,0 +1,60 @@
+package transform
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/jshlbrd/vibestation/config"
+	"github.com/jshlbrd/vibestation/message"
+)
+
+func TestEditDistance(t *testing.T) {
+	tests := []struct {
+		left     string
+		right    string
+		expected int64
+	}{
+		{"kitten", "sitting", 3},
+		{"flaw", "lawn", 2},
+		{"", "abc", 3},
+		{"same", "same", 0},
+		{"café", "cafe", 1},
+	}
+
+	cfg := config.Config{
+		Type: "edit_distance",
+		Settings: map[string]interface{}{
+			"left":   "$.a",
+			"right":  "$.b",
+			"target": "$.distance",
+		},
+	}
+	tf, err := newEditDistance(context.Background(), cfg)
+	if err != nil {
+		t.Fatalf("failed to create edit_distance transform: %v", err)
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.left+"/"+tt.right, func(t *testing.T) {
+			data, _ := json.Marshal(map[string]string{"a": tt.left, "b": tt.right})
+			results, err := tf.Transform(context.Background(), message.New().SetData(data))
+			if err != nil {
+				t.Fatalf("transform failed: %v", err)
+			}
+			if got := results[0].GetValue("$.distance").Int(); got != tt.expected {
+				t.Errorf("expected %d, got %d", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestEditDistance_MissingOptions(t *testing.T) {
+	cfg := config.Config{
+		Type:     "edit_distance",
+		Settings: map[string]interface{}{"left": "$.a"},
+	}
+	if _, err := newEditDistance(context.Background(), cfg); err == nil {
+		t.Fatal("expected error for missing right, got nil")
+	}
+}
@@ -0,0 +1,106 @@
+package transform
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/jshlbrd/vibestation/config"
+	"github.com/jshlbrd/vibestation/message"
+)
+
+type EncodeBase32Config struct {
+	// NoPadding omits padding characters from the encoded data.
+	NoPadding bool   `json:"no_padding"`
+	ID        string `json:"id"`
+}
+
+func (c *EncodeBase32Config) Decode(in interface{}) error {
+	return config.Decode(in, c)
+}
+
+func newEncodeBase32(_ context.Context, cfg config.Config) (*EncodeBase32Transform, error) {
+	conf := EncodeBase32Config{}
+	if err := conf.Decode(cfg.Settings); err != nil {
+		return nil, fmt.Errorf("transform encode_base32: %v", err)
+	}
+
+	// Use settings to determine ID (named only)
+	id := "encode_base32"
+	if v, ok := cfg.Settings["id"]; ok {
+		if s, ok := v.(string); ok && s != "" {
+			id = s
+		}
+	}
+	conf.ID = id
+
+	// Universal source argument (named only)
+	var sourcePath string
+	if v, ok := cfg.Settings["source"]; ok {
+		if s, ok := v.(string); ok {
+			sourcePath = s
+		}
+	}
+
+	// Target path for assignments
+	var targetPath string
+	if v, ok := cfg.Settings["target"]; ok {
+		if s, ok := v.(string); ok {
+			targetPath = s
+		}
+	}
+
+	tf := EncodeBase32Transform{
+		conf:       conf,
+		settings:   cfg.Settings,
+		sourcePath: sourcePath,
+		targetPath: targetPath,
+	}
+
+	return &tf, nil
+}
+
+type EncodeBase32Transform struct {
+	conf       EncodeBase32Config
+	settings   map[string]interface{}
+	sourcePath string
+	targetPath string
+}
+
+func (tf *EncodeBase32Transform) Transform(ctx context.Context, msg *message.Message) ([]*message.Message, error) {
+	if msg.IsControl() {
+		return []*message.Message{msg}, nil
+	}
+
+	// Determine input data
+	var inputData []byte
+	if tf.sourcePath != "" {
+		val := msg.GetValue(tf.sourcePath)
+		if val.Exists() {
+			inputData = val.Bytes()
+		}
+	}
+	if inputData == nil {
+		inputData = msg.Data()
+	}
+
+	encoded := base32Encoding(tf.conf.NoPadding).EncodeToString(inputData)
+
+	// If we have a target path, store the result there
+	if tf.targetPath != "" {
+		err := msg.SetValue(tf.targetPath, encoded)
+		if err != nil {
+			return nil, fmt.Errorf("transform %s: failed to set target: %v", tf.conf.ID, err)
+		}
+	} else {
+		// Otherwise, set as message data
+		msg.SetData([]byte(encoded))
+	}
+
+	return []*message.Message{msg}, nil
+}
+
+func (tf *EncodeBase32Transform) String() string {
+	b, _ := json.Marshal(tf.conf)
+	return string(b)
+}
@@ -0,0 +1,50 @@
+package transform
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jshlbrd/vibestation/config"
+	"github.com/jshlbrd/vibestation/message"
+)
+
+func TestEncodeBase32Transform_RoundTrip(t *testing.T) {
+	for _, noPadding := range []bool{false, true} {
+		enc, err := newEncodeBase32(context.Background(), config.Config{
+			Type: "encode_base32",
+			Settings: map[string]interface{}{
+				"source":     "$.plain",
+				"target":     "$.encoded",
+				"no_padding": noPadding,
+			},
+		})
+		if err != nil {
+			t.Fatalf("failed to create encode_base32 transform: %v", err)
+		}
+		dec, err := newDecodeBase32(context.Background(), config.Config{
+			Type: "decode_base32",
+			Settings: map[string]interface{}{
+				"source":     "$.encoded",
+				"target":     "$.decoded",
+				"no_padding": noPadding,
+			},
+		})
+		if err != nil {
+			t.Fatalf("failed to create decode_base32 transform: %v", err)
+		}
+
+		msg := message.New().SetData([]byte(`{"plain":"test data"}`))
+		msgs, err := Apply(context.Background(), []Transformer{enc, dec}, msg)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		encoded := msgs[0].GetValue("$.encoded").String()
+		if hasPadding := encoded[len(encoded)-1] == '='; hasPadding == noPadding {
+			t.Errorf("no_padding %v: unexpected padding in %q", noPadding, encoded)
+		}
+		if got := msgs[0].GetValue("$.decoded").String(); got != "test data" {
+			t.Errorf("no_padding %v: expected %q, got %q", noPadding, "test data", got)
+		}
+	}
+}
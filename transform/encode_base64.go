@@ -0,0 +1,115 @@
+package transform
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+
+	"github.com/jshlbrd/vibestation/config"
+	"github.com/jshlbrd/vibestation/message"
+)
+
+type EncodeBase64Config struct {
+	ID string `json:"id"`
+}
+
+func (c *EncodeBase64Config) Decode(in interface{}) error {
+	return config.Decode(in, c)
+}
+
+func newEncodeBase64(_ context.Context, cfg config.Config) (*EncodeBase64Transform, error) {
+	conf := EncodeBase64Config{}
+	if err := conf.Decode(cfg.Settings); err != nil {
+		return nil, fmt.Errorf("transform encode_base64: %v", err)
+	}
+
+	// Use settings to determine ID (named only)
+	id := "encode_base64"
+	if v, ok := cfg.Settings["id"]; ok {
+		if s, ok := v.(string); ok && s != "" {
+			id = s
+		}
+	}
+	conf.ID = id
+
+	// Universal source argument (named only)
+	var sourcePath string
+	if v, ok := cfg.Settings["source"]; ok {
+		if s, ok := v.(string); ok {
+			sourcePath = s
+		}
+	}
+
+	// Target path for assignments
+	var targetPath string
+	if v, ok := cfg.Settings["target"]; ok {
+		if s, ok := v.(string); ok {
+			targetPath = s
+		}
+	}
+
+	tf := EncodeBase64Transform{
+		conf:       conf,
+		settings:   cfg.Settings,
+		sourcePath: sourcePath,
+		targetPath: targetPath,
+	}
+
+	return &tf, nil
+}
+
+// EncodeBase64Transform encodes data from the source (or message data) as
+// standard base64 and writes it to the target (or message data).
+type EncodeBase64Transform struct {
+	conf       EncodeBase64Config
+	settings   map[string]interface{}
+	sourcePath string
+	targetPath string
+}
+
+func (tf *EncodeBase64Transform) Transform(ctx context.Context, msg *message.Message) ([]*message.Message, error) {
+	if msg.IsControl() {
+		return []*message.Message{msg}, nil
+	}
+
+	// Determine input data
+	var inputData []byte
+	if tf.sourcePath != "" {
+		val := msg.GetValue(tf.sourcePath)
+		if val.Exists() {
+			inputData = val.Bytes()
+		}
+	}
+	if inputData == nil {
+		inputData = msg.Data()
+	}
+
+	encoded := encodeBase64(inputData)
+
+	// If we have a target path, store the result there
+	if tf.targetPath != "" {
+		err := msg.SetValue(tf.targetPath, string(encoded))
+		if err != nil {
+			return nil, fmt.Errorf("transform %s: failed to set target: %v", tf.conf.ID, err)
+		}
+	} else {
+		// Otherwise, set as message data
+		msg.SetData(encoded)
+	}
+
+	return []*message.Message{msg}, nil
+}
+
+func (tf *EncodeBase64Transform) String() string {
+	b, _ := json.Marshal(tf.conf)
+	return string(b)
+}
+
+// encodeBase64 encodes data as standard base64.
+func encodeBase64(data []byte) []byte {
+	encoded := make([]byte, base64.StdEncoding.EncodedLen(len(data)))
+	base64.StdEncoding.Encode(encoded, data)
+
+	return encoded
+}
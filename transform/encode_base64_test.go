@@ -0,0 +1,74 @@
+package transform
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jshlbrd/vibestation/config"
+	"github.com/jshlbrd/vibestation/message"
+)
+
+func TestEncodeBase64Transform_Basic(t *testing.T) {
+	tf, err := newEncodeBase64(context.Background(), config.Config{Type: "encode_base64"})
+	if err != nil {
+		t.Fatalf("failed to create encode_base64 transform: %v", err)
+	}
+
+	msgs, err := tf.Transform(context.Background(), message.New().SetData([]byte("test")))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := string(msgs[0].Data()); got != "dGVzdA==" {
+		t.Errorf("expected %q, got %q", "dGVzdA==", got)
+	}
+}
+
+func TestEncodeBase64Transform_RoundTrip(t *testing.T) {
+	enc, err := newEncodeBase64(context.Background(), config.Config{
+		Type: "encode_base64",
+		Settings: map[string]interface{}{
+			"source": "$.plain",
+			"target": "$.encoded",
+		},
+	})
+	if err != nil {
+		t.Fatalf("failed to create encode_base64 transform: %v", err)
+	}
+	dec, err := newDecodeBase64(context.Background(), config.Config{
+		Type: "decode_base64",
+		Settings: map[string]interface{}{
+			"source": "$.encoded",
+			"target": "$.decoded",
+		},
+	})
+	if err != nil {
+		t.Fatalf("failed to create decode_base64 transform: %v", err)
+	}
+
+	msg := message.New().SetData([]byte(`{"plain":"test data"}`))
+	msgs, err := Apply(context.Background(), []Transformer{enc, dec}, msg)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := msgs[0].GetValue("$.encoded").String(); got != "dGVzdCBkYXRh" {
+		t.Errorf("expected encoded %q, got %q", "dGVzdCBkYXRh", got)
+	}
+	if got := msgs[0].GetValue("$.decoded").String(); got != "test data" {
+		t.Errorf("expected decoded %q, got %q", "test data", got)
+	}
+}
+
+func TestEncodeBase64Transform_ControlMessage(t *testing.T) {
+	tf, err := newEncodeBase64(context.Background(), config.Config{Type: "encode_base64"})
+	if err != nil {
+		t.Fatalf("failed to create encode_base64 transform: %v", err)
+	}
+
+	msgs, err := tf.Transform(context.Background(), message.New().AsControl())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(msgs) != 1 || !msgs[0].IsControl() {
+		t.Error("expected control message to pass through")
+	}
+}
@@ -0,0 +1,127 @@
+package transform
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/jshlbrd/vibestation/config"
+	"github.com/jshlbrd/vibestation/message"
+)
+
+type EnrichJSONConfig struct {
+	// File is the path to a JSON object that maps lookup keys to the objects
+	// that are merged into messages.
+	File string `json:"file"`
+	// Default is merged into messages whose key is not in the file. If not
+	// set, then those messages are unchanged.
+	Default map[string]interface{} `json:"default"`
+	ID      string                 `json:"id"`
+}
+
+func (c *EnrichJSONConfig) Decode(in interface{}) error {
+	return config.Decode(in, c)
+}
+
+func (c *EnrichJSONConfig) Validate() error {
+	if c.File == "" {
+		return fmt.Errorf("file: missing required option")
+	}
+	return nil
+}
+
+func newEnrichJSON(_ context.Context, cfg config.Config) (*EnrichJSON, error) {
+	conf := EnrichJSONConfig{}
+	if err := conf.Decode(cfg.Settings); err != nil {
+		return nil, fmt.Errorf("transform enrich_json: %v", err)
+	}
+	if conf.ID == "" {
+		conf.ID = "enrich_json"
+	}
+	if err := conf.Validate(); err != nil {
+		return nil, fmt.Errorf("transform %s: %v", conf.ID, err)
+	}
+
+	var sourcePath string
+	if v, ok := cfg.Settings["source"]; ok {
+		if s, ok := v.(string); ok {
+			sourcePath = s
+		}
+	}
+	if sourcePath == "" {
+		return nil, fmt.Errorf("transform %s: source: missing required option", conf.ID)
+	}
+
+	targetPath := "$"
+	if v, ok := cfg.Settings["target"]; ok {
+		if s, ok := v.(string); ok && s != "" {
+			targetPath = s
+		}
+	}
+
+	b, err := os.ReadFile(conf.File)
+	if err != nil {
+		return nil, fmt.Errorf("transform %s: %v", conf.ID, err)
+	}
+
+	var lookup map[string]map[string]interface{}
+	if err := json.Unmarshal(b, &lookup); err != nil {
+		return nil, fmt.Errorf("transform %s: file: %v", conf.ID, err)
+	}
+
+	tf := EnrichJSON{
+		conf:       conf,
+		settings:   cfg.Settings,
+		sourcePath: sourcePath,
+		targetPath: targetPath,
+		lookup:     lookup,
+	}
+	return &tf, nil
+}
+
+// EnrichJSON looks up the value at the source path in a JSON file that is
+// loaded once at construction and merges the matching object into the
+// target. Keys from the lookup object overwrite existing keys in the target.
+type EnrichJSON struct {
+	conf       EnrichJSONConfig
+	settings   map[string]interface{}
+	sourcePath string
+	targetPath string
+	lookup     map[string]map[string]interface{}
+}
+
+func (tf *EnrichJSON) Transform(ctx context.Context, msg *message.Message) ([]*message.Message, error) {
+	if msg.IsControl() {
+		return []*message.Message{msg}, nil
+	}
+
+	enrichment, ok := tf.lookup[msg.GetValue(tf.sourcePath).String()]
+	if !ok {
+		enrichment = tf.conf.Default
+	}
+	if enrichment == nil {
+		return []*message.Message{msg}, nil
+	}
+
+	merged := make(map[string]interface{})
+	if existing, ok := msg.GetValue(tf.targetPath).Value().(map[string]interface{}); ok {
+		for k, v := range existing {
+			merged[k] = v
+		}
+	}
+	for k, v := range enrichment {
+		merged[k] = v
+	}
+
+	if err := msg.SetValue(tf.targetPath, merged); err != nil {
+		return nil, fmt.Errorf("transform %s: failed to set target: %v", tf.conf.ID, err)
+	}
+
+	return []*message.Message{msg}, nil
+}
+
+func (tf *EnrichJSON) String() string {
+	b, _ := json.Marshal(tf.conf)
+	return string(b)
+}
@@ -0,0 +1,86 @@
+package transform
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jshlbrd/vibestation/config"
+	"github.com/jshlbrd/vibestation/message"
+)
+
+func TestEnrichJSON(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "lookup.json")
+	lookup := `{"10.0.0.1":{"host":"web-1","env":"prod"},"10.0.0.2":{"host":"db-1","env":"prod"}}`
+	if err := os.WriteFile(file, []byte(lookup), 0o600); err != nil {
+		t.Fatalf("failed to write lookup file: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		settings map[string]interface{}
+		data     string
+		expected string
+	}{
+		{
+			name:     "merge into root",
+			settings: map[string]interface{}{"file": file, "source": "$.ip"},
+			data:     `{"ip":"10.0.0.1","env":"dev"}`,
+			expected: `{"env":"prod","host":"web-1","ip":"10.0.0.1"}`,
+		},
+		{
+			name:     "merge into target",
+			settings: map[string]interface{}{"file": file, "source": "$.ip", "target": "$.asset"},
+			data:     `{"ip":"10.0.0.2"}`,
+			expected: `{"asset":{"env":"prod","host":"db-1"},"ip":"10.0.0.2"}`,
+		},
+		{
+			name: "missing key with default",
+			settings: map[string]interface{}{
+				"file":    file,
+				"source":  "$.ip",
+				"target":  "$.asset",
+				"default": map[string]interface{}{"host": "unknown"},
+			},
+			data:     `{"ip":"10.0.0.9"}`,
+			expected: `{"asset":{"host":"unknown"},"ip":"10.0.0.9"}`,
+		},
+		{
+			name:     "missing key without default",
+			settings: map[string]interface{}{"file": file, "source": "$.ip"},
+			data:     `{"ip":"10.0.0.9"}`,
+			expected: `{"ip":"10.0.0.9"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tf, err := newEnrichJSON(context.Background(), config.Config{Type: "enrich_json", Settings: tt.settings})
+			if err != nil {
+				t.Fatalf("failed to create enrich_json transform: %v", err)
+			}
+
+			results, err := tf.Transform(context.Background(), message.New().SetData([]byte(tt.data)))
+			if err != nil {
+				t.Fatalf("transform failed: %v", err)
+			}
+			if got := results[0].GetValue("$").String(); got != tt.expected {
+				t.Errorf("expected %s, got %s", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestEnrichJSON_MissingFile(t *testing.T) {
+	cfg := config.Config{
+		Type: "enrich_json",
+		Settings: map[string]interface{}{
+			"file":   filepath.Join(t.TempDir(), "missing.json"),
+			"source": "$.ip",
+		},
+	}
+	if _, err := newEnrichJSON(context.Background(), cfg); err == nil {
+		t.Fatal("expected error for missing file, got nil")
+	}
+}
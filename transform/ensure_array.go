@@ -0,0 +1,87 @@
+package transform
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/jshlbrd/vibestation/config"
+	"github.com/jshlbrd/vibestation/message"
+)
+
+type EnsureArrayConfig struct {
+	ID string `json:"id"`
+}
+
+func (c *EnsureArrayConfig) Decode(in interface{}) error {
+	return config.Decode(in, c)
+}
+
+func newEnsureArray(_ context.Context, cfg config.Config) (*EnsureArray, error) {
+	conf := EnsureArrayConfig{}
+	if err := conf.Decode(cfg.Settings); err != nil {
+		return nil, fmt.Errorf("transform ensure_array: %v", err)
+	}
+	if conf.ID == "" {
+		conf.ID = "ensure_array"
+	}
+
+	sourcePath := "$"
+	if v, ok := cfg.Settings["source"]; ok {
+		if s, ok := v.(string); ok && s != "" {
+			sourcePath = s
+		}
+	}
+
+	// If no target is set, then the source is updated in place.
+	targetPath := sourcePath
+	if v, ok := cfg.Settings["target"]; ok {
+		if s, ok := v.(string); ok && s != "" {
+			targetPath = s
+		}
+	}
+
+	tf := EnsureArray{
+		conf:       conf,
+		settings:   cfg.Settings,
+		sourcePath: sourcePath,
+		targetPath: targetPath,
+	}
+	return &tf, nil
+}
+
+// EnsureArray wraps a value that is not an array in a single-element array.
+// Arrays are unchanged and missing values are skipped.
+type EnsureArray struct {
+	conf       EnsureArrayConfig
+	settings   map[string]interface{}
+	sourcePath string
+	targetPath string
+}
+
+func (tf *EnsureArray) Transform(ctx context.Context, msg *message.Message) ([]*message.Message, error) {
+	if msg.IsControl() {
+		return []*message.Message{msg}, nil
+	}
+
+	val := msg.GetValue(tf.sourcePath)
+	if !val.Exists() {
+		return []*message.Message{msg}, nil
+	}
+
+	result := val.Value()
+	if !val.IsArray() {
+		result = []interface{}{result}
+	}
+
+	if err := msg.SetValue(tf.targetPath, result); err != nil {
+		return nil, fmt.Errorf("transform %s: failed to set target: %v", tf.conf.ID, err)
+	}
+
+	return []*message.Message{msg}, nil
+}
+
+func (tf *EnsureArray) String() string {
+	b, _ := json.Marshal(tf.conf)
+	return string(b)
+}
@@ -0,0 +1,66 @@
+package transform
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jshlbrd/vibestation/config"
+	"github.com/jshlbrd/vibestation/message"
+)
+
+func TestEnsureArray(t *testing.T) {
+	tests := []struct {
+		name     string
+		settings map[string]interface{}
+		data     string
+		expected string
+	}{
+		{
+			name:     "scalar in place",
+			settings: map[string]interface{}{"source": "$.tags"},
+			data:     `{"tags":"a"}`,
+			expected: `{"tags":["a"]}`,
+		},
+		{
+			name:     "object in place",
+			settings: map[string]interface{}{"source": "$.tags"},
+			data:     `{"tags":{"k":"v"}}`,
+			expected: `{"tags":[{"k":"v"}]}`,
+		},
+		{
+			name:     "array unchanged",
+			settings: map[string]interface{}{"source": "$.tags"},
+			data:     `{"tags":["a","b"]}`,
+			expected: `{"tags":["a","b"]}`,
+		},
+		{
+			name:     "scalar to target",
+			settings: map[string]interface{}{"source": "$.tags", "target": "$.list"},
+			data:     `{"tags":1}`,
+			expected: `{"list":[1],"tags":1}`,
+		},
+		{
+			name:     "missing source",
+			settings: map[string]interface{}{"source": "$.tags"},
+			data:     `{"other":1}`,
+			expected: `{"other":1}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tf, err := newEnsureArray(context.Background(), config.Config{Type: "ensure_array", Settings: tt.settings})
+			if err != nil {
+				t.Fatalf("failed to create ensure_array transform: %v", err)
+			}
+
+			results, err := tf.Transform(context.Background(), message.New().SetData([]byte(tt.data)))
+			if err != nil {
+				t.Fatalf("transform failed: %v", err)
+			}
+			if got := results[0].GetValue("$").String(); got != tt.expected {
+				t.Errorf("expected %s, got %s", tt.expected, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,122 @@
+package transform
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/jshlbrd/vibestation/config"
+	"github.com/jshlbrd/vibestation/message"
+)
+
+// envelopeSourceKey is the metadata path read into the envelope source field.
+const envelopeSourceKey = "meta.$.source"
+
+type EnvelopeConfig struct {
+	// TimestampField is the envelope field for the current time. Defaults to
+	// "timestamp".
+	TimestampField string `json:"timestamp_field"`
+	// SourceField is the envelope field for the message source. Defaults to
+	// "source".
+	SourceField string `json:"source_field"`
+	// PayloadField is the envelope field for the message data. Defaults to
+	// "payload".
+	PayloadField string `json:"payload_field"`
+	ID           string `json:"id"`
+}
+
+func (c *EnvelopeConfig) Decode(in interface{}) error {
+	return config.Decode(in, c)
+}
+
+func (c *EnvelopeConfig) Validate() error {
+	if c.TimestampField == c.SourceField || c.TimestampField == c.PayloadField || c.SourceField == c.PayloadField {
+		return fmt.Errorf("field names must be unique")
+	}
+	return nil
+}
+
+// clock provides the current time and can be replaced for deterministic tests.
+type clock interface {
+	Now() time.Time
+}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+func newEnvelope(_ context.Context, cfg config.Config) (*Envelope, error) {
+	conf := EnvelopeConfig{}
+	if err := conf.Decode(cfg.Settings); err != nil {
+		return nil, fmt.Errorf("transform envelope: %v", err)
+	}
+	if conf.ID == "" {
+		conf.ID = "envelope"
+	}
+	if conf.TimestampField == "" {
+		conf.TimestampField = "timestamp"
+	}
+	if conf.SourceField == "" {
+		conf.SourceField = "source"
+	}
+	if conf.PayloadField == "" {
+		conf.PayloadField = "payload"
+	}
+	if err := conf.Validate(); err != nil {
+		return nil, fmt.Errorf("transform %s: %v", conf.ID, err)
+	}
+
+	tf := Envelope{
+		conf:     conf,
+		settings: cfg.Settings,
+		clock:    systemClock{},
+	}
+	return &tf, nil
+}
+
+// Envelope wraps message data in an object that contains the current time
+// (RFC 3339), the message source from metadata, and the original data as the
+// payload. Data that is not valid JSON is stored as a string.
+type Envelope struct {
+	conf     EnvelopeConfig
+	settings map[string]interface{}
+	clock    clock
+}
+
+func (tf *Envelope) Transform(ctx context.Context, msg *message.Message) ([]*message.Message, error) {
+	if msg.IsControl() {
+		return []*message.Message{msg}, nil
+	}
+
+	var payload interface{} = json.RawMessage(msg.Data())
+	if !json.Valid(msg.Data()) {
+		payload = string(msg.Data())
+	}
+
+	var source interface{}
+	if v := msg.GetValue(envelopeSourceKey); v.Exists() {
+		source = v.Value()
+	}
+
+	env := map[string]interface{}{
+		tf.conf.TimestampField: tf.clock.Now().UTC().Format(time.RFC3339),
+		tf.conf.SourceField:    source,
+		tf.conf.PayloadField:   payload,
+	}
+
+	b, err := json.Marshal(env)
+	if err != nil {
+		return nil, fmt.Errorf("transform %s: %v", tf.conf.ID, err)
+	}
+
+	msg.SetData(b)
+	return []*message.Message{msg}, nil
+}
+
+func (tf *Envelope) String() string {
+	b, _ := json.Marshal(tf.conf)
+	return string(b)
+}
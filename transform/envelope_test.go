@@ -0,0 +1,80 @@
+package transform
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jshlbrd/vibestation/config"
+	"github.com/jshlbrd/vibestation/message"
+)
+
+type fixedClock struct {
+	t time.Time
+}
+
+func (c fixedClock) Now() time.Time {
+	return c.t
+}
+
+func TestEnvelope(t *testing.T) {
+	tf, err := newEnvelope(context.Background(), config.Config{Type: "envelope"})
+	if err != nil {
+		t.Fatalf("failed to create envelope transform: %v", err)
+	}
+	tf.clock = fixedClock{time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)}
+
+	msg := message.New().SetData([]byte(`{"a":1}`))
+	if err := msg.SetValue("meta.$.source", "sensor-1"); err != nil {
+		t.Fatalf("failed to set metadata: %v", err)
+	}
+
+	results, err := tf.Transform(context.Background(), msg)
+	if err != nil {
+		t.Fatalf("transform failed: %v", err)
+	}
+	if len(results) != 1 {
+		t.Fatalf("expected 1 message, got %d", len(results))
+	}
+
+	expected := `{"payload":{"a":1},"source":"sensor-1","timestamp":"2024-01-02T03:04:05Z"}`
+	if got := string(results[0].Data()); got != expected {
+		t.Errorf("expected %s, got %s", expected, got)
+	}
+}
+
+func TestEnvelope_CustomFields(t *testing.T) {
+	cfg := config.Config{
+		Type: "envelope",
+		Settings: map[string]interface{}{
+			"timestamp_field": "ts",
+			"source_field":    "src",
+			"payload_field":   "body",
+		},
+	}
+	tf, err := newEnvelope(context.Background(), cfg)
+	if err != nil {
+		t.Fatalf("failed to create envelope transform: %v", err)
+	}
+	tf.clock = fixedClock{time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)}
+
+	results, err := tf.Transform(context.Background(), message.New().SetData([]byte(`plain text`)))
+	if err != nil {
+		t.Fatalf("transform failed: %v", err)
+	}
+
+	expected := `{"body":"plain text","src":null,"ts":"2024-01-02T03:04:05Z"}`
+	if got := string(results[0].Data()); got != expected {
+		t.Errorf("expected %s, got %s", expected, got)
+	}
+}
+
+func TestEnvelope_DuplicateFields(t *testing.T) {
+	cfg := config.Config{
+		Type:     "envelope",
+		Settings: map[string]interface{}{"source_field": "payload"},
+	}
+	if _, err := newEnvelope(context.Background(), cfg); err == nil {
+		t.Fatal("expected error for duplicate field names, got nil")
+	}
+}
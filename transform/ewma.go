@@ -0,0 +1,123 @@
+package transform
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"sync"
+
+	"github.com/jshlbrd/vibestation/config"
+	"github.com/jshlbrd/vibestation/message"
+)
+
+type EWMAConfig struct {
+	// Alpha is the smoothing factor, greater than 0.0 and at most 1.0.
+	// Higher values give more weight to recent values.
+	Alpha float64 `json:"alpha"`
+	ID    string  `json:"id"`
+}
+
+func (c *EWMAConfig) Decode(in interface{}) error {
+	return config.Decode(in, c)
+}
+
+func (c *EWMAConfig) Validate() error {
+	if c.Alpha <= 0 || c.Alpha > 1 {
+		return fmt.Errorf("alpha: must be greater than 0.0 and at most 1.0")
+	}
+	return nil
+}
+
+func newEWMA(_ context.Context, cfg config.Config) (*EWMA, error) {
+	conf := EWMAConfig{}
+	if err := conf.Decode(cfg.Settings); err != nil {
+		return nil, fmt.Errorf("transform ewma: %v", err)
+	}
+	if conf.ID == "" {
+		conf.ID = "ewma"
+	}
+	if err := conf.Validate(); err != nil {
+		return nil, fmt.Errorf("transform %s: %v", conf.ID, err)
+	}
+
+	sourcePath := "$"
+	if v, ok := cfg.Settings["source"]; ok {
+		if s, ok := v.(string); ok && s != "" {
+			sourcePath = s
+		}
+	}
+
+	var targetPath string
+	if v, ok := cfg.Settings["target"]; ok {
+		if s, ok := v.(string); ok {
+			targetPath = s
+		}
+	}
+
+	tf := EWMA{
+		conf:       conf,
+		settings:   cfg.Settings,
+		sourcePath: sourcePath,
+		targetPath: targetPath,
+	}
+	return &tf, nil
+}
+
+// EWMA computes the exponentially weighted moving average of a numeric value
+// across data messages. The first value seeds the average. Control messages
+// reset the average. A value that is not numeric is an error and does not
+// change the average.
+type EWMA struct {
+	conf       EWMAConfig
+	settings   map[string]interface{}
+	sourcePath string
+	targetPath string
+
+	mu      sync.Mutex
+	avg     float64
+	started bool
+}
+
+func (tf *EWMA) Transform(ctx context.Context, msg *message.Message) ([]*message.Message, error) {
+	tf.mu.Lock()
+	defer tf.mu.Unlock()
+
+	if msg.IsControl() {
+		tf.avg = 0
+		tf.started = false
+		return []*message.Message{msg}, nil
+	}
+
+	val := msg.GetValue(tf.sourcePath)
+	if !val.Exists() {
+		return nil, fmt.Errorf("transform %s: source %s not found", tf.conf.ID, tf.sourcePath)
+	}
+
+	f, ok := numericValue(val)
+	if !ok {
+		return nil, fmt.Errorf("transform %s: source %s is not a number", tf.conf.ID, tf.sourcePath)
+	}
+
+	if tf.started {
+		tf.avg = tf.conf.Alpha*f + (1-tf.conf.Alpha)*tf.avg
+	} else {
+		tf.avg = f
+		tf.started = true
+	}
+
+	if tf.targetPath != "" {
+		if err := msg.SetValue(tf.targetPath, tf.avg); err != nil {
+			return nil, fmt.Errorf("transform %s: failed to set target: %v", tf.conf.ID, err)
+		}
+	} else {
+		msg.SetData([]byte(strconv.FormatFloat(tf.avg, 'f', -1, 64)))
+	}
+
+	return []*message.Message{msg}, nil
+}
+
+func (tf *EWMA) String() string {
+	b, _ := json.Marshal(tf.conf)
+	return string(b)
+}
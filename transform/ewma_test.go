@@ -0,0 +1,94 @@
+package transform
+
+import (
+	"context"
+	"math"
+	"strconv"
+	"testing"
+
+	"github.com/jshlbrd/vibestation/config"
+	"github.com/jshlbrd/vibestation/message"
+)
+
+func TestEWMA(t *testing.T) {
+	cfg := config.Config{
+		Type: "ewma",
+		Settings: map[string]interface{}{
+			"alpha":  0.5,
+			"source": "$.v",
+			"target": "$.avg",
+		},
+	}
+	tf, err := newEWMA(context.Background(), cfg)
+	if err != nil {
+		t.Fatalf("failed to create ewma transform: %v", err)
+	}
+
+	inputs := []float64{10, 20, 20, 0}
+	expected := []float64{10, 15, 17.5, 8.75}
+	for i, v := range inputs {
+		data := []byte(`{"v":` + strconv.FormatFloat(v, 'f', -1, 64) + `}`)
+		results, err := tf.Transform(context.Background(), message.New().SetData(data))
+		if err != nil {
+			t.Fatalf("transform failed: %v", err)
+		}
+		if got := results[0].GetValue("$.avg").Float(); math.Abs(got-expected[i]) > 1e-9 {
+			t.Errorf("message %d: expected %v, got %v", i, expected[i], got)
+		}
+	}
+
+	// A control message resets the average.
+	if _, err := tf.Transform(context.Background(), message.New().AsControl()); err != nil {
+		t.Fatalf("transform failed: %v", err)
+	}
+	results, err := tf.Transform(context.Background(), message.New().SetData([]byte(`{"v":100}`)))
+	if err != nil {
+		t.Fatalf("transform failed: %v", err)
+	}
+	if got := results[0].GetValue("$.avg").Float(); got != 100 {
+		t.Errorf("expected reset average 100, got %v", got)
+	}
+}
+
+func TestEWMA_NonNumeric(t *testing.T) {
+	cfg := config.Config{
+		Type: "ewma",
+		Settings: map[string]interface{}{
+			"alpha":  0.5,
+			"source": "$.v",
+			"target": "$.avg",
+		},
+	}
+	tf, err := newEWMA(context.Background(), cfg)
+	if err != nil {
+		t.Fatalf("failed to create ewma transform: %v", err)
+	}
+
+	if _, err := tf.Transform(context.Background(), message.New().SetData([]byte(`{"v":10}`))); err != nil {
+		t.Fatalf("transform failed: %v", err)
+	}
+	if _, err := tf.Transform(context.Background(), message.New().SetData([]byte(`{"v":"bad"}`))); err == nil {
+		t.Fatal("expected error for non-numeric value, got nil")
+	}
+
+	// The rejected value does not change the average.
+	results, err := tf.Transform(context.Background(), message.New().SetData([]byte(`{"v":20}`)))
+	if err != nil {
+		t.Fatalf("transform failed: %v", err)
+	}
+	if got := results[0].GetValue("$.avg").Float(); got != 15 {
+		t.Errorf("expected 15, got %v", got)
+	}
+}
+
+func TestEWMA_InvalidAlpha(t *testing.T) {
+	for _, alpha := range []float64{0, -0.1, 1.5} {
+		cfg := config.Config{
+			Type:     "ewma",
+			Settings: map[string]interface{}{"alpha": alpha},
+		}
+		if _, err := newEWMA(context.Background(), cfg); err == nil {
+			t.Errorf("expected error for alpha %v, got nil", alpha)
+		}
+	}
+}
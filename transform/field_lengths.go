@@ -0,0 +1,114 @@
+package transform
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/jshlbrd/vibestation/config"
+	"github.com/jshlbrd/vibestation/message"
+)
+
+// fieldLengthsKey is the metadata path where field_lengths records the
+// length of each leaf value.
+const fieldLengthsKey = "meta.$._lengths"
+
+type FieldLengthsConfig struct {
+	ID string `json:"id"`
+}
+
+func (c *FieldLengthsConfig) Decode(in interface{}) error {
+	return config.Decode(in, c)
+}
+
+func newFieldLengths(_ context.Context, cfg config.Config) (*FieldLengths, error) {
+	conf := FieldLengthsConfig{}
+	if err := conf.Decode(cfg.Settings); err != nil {
+		return nil, fmt.Errorf("transform field_lengths: %v", err)
+	}
+	if conf.ID == "" {
+		conf.ID = "field_lengths"
+	}
+
+	tf := FieldLengths{
+		conf:     conf,
+		settings: cfg.Settings,
+	}
+	return &tf, nil
+}
+
+// FieldLengths records the length of every leaf value in the message data
+// as an object in metadata, keyed by the leaf's JSON path (e.g. $.a[0].b).
+// Strings are measured in characters and all other leaves are measured by
+// the length of their JSON encoding.
+type FieldLengths struct {
+	conf     FieldLengthsConfig
+	settings map[string]interface{}
+}
+
+func (tf *FieldLengths) Transform(ctx context.Context, msg *message.Message) ([]*message.Message, error) {
+	if msg.IsControl() {
+		return []*message.Message{msg}, nil
+	}
+
+	v, err := decodeJSONNumber(msg.Data())
+	if err != nil {
+		return nil, fmt.Errorf("transform %s: %v", tf.conf.ID, err)
+	}
+
+	lengths := make(map[string]interface{})
+	if err := fieldLengths("$", v, lengths); err != nil {
+		return nil, fmt.Errorf("transform %s: %v", tf.conf.ID, err)
+	}
+
+	if err := msg.SetValue(fieldLengthsKey, lengths); err != nil {
+		return nil, fmt.Errorf("transform %s: %v", tf.conf.ID, err)
+	}
+
+	return []*message.Message{msg}, nil
+}
+
+func (tf *FieldLengths) String() string {
+	b, _ := json.Marshal(tf.conf)
+	return string(b)
+}
+
+func fieldLengths(path string, v interface{}, lengths map[string]interface{}) error {
+	switch val := v.(type) {
+	case map[string]interface{}:
+		for k, elem := range val {
+			if err := fieldLengths(fieldLengthsChild(path, k), elem, lengths); err != nil {
+				return err
+			}
+		}
+	case []interface{}:
+		for i, elem := range val {
+			if err := fieldLengths(path+"["+strconv.Itoa(i)+"]", elem, lengths); err != nil {
+				return err
+			}
+		}
+	case string:
+		lengths[path] = utf8.RuneCountInString(val)
+	default:
+		b, err := json.Marshal(val)
+		if err != nil {
+			return err
+		}
+		lengths[path] = len(b)
+	}
+
+	return nil
+}
+
+// fieldLengthsChild returns the path of key k within path. Keys that contain
+// path syntax are quoted in brackets (e.g. $["b.c"]) so that they cannot be
+// confused with nested keys.
+func fieldLengthsChild(path, k string) string {
+	if strings.ContainsAny(k, ".[]") {
+		return path + "[" + strconv.Quote(k) + "]"
+	}
+	return path + "." + k
+}
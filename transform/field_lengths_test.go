@@ -0,0 +1,82 @@
+package transform
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jshlbrd/vibestation/config"
+	"github.com/jshlbrd/vibestation/message"
+)
+
+func TestFieldLengths(t *testing.T) {
+	tf, err := newFieldLengths(context.Background(), config.Config{Type: "field_lengths"})
+	if err != nil {
+		t.Fatalf("failed to create field_lengths transform: %v", err)
+	}
+
+	data := `{"name":"héllo","user":{"id":12345,"tags":["a","bcd"],"active":true},"note":null}`
+	results, err := tf.Transform(context.Background(), message.New().SetData([]byte(data)))
+	if err != nil {
+		t.Fatalf("transform failed: %v", err)
+	}
+	if got := string(results[0].Data()); got != data {
+		t.Errorf("expected data to be unchanged, got %s", got)
+	}
+
+	expected := `{"$.name":5,"$.note":4,"$.user.active":4,"$.user.id":5,"$.user.tags[0]":1,"$.user.tags[1]":3}`
+	if got := results[0].GetValue(fieldLengthsKey).String(); got != expected {
+		t.Errorf("expected %s, got %s", expected, got)
+	}
+}
+
+func TestFieldLengths_Paths(t *testing.T) {
+	tf, err := newFieldLengths(context.Background(), config.Config{Type: "field_lengths"})
+	if err != nil {
+		t.Fatalf("failed to create field_lengths transform: %v", err)
+	}
+
+	results, err := tf.Transform(context.Background(), message.New().SetData([]byte(`{"a":[{"b":"xy"}]}`)))
+	if err != nil {
+		t.Fatalf("transform failed: %v", err)
+	}
+
+	// Keys use the same notation as Value.Path.
+	leaf := results[0].GetValue("$.a").Array()[0].Map()["b"]
+	lengths := results[0].GetValue(fieldLengthsKey).Map()
+	if _, ok := lengths[leaf.Path()]; !ok || len(lengths) != 1 {
+		t.Errorf("expected key %q, got %s", leaf.Path(), results[0].GetValue(fieldLengthsKey).String())
+	}
+}
+
+func TestFieldLengths_QuotedKeys(t *testing.T) {
+	tf, err := newFieldLengths(context.Background(), config.Config{Type: "field_lengths"})
+	if err != nil {
+		t.Fatalf("failed to create field_lengths transform: %v", err)
+	}
+
+	results, err := tf.Transform(context.Background(), message.New().SetData([]byte(`{"b.c":"z","b":{"c":"yy"},"d[0]":"www"}`)))
+	if err != nil {
+		t.Fatalf("transform failed: %v", err)
+	}
+
+	expected := `{"$.b.c":2,"$[\"b.c\"]":1,"$[\"d[0]\"]":3}`
+	if got := results[0].GetValue(fieldLengthsKey).String(); got != expected {
+		t.Errorf("expected %s, got %s", expected, got)
+	}
+
+	leaf := results[0].GetValue("$").Map()["b.c"]
+	if got := leaf.Path(); got != `$["b.c"]` {
+		t.Errorf("expected path %q, got %q", `$["b.c"]`, got)
+	}
+}
+
+func TestFieldLengths_Invalid(t *testing.T) {
+	tf, err := newFieldLengths(context.Background(), config.Config{Type: "field_lengths"})
+	if err != nil {
+		t.Fatalf("failed to create field_lengths transform: %v", err)
+	}
+
+	if _, err := tf.Transform(context.Background(), message.New().SetData([]byte(`{"a":`))); err == nil {
+		t.Fatal("expected error for invalid JSON, got nil")
+	}
+}
@@ -0,0 +1,160 @@
+package transform
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+
+	"github.com/jshlbrd/vibestation/config"
+	"github.com/jshlbrd/vibestation/message"
+)
+
+type FieldMathConfig struct {
+	// Left is the JSON path to the left operand.
+	Left string `json:"left"`
+	// Right is the JSON path to the right operand.
+	Right string `json:"right"`
+	// Operation is one of add, subtract, multiply, or divide.
+	Operation string `json:"operation"`
+	ID        string `json:"id"`
+}
+
+func (c *FieldMathConfig) Decode(in interface{}) error {
+	return config.Decode(in, c)
+}
+
+func (c *FieldMathConfig) Validate() error {
+	if c.Left == "" {
+		return fmt.Errorf("left: missing required option")
+	}
+	if c.Right == "" {
+		return fmt.Errorf("right: missing required option")
+	}
+	switch c.Operation {
+	case "add", "subtract", "multiply", "divide":
+	default:
+		return fmt.Errorf("operation: unsupported operation %q", c.Operation)
+	}
+	return nil
+}
+
+func newFieldMath(_ context.Context, cfg config.Config) (*FieldMath, error) {
+	conf := FieldMathConfig{}
+	if err := conf.Decode(cfg.Settings); err != nil {
+		return nil, fmt.Errorf("transform field_math: %v", err)
+	}
+	if conf.ID == "" {
+		conf.ID = "field_math"
+	}
+	if err := conf.Validate(); err != nil {
+		return nil, fmt.Errorf("transform %s: %v", conf.ID, err)
+	}
+
+	var targetPath string
+	if v, ok := cfg.Settings["target"]; ok {
+		if s, ok := v.(string); ok {
+			targetPath = s
+		}
+	}
+
+	tf := FieldMath{
+		conf:       conf,
+		settings:   cfg.Settings,
+		targetPath: targetPath,
+	}
+	return &tf, nil
+}
+
+// FieldMath applies an arithmetic operation to the values at two paths,
+// such as $.end - $.start. Division by zero returns an error.
+type FieldMath struct {
+	conf       FieldMathConfig
+	settings   map[string]interface{}
+	targetPath string
+}
+
+func (tf *FieldMath) Transform(ctx context.Context, msg *message.Message) ([]*message.Message, error) {
+	if msg.IsControl() {
+		return []*message.Message{msg}, nil
+	}
+
+	left := msg.GetValue(tf.conf.Left)
+	if !left.Exists() {
+		return nil, fmt.Errorf("transform %s: left %s not found", tf.conf.ID, tf.conf.Left)
+	}
+	right := msg.GetValue(tf.conf.Right)
+	if !right.Exists() {
+		return nil, fmt.Errorf("transform %s: right %s not found", tf.conf.ID, tf.conf.Right)
+	}
+
+	l, ok := numericValue(left)
+	if !ok {
+		return nil, fmt.Errorf("transform %s: left %s is not a number", tf.conf.ID, tf.conf.Left)
+	}
+	r, ok := numericValue(right)
+	if !ok {
+		return nil, fmt.Errorf("transform %s: right %s is not a number", tf.conf.ID, tf.conf.Right)
+	}
+
+	var result float64
+	switch tf.conf.Operation {
+	case "add":
+		result = l + r
+	case "subtract":
+		result = l - r
+	case "multiply":
+		result = l * r
+	case "divide":
+		if r == 0 {
+			return nil, fmt.Errorf("transform %s: division by zero", tf.conf.ID)
+		}
+		result = l / r
+	}
+
+	if tf.targetPath != "" {
+		if err := msg.SetValue(tf.targetPath, result); err != nil {
+			return nil, fmt.Errorf("transform %s: failed to set target: %v", tf.conf.ID, err)
+		}
+	} else {
+		msg.SetData([]byte(strconv.FormatFloat(result, 'f', -1, 64)))
+	}
+
+	return []*message.Message{msg}, nil
+}
+
+func (tf *FieldMath) String() string {
+	b, _ := json.Marshal(tf.conf)
+	return string(b)
+}
+
+// numericValue returns the value as a float64 if it is a number or a string
+// that parses as a finite number.
+func numericValue(v message.Value) (float64, bool) {
+	switch n := v.Value().(type) {
+	case float64:
+		return n, true
+	case int:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	case uint:
+		return float64(n), true
+	case uint64:
+		return float64(n), true
+	case string:
+		return parseNumber(n)
+	}
+
+	return 0, false
+}
+
+// parseNumber parses s as a float64, rejecting NaN and infinities.
+func parseNumber(s string) (float64, bool) {
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil || math.IsNaN(f) || math.IsInf(f, 0) {
+		return 0, false
+	}
+	return f, true
+}
@@ -0,0 +1,122 @@
+package transform
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jshlbrd/vibestation/config"
+	"github.com/jshlbrd/vibestation/message"
+)
+
+func TestFieldMath(t *testing.T) {
+	tests := []struct {
+		operation string
+		data      string
+		expected  string
+	}{
+		{"subtract", `{"start":100,"end":250}`, "150"},
+		{"add", `{"start":1.5,"end":2}`, "3.5"},
+		{"multiply", `{"start":3,"end":4}`, "12"},
+		{"divide", `{"start":4,"end":10}`, "2.5"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.operation, func(t *testing.T) {
+			cfg := config.Config{
+				Type: "field_math",
+				Settings: map[string]interface{}{
+					"left":      "$.end",
+					"right":     "$.start",
+					"operation": test.operation,
+					"target":    "$.result",
+				},
+			}
+			tf, err := newFieldMath(context.Background(), cfg)
+			if err != nil {
+				t.Fatalf("failed to create field_math transform: %v", err)
+			}
+
+			msg := message.New().SetData([]byte(test.data))
+			results, err := tf.Transform(context.Background(), msg)
+			if err != nil {
+				t.Fatalf("transform failed: %v", err)
+			}
+
+			if got := results[0].GetValue("$.result").String(); got != test.expected {
+				t.Errorf("expected %s, got %s", test.expected, got)
+			}
+		})
+	}
+}
+
+func TestFieldMath_DivideByZero(t *testing.T) {
+	cfg := config.Config{
+		Type: "field_math",
+		Settings: map[string]interface{}{
+			"left":      "$.a",
+			"right":     "$.b",
+			"operation": "divide",
+			"target":    "$.c",
+		},
+	}
+	tf, err := newFieldMath(context.Background(), cfg)
+	if err != nil {
+		t.Fatalf("failed to create field_math transform: %v", err)
+	}
+
+	msg := message.New().SetData([]byte(`{"a":1,"b":0}`))
+	if _, err := tf.Transform(context.Background(), msg); err == nil {
+		t.Fatal("expected error for division by zero, got nil")
+	}
+}
+
+func TestFieldMath_NonNumeric(t *testing.T) {
+	cfg := config.Config{
+		Type: "field_math",
+		Settings: map[string]interface{}{
+			"left":      "$.a",
+			"right":     "$.b",
+			"operation": "subtract",
+			"target":    "$.c",
+		},
+	}
+	tf, err := newFieldMath(context.Background(), cfg)
+	if err != nil {
+		t.Fatalf("failed to create field_math transform: %v", err)
+	}
+
+	inputs := []string{
+		`{"a":"abc","b":1}`,
+		`{"a":1,"b":"NaN"}`,
+		`{"a":true,"b":1}`,
+		`{"a":[1],"b":1}`,
+	}
+	for _, input := range inputs {
+		if _, err := tf.Transform(context.Background(), message.New().SetData([]byte(input))); err == nil {
+			t.Errorf("expected error for %s, got nil", input)
+		}
+	}
+
+	// Strings that contain numbers are allowed.
+	results, err := tf.Transform(context.Background(), message.New().SetData([]byte(`{"a":"3.5","b":1}`)))
+	if err != nil {
+		t.Fatalf("transform failed: %v", err)
+	}
+	if got := results[0].GetValue("$.c").Float(); got != 2.5 {
+		t.Errorf("expected 2.5, got %v", got)
+	}
+}
+
+func TestFieldMath_InvalidOperation(t *testing.T) {
+	cfg := config.Config{
+		Type: "field_math",
+		Settings: map[string]interface{}{
+			"left":      "$.a",
+			"right":     "$.b",
+			"operation": "modulo",
+		},
+	}
+	if _, err := newFieldMath(context.Background(), cfg); err == nil {
+		t.Fatal("expected error for unsupported operation, got nil")
+	}
+}
@@ -0,0 +1,124 @@
+package transform
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/jshlbrd/vibestation/config"
+	"github.com/jshlbrd/vibestation/message"
+)
+
+type FlattenArrayConfig struct {
+	// Depth is the maximum number of nested levels that are flattened. If
+	// not set, then arrays are flattened completely.
+	Depth int    `json:"depth"`
+	ID    string `json:"id"`
+}
+
+func (c *FlattenArrayConfig) Decode(in interface{}) error {
+	return config.Decode(in, c)
+}
+
+func (c *FlattenArrayConfig) Validate() error {
+	if c.Depth < 0 {
+		return fmt.Errorf("depth: must not be negative")
+	}
+	return nil
+}
+
+func newFlattenArray(_ context.Context, cfg config.Config) (*FlattenArray, error) {
+	conf := FlattenArrayConfig{}
+	if err := conf.Decode(cfg.Settings); err != nil {
+		return nil, fmt.Errorf("transform flatten_array: %v", err)
+	}
+	if conf.ID == "" {
+		conf.ID = "flatten_array"
+	}
+	if err := conf.Validate(); err != nil {
+		return nil, fmt.Errorf("transform %s: %v", conf.ID, err)
+	}
+
+	sourcePath := "$"
+	if v, ok := cfg.Settings["source"]; ok {
+		if s, ok := v.(string); ok && s != "" {
+			sourcePath = s
+		}
+	}
+
+	var targetPath string
+	if v, ok := cfg.Settings["target"]; ok {
+		if s, ok := v.(string); ok {
+			targetPath = s
+		}
+	}
+
+	tf := FlattenArray{
+		conf:       conf,
+		settings:   cfg.Settings,
+		sourcePath: sourcePath,
+		targetPath: targetPath,
+	}
+	return &tf, nil
+}
+
+// FlattenArray flattens nested arrays into a single array, such as
+// [[1,[2]],3] into [1,2,3].
+type FlattenArray struct {
+	conf       FlattenArrayConfig
+	settings   map[string]interface{}
+	sourcePath string
+	targetPath string
+}
+
+func (tf *FlattenArray) Transform(ctx context.Context, msg *message.Message) ([]*message.Message, error) {
+	if msg.IsControl() {
+		return []*message.Message{msg}, nil
+	}
+
+	val := msg.GetValue(tf.sourcePath)
+	arr, ok := val.Value().([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("transform %s: source %s is not an array", tf.conf.ID, tf.sourcePath)
+	}
+
+	depth := tf.conf.Depth
+	if depth == 0 {
+		depth = -1
+	}
+	result := flattenArray(arr, depth)
+
+	if tf.targetPath != "" {
+		if err := msg.SetValue(tf.targetPath, result); err != nil {
+			return nil, fmt.Errorf("transform %s: failed to set target: %v", tf.conf.ID, err)
+		}
+	} else {
+		b, err := json.Marshal(result)
+		if err != nil {
+			return nil, fmt.Errorf("transform %s: %v", tf.conf.ID, err)
+		}
+		msg.SetData(b)
+	}
+
+	return []*message.Message{msg}, nil
+}
+
+func (tf *FlattenArray) String() string {
+	b, _ := json.Marshal(tf.conf)
+	return string(b)
+}
+
+// flattenArray flattens arr up to depth levels. A negative depth flattens
+// completely.
+func flattenArray(arr []interface{}, depth int) []interface{} {
+	result := make([]interface{}, 0, len(arr))
+	for _, v := range arr {
+		if nested, ok := v.([]interface{}); ok && depth != 0 {
+			result = append(result, flattenArray(nested, depth-1)...)
+			continue
+		}
+
+		result = append(result, v)
+	}
+	return result
+}
@@ -0,0 +1,67 @@
+package transform
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jshlbrd/vibestation/config"
+	"github.com/jshlbrd/vibestation/message"
+)
+
+func TestFlattenArray(t *testing.T) {
+	tests := []struct {
+		name     string
+		depth    int
+		data     string
+		expected string
+	}{
+		{"unlimited", 0, `[[1,[2]],3]`, `[1,2,3]`},
+		{"deep", 0, `[[[["a"]]],{"b":[1]},[]]`, `["a",{"b":[1]}]`},
+		{"depth 1", 1, `[[1,[2]],3]`, `[1,[2],3]`},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cfg := config.Config{
+				Type:     "flatten_array",
+				Settings: map[string]interface{}{"depth": test.depth},
+			}
+			tf, err := newFlattenArray(context.Background(), cfg)
+			if err != nil {
+				t.Fatalf("failed to create flatten_array transform: %v", err)
+			}
+
+			results, err := tf.Transform(context.Background(), message.New().SetData([]byte(test.data)))
+			if err != nil {
+				t.Fatalf("transform failed: %v", err)
+			}
+
+			if got := string(results[0].Data()); got != test.expected {
+				t.Errorf("expected %s, got %s", test.expected, got)
+			}
+		})
+	}
+}
+
+func TestFlattenArray_SourceTarget(t *testing.T) {
+	cfg := config.Config{
+		Type: "flatten_array",
+		Settings: map[string]interface{}{
+			"source": "$.nested",
+			"target": "$.flat",
+		},
+	}
+	tf, err := newFlattenArray(context.Background(), cfg)
+	if err != nil {
+		t.Fatalf("failed to create flatten_array transform: %v", err)
+	}
+
+	results, err := tf.Transform(context.Background(), message.New().SetData([]byte(`{"nested":[[1,[2]],3]}`)))
+	if err != nil {
+		t.Fatalf("transform failed: %v", err)
+	}
+
+	if got := results[0].GetValue("$.flat").String(); got != `[1,2,3]` {
+		t.Errorf("expected [1,2,3], got %s", got)
+	}
+}
@@ -0,0 +1,137 @@
+package transform
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"regexp"
+
+	"github.com/jshlbrd/vibestation/config"
+	"github.com/jshlbrd/vibestation/message"
+)
+
+// formatPlaceholder matches {field} placeholders in format_string.
+var formatPlaceholder = regexp.MustCompile(`\{([^{}]+)\}`)
+
+type FormatStringConfig struct {
+	// Format is the format string. If Args is set, then it uses Go fmt verbs
+	// (e.g. "%s=%d"). Otherwise, {field} placeholders are replaced with the
+	// value at $.field.
+	Format string `json:"format"`
+	// Args is the ordered list of JSON paths used as fmt arguments.
+	Args []string `json:"args"`
+	ID   string   `json:"id"`
+}
+
+func (c *FormatStringConfig) Decode(in interface{}) error {
+	return config.Decode(in, c)
+}
+
+func (c *FormatStringConfig) Validate() error {
+	if c.Format == "" {
+		return fmt.Errorf("format: missing required option")
+	}
+	return nil
+}
+
+func newFormatString(_ context.Context, cfg config.Config) (*FormatString, error) {
+	conf := FormatStringConfig{}
+	if err := conf.Decode(cfg.Settings); err != nil {
+		return nil, fmt.Errorf("transform format_string: %v", err)
+	}
+	if conf.ID == "" {
+		conf.ID = "format_string"
+	}
+	if err := conf.Validate(); err != nil {
+		return nil, fmt.Errorf("transform %s: %v", conf.ID, err)
+	}
+
+	var targetPath string
+	if v, ok := cfg.Settings["target"]; ok {
+		if s, ok := v.(string); ok {
+			targetPath = s
+		}
+	}
+
+	tf := FormatString{
+		conf:       conf,
+		settings:   cfg.Settings,
+		targetPath: targetPath,
+	}
+	return &tf, nil
+}
+
+// FormatString builds a string from values in the message, either with Go
+// fmt verbs and an ordered list of argument paths, or with {field}
+// placeholders. Missing values are formatted as empty strings in placeholder
+// mode and as nil in fmt mode.
+type FormatString struct {
+	conf       FormatStringConfig
+	settings   map[string]interface{}
+	targetPath string
+}
+
+func (tf *FormatString) Transform(ctx context.Context, msg *message.Message) ([]*message.Message, error) {
+	if msg.IsControl() {
+		return []*message.Message{msg}, nil
+	}
+
+	var result string
+	if len(tf.conf.Args) > 0 {
+		args := make([]interface{}, len(tf.conf.Args))
+		for i, p := range tf.conf.Args {
+			args[i] = formatArg(msg.GetValue(p).Value())
+		}
+		result = fmt.Sprintf(tf.conf.Format, args...)
+	} else {
+		result = formatPlaceholder.ReplaceAllStringFunc(tf.conf.Format, func(m string) string {
+			val := msg.GetValue("$." + m[1:len(m)-1])
+			if !val.Exists() {
+				return ""
+			}
+			return val.String()
+		})
+	}
+
+	if tf.targetPath != "" {
+		if err := msg.SetValue(tf.targetPath, result); err != nil {
+			return nil, fmt.Errorf("transform %s: failed to set target: %v", tf.conf.ID, err)
+		}
+	} else {
+		msg.SetData([]byte(result))
+	}
+
+	return []*message.Message{msg}, nil
+}
+
+func (tf *FormatString) String() string {
+	b, _ := json.Marshal(tf.conf)
+	return string(b)
+}
+
+// formatArg wraps JSON numbers so that whole numbers can be formatted with
+// integer verbs such as %d, while float verbs such as %.2f still receive a
+// float64.
+func formatArg(v interface{}) interface{} {
+	if f, ok := v.(float64); ok {
+		return formatNumber(f)
+	}
+	return v
+}
+
+// formatNumber is a JSON number that is formatted as an int64 for integer
+// verbs when it has no fractional part, and as a float64 otherwise.
+type formatNumber float64
+
+func (n formatNumber) Format(s fmt.State, verb rune) {
+	f := float64(n)
+	switch verb {
+	case 'b', 'c', 'd', 'o', 'O', 'q', 'U', 'x', 'X':
+		if f == math.Trunc(f) && math.Abs(f) < 1<<53 {
+			fmt.Fprintf(s, fmt.FormatString(s, verb), int64(f))
+			return
+		}
+	}
+	fmt.Fprintf(s, fmt.FormatString(s, verb), f)
+}
@@ -0,0 +1,71 @@
+package transform
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jshlbrd/vibestation/config"
+	"github.com/jshlbrd/vibestation/message"
+)
+
+func TestFormatString(t *testing.T) {
+	data := `{"name":"alice","count":3,"ratio":0.5,"user":{"id":"u1"}}`
+
+	tests := []struct {
+		name     string
+		settings map[string]interface{}
+		expected string
+	}{
+		{
+			"fmt verbs",
+			map[string]interface{}{
+				"format": "%s=%d (%.1f)",
+				"args":   []interface{}{"$.name", "$.count", "$.ratio"},
+			},
+			"alice=3 (0.5)",
+		},
+		{
+			"float verb with whole number",
+			map[string]interface{}{
+				"format": "%.2f/%03d/%v/%x",
+				"args":   []interface{}{"$.count", "$.count", "$.count", "$.count"},
+			},
+			"3.00/003/3/3",
+		},
+		{
+			"placeholders",
+			map[string]interface{}{
+				"format": "{name} has {count} items as {user.id}{missing}",
+			},
+			"alice has 3 items as u1",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			test.settings["target"] = "$.message"
+			tf, err := newFormatString(context.Background(), config.Config{
+				Type:     "format_string",
+				Settings: test.settings,
+			})
+			if err != nil {
+				t.Fatalf("failed to create format_string transform: %v", err)
+			}
+
+			results, err := tf.Transform(context.Background(), message.New().SetData([]byte(data)))
+			if err != nil {
+				t.Fatalf("transform failed: %v", err)
+			}
+
+			if got := results[0].GetValue("$.message").String(); got != test.expected {
+				t.Errorf("expected %q, got %q", test.expected, got)
+			}
+		})
+	}
+}
+
+func TestFormatString_MissingFormat(t *testing.T) {
+	if _, err := newFormatString(context.Background(), config.Config{Type: "format_string"}); err == nil {
+		t.Fatal("expected error for missing format, got nil")
+	}
+}
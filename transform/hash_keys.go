@@ -0,0 +1,115 @@
+package transform
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"hash"
+	"regexp"
+
+	"github.com/jshlbrd/vibestation/config"
+	"github.com/jshlbrd/vibestation/message"
+)
+
+type HashKeysConfig struct {
+	// Pattern is a regular expression matched against each key.
+	Pattern string `json:"pattern"`
+	// Algorithm is the hash algorithm, one of sha1, sha256, or sha512.
+	// Defaults to sha256.
+	Algorithm string `json:"algorithm"`
+	ID        string `json:"id"`
+}
+
+func (c *HashKeysConfig) Decode(in interface{}) error {
+	return config.Decode(in, c)
+}
+
+func (c *HashKeysConfig) Validate() error {
+	if c.Pattern == "" {
+		return fmt.Errorf("pattern: missing required option")
+	}
+	return nil
+}
+
+func newHashKeys(_ context.Context, cfg config.Config) (*HashKeys, error) {
+	conf := HashKeysConfig{}
+	if err := conf.Decode(cfg.Settings); err != nil {
+		return nil, fmt.Errorf("transform hash_keys: %v", err)
+	}
+	if conf.ID == "" {
+		conf.ID = "hash_keys"
+	}
+	if conf.Algorithm == "" {
+		conf.Algorithm = "sha256"
+	}
+	if err := conf.Validate(); err != nil {
+		return nil, fmt.Errorf("transform %s: %v", conf.ID, err)
+	}
+
+	re, err := regexp.Compile(conf.Pattern)
+	if err != nil {
+		return nil, fmt.Errorf("transform %s: pattern: %v", conf.ID, err)
+	}
+
+	hashFunc, err := newHashFunc(conf.Algorithm)
+	if err != nil {
+		return nil, fmt.Errorf("transform %s: %v", conf.ID, err)
+	}
+
+	tf := HashKeys{
+		conf:     conf,
+		settings: cfg.Settings,
+		re:       re,
+		hashFunc: hashFunc,
+	}
+	return &tf, nil
+}
+
+// HashKeys replaces the value of every key in the data that matches the
+// pattern with its hex digest, including keys in nested objects and arrays
+// of objects. Strings are hashed as-is and other values are hashed as JSON.
+type HashKeys struct {
+	conf     HashKeysConfig
+	settings map[string]interface{}
+	re       *regexp.Regexp
+	hashFunc func() hash.Hash
+}
+
+func (tf *HashKeys) Transform(ctx context.Context, msg *message.Message) ([]*message.Message, error) {
+	if msg.IsControl() {
+		return []*message.Message{msg}, nil
+	}
+
+	data, err := decodeJSONNumber(msg.Data())
+	if err != nil {
+		return nil, fmt.Errorf("transform %s: %v", tf.conf.ID, err)
+	}
+
+	data = replaceKeys(data, tf.re, func(v interface{}) interface{} {
+		var b []byte
+		if s, ok := v.(string); ok {
+			b = []byte(s)
+		} else {
+			// Values decoded from JSON always marshal.
+			b, _ = json.Marshal(v)
+		}
+
+		h := tf.hashFunc()
+		h.Write(b)
+		return hex.EncodeToString(h.Sum(nil))
+	})
+
+	b, err := json.Marshal(data)
+	if err != nil {
+		return nil, fmt.Errorf("transform %s: %v", tf.conf.ID, err)
+	}
+	msg.SetData(b)
+
+	return []*message.Message{msg}, nil
+}
+
+func (tf *HashKeys) String() string {
+	b, _ := json.Marshal(tf.conf)
+	return string(b)
+}
@@ -0,0 +1,68 @@
+package transform
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jshlbrd/vibestation/config"
+	"github.com/jshlbrd/vibestation/message"
+)
+
+func TestHashKeys(t *testing.T) {
+	cfg := config.Config{
+		Type: "hash_keys",
+		Settings: map[string]interface{}{
+			"pattern": "^(email|ip)$",
+		},
+	}
+	tf, err := newHashKeys(context.Background(), cfg)
+	if err != nil {
+		t.Fatalf("failed to create hash_keys transform: %v", err)
+	}
+
+	// sha256("alice@example.com") and sha256("10.0.0.1")
+	email := "ff8d9819fc0e12bf0d24892e45987e249a28dce836a85cad60e28eaaa8c6d976"
+	ip := "f5047344122f0dee9974ba6761e61c6b8649e1f3968d13a635ebbf7be53a3a0d"
+
+	msg := message.New().SetData([]byte(`{"email":"alice@example.com","event":"login","client":{"ip":"10.0.0.1","port":443}}`))
+	results, err := tf.Transform(context.Background(), msg)
+	if err != nil {
+		t.Fatalf("transform failed: %v", err)
+	}
+
+	if got := results[0].GetValue("$.email").String(); got != email {
+		t.Errorf("expected email %s, got %s", email, got)
+	}
+	if got := results[0].GetValue("$.client.ip").String(); got != ip {
+		t.Errorf("expected ip %s, got %s", ip, got)
+	}
+	if got := results[0].GetValue("$.event").String(); got != "login" {
+		t.Errorf("expected event to be untouched, got %s", got)
+	}
+	if got := results[0].GetValue("$.client.port").Int(); got != 443 {
+		t.Errorf("expected port to be untouched, got %d", got)
+	}
+
+	// The same input produces the same digest.
+	msg = message.New().SetData([]byte(`{"user":{"email":"alice@example.com"}}`))
+	results, err = tf.Transform(context.Background(), msg)
+	if err != nil {
+		t.Fatalf("transform failed: %v", err)
+	}
+	if got := results[0].GetValue("$.user.email").String(); got != email {
+		t.Errorf("expected nested email %s, got %s", email, got)
+	}
+}
+
+func TestHashKeys_InvalidAlgorithm(t *testing.T) {
+	cfg := config.Config{
+		Type: "hash_keys",
+		Settings: map[string]interface{}{
+			"pattern":   "email",
+			"algorithm": "md4",
+		},
+	}
+	if _, err := newHashKeys(context.Background(), cfg); err == nil {
+		t.Fatal("expected error for unsupported algorithm, got nil")
+	}
+}
@@ -0,0 +1,132 @@
+package transform
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/jshlbrd/vibestation/config"
+	"github.com/jshlbrd/vibestation/message"
+)
+
+type HumanizeBytesConfig struct {
+	// Base is either 1024 (KiB, MiB, ...) or 1000 (kB, MB, ...). Defaults
+	// to 1024.
+	Base int    `json:"base"`
+	ID   string `json:"id"`
+}
+
+func (c *HumanizeBytesConfig) Decode(in interface{}) error {
+	return config.Decode(in, c)
+}
+
+func (c *HumanizeBytesConfig) Validate() error {
+	if c.Base != 1000 && c.Base != 1024 {
+		return fmt.Errorf("base: must be 1000 or 1024")
+	}
+	return nil
+}
+
+func newHumanizeBytes(_ context.Context, cfg config.Config) (*HumanizeBytes, error) {
+	conf := HumanizeBytesConfig{}
+	if err := conf.Decode(cfg.Settings); err != nil {
+		return nil, fmt.Errorf("transform humanize_bytes: %v", err)
+	}
+	if conf.ID == "" {
+		conf.ID = "humanize_bytes"
+	}
+	if conf.Base == 0 {
+		conf.Base = 1024
+	}
+	if err := conf.Validate(); err != nil {
+		return nil, fmt.Errorf("transform %s: %v", conf.ID, err)
+	}
+
+	var sourcePath string
+	if v, ok := cfg.Settings["source"]; ok {
+		if s, ok := v.(string); ok {
+			sourcePath = s
+		}
+	}
+
+	var targetPath string
+	if v, ok := cfg.Settings["target"]; ok {
+		if s, ok := v.(string); ok {
+			targetPath = s
+		}
+	}
+
+	tf := HumanizeBytes{
+		conf:       conf,
+		settings:   cfg.Settings,
+		sourcePath: sourcePath,
+		targetPath: targetPath,
+	}
+	return &tf, nil
+}
+
+// HumanizeBytes converts a number of bytes into a human-readable string,
+// such as 1536 into "1.5 KiB".
+type HumanizeBytes struct {
+	conf       HumanizeBytesConfig
+	settings   map[string]interface{}
+	sourcePath string
+	targetPath string
+}
+
+func (tf *HumanizeBytes) Transform(ctx context.Context, msg *message.Message) ([]*message.Message, error) {
+	if msg.IsControl() {
+		return []*message.Message{msg}, nil
+	}
+
+	var (
+		n  float64
+		ok bool
+	)
+	if val := msg.GetValue(tf.sourcePath); tf.sourcePath != "" && val.Exists() {
+		n, ok = numericValue(val)
+	} else {
+		n, ok = parseNumber(strings.TrimSpace(string(msg.Data())))
+	}
+	if !ok {
+		return nil, fmt.Errorf("transform %s: source is not a number", tf.conf.ID)
+	}
+	result := humanizeBytes(n, tf.conf.Base)
+
+	if tf.targetPath != "" {
+		if err := msg.SetValue(tf.targetPath, result); err != nil {
+			return nil, fmt.Errorf("transform %s: failed to set target: %v", tf.conf.ID, err)
+		}
+	} else {
+		msg.SetData([]byte(result))
+	}
+
+	return []*message.Message{msg}, nil
+}
+
+func (tf *HumanizeBytes) String() string {
+	b, _ := json.Marshal(tf.conf)
+	return string(b)
+}
+
+// humanizeBytes formats n bytes using binary (base 1024) or decimal (base
+// 1000) units.
+func humanizeBytes(n float64, base int) string {
+	units := []string{"KiB", "MiB", "GiB", "TiB", "PiB", "EiB"}
+	if base == 1000 {
+		units = []string{"kB", "MB", "GB", "TB", "PB", "EB"}
+	}
+
+	b := float64(base)
+	if n < b && n > -b {
+		return fmt.Sprintf("%d B", int64(n))
+	}
+
+	i := -1
+	for (n >= b || n <= -b) && i < len(units)-1 {
+		n /= b
+		i++
+	}
+	return fmt.Sprintf("%.1f %s", n, units[i])
+}
@@ -0,0 +1,75 @@
+package transform
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jshlbrd/vibestation/config"
+	"github.com/jshlbrd/vibestation/message"
+)
+
+func TestHumanizeBytes(t *testing.T) {
+	tests := []struct {
+		base     int
+		size     string
+		expected string
+	}{
+		{1024, "512", "512 B"},
+		{1024, "1536", "1.5 KiB"},
+		{1024, "5242880", "5.0 MiB"},
+		{1000, "999", "999 B"},
+		{1000, "1500", "1.5 kB"},
+		{1000, "2500000", "2.5 MB"},
+	}
+
+	for _, test := range tests {
+		cfg := config.Config{
+			Type: "humanize_bytes",
+			Settings: map[string]interface{}{
+				"base":   test.base,
+				"source": "$.size",
+				"target": "$.size_human",
+			},
+		}
+		tf, err := newHumanizeBytes(context.Background(), cfg)
+		if err != nil {
+			t.Fatalf("failed to create humanize_bytes transform: %v", err)
+		}
+
+		msg := message.New().SetData([]byte(`{"size":` + test.size + `}`))
+		results, err := tf.Transform(context.Background(), msg)
+		if err != nil {
+			t.Fatalf("transform failed: %v", err)
+		}
+
+		if got := results[0].GetValue("$.size_human").String(); got != test.expected {
+			t.Errorf("%s (base %d): expected %q, got %q", test.size, test.base, test.expected, got)
+		}
+	}
+}
+
+func TestHumanizeBytes_NotNumber(t *testing.T) {
+	tf, err := newHumanizeBytes(context.Background(), config.Config{
+		Type:     "humanize_bytes",
+		Settings: map[string]interface{}{"source": "$.size"},
+	})
+	if err != nil {
+		t.Fatalf("failed to create humanize_bytes transform: %v", err)
+	}
+
+	for _, data := range []string{`{"size":"NaN"}`, `{"size":"Inf"}`, `{"size":"-Inf"}`, `{"size":"abc"}`, `NaN`, `+Inf`} {
+		if _, err := tf.Transform(context.Background(), message.New().SetData([]byte(data))); err == nil {
+			t.Errorf("%s: expected error, got nil", data)
+		}
+	}
+}
+
+func TestHumanizeBytes_InvalidBase(t *testing.T) {
+	cfg := config.Config{
+		Type:     "humanize_bytes",
+		Settings: map[string]interface{}{"base": 512},
+	}
+	if _, err := newHumanizeBytes(context.Background(), cfg); err == nil {
+		t.Fatal("expected error for invalid base, got nil")
+	}
+}
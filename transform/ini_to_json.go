@@ -0,0 +1,124 @@
+package transform
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/jshlbrd/vibestation/config"
+	"github.com/jshlbrd/vibestation/message"
+)
+
+type INIToJSONConfig struct {
+	ID string `json:"id"`
+}
+
+func (c *INIToJSONConfig) Decode(in interface{}) error {
+	return config.Decode(in, c)
+}
+
+func newINIToJSON(_ context.Context, cfg config.Config) (*INIToJSON, error) {
+	conf := INIToJSONConfig{}
+	if err := conf.Decode(cfg.Settings); err != nil {
+		return nil, fmt.Errorf("transform ini_to_json: %v", err)
+	}
+	if conf.ID == "" {
+		conf.ID = "ini_to_json"
+	}
+
+	tf := INIToJSON{
+		conf:     conf,
+		settings: cfg.Settings,
+	}
+	return &tf, nil
+}
+
+// INIToJSON converts INI text into a JSON object. Keys inside a [section]
+// are nested under the section name and keys before the first section are
+// written at the top level. Lines starting with ';' or '#' are comments. If
+// a key appears more than once, then its values are collected into an array.
+type INIToJSON struct {
+	conf     INIToJSONConfig
+	settings map[string]interface{}
+}
+
+func (tf *INIToJSON) Transform(ctx context.Context, msg *message.Message) ([]*message.Message, error) {
+	if msg.IsControl() {
+		return []*message.Message{msg}, nil
+	}
+
+	obj, err := parseINI(string(msg.Data()))
+	if err != nil {
+		return nil, fmt.Errorf("transform %s: %v", tf.conf.ID, err)
+	}
+
+	b, err := json.Marshal(obj)
+	if err != nil {
+		return nil, fmt.Errorf("transform %s: %v", tf.conf.ID, err)
+	}
+
+	msg.SetData(b)
+	return []*message.Message{msg}, nil
+}
+
+func (tf *INIToJSON) String() string {
+	b, _ := json.Marshal(tf.conf)
+	return string(b)
+}
+
+func parseINI(s string) (map[string]interface{}, error) {
+	root := make(map[string]interface{})
+	current := root
+
+	for i, line := range strings.Split(strings.ReplaceAll(s, "\r\n", "\n"), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, ";") || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		if strings.HasPrefix(line, "[") {
+			if !strings.HasSuffix(line, "]") {
+				return nil, fmt.Errorf("line %d: unterminated section", i+1)
+			}
+			name := strings.TrimSpace(line[1 : len(line)-1])
+			if name == "" {
+				return nil, fmt.Errorf("line %d: empty section name", i+1)
+			}
+
+			section, ok := root[name].(map[string]interface{})
+			if !ok {
+				if _, exists := root[name]; exists {
+					return nil, fmt.Errorf("line %d: section %q conflicts with key", i+1, name)
+				}
+				section = make(map[string]interface{})
+				root[name] = section
+			}
+			current = section
+			continue
+		}
+
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			return nil, fmt.Errorf("line %d: expected key = value", i+1)
+		}
+		key = strings.TrimSpace(key)
+		value = strings.TrimSpace(value)
+		if key == "" {
+			return nil, fmt.Errorf("line %d: empty key", i+1)
+		}
+
+		switch existing := current[key].(type) {
+		case nil:
+			current[key] = value
+		case []interface{}:
+			current[key] = append(existing, value)
+		case string:
+			current[key] = []interface{}{existing, value}
+		default:
+			return nil, fmt.Errorf("line %d: key %q conflicts with section", i+1, key)
+		}
+	}
+
+	return root, nil
+}
@@ -0,0 +1,63 @@
+package transform
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jshlbrd/vibestation/config"
+	"github.com/jshlbrd/vibestation/message"
+)
+
+func TestINIToJSON(t *testing.T) {
+	tf, err := newINIToJSON(context.Background(), config.Config{Type: "ini_to_json"})
+	if err != nil {
+		t.Fatalf("failed to create ini_to_json transform: %v", err)
+	}
+
+	input := "; global settings\n" +
+		"name = app\n" +
+		"\n" +
+		"[database]\n" +
+		"# connection details\n" +
+		"host = localhost\n" +
+		"port=5432\n" +
+		"\n" +
+		"[server]\n" +
+		"; listeners\n" +
+		"listen = :80\n" +
+		"listen = :443\n"
+
+	results, err := tf.Transform(context.Background(), message.New().SetData([]byte(input)))
+	if err != nil {
+		t.Fatalf("transform failed: %v", err)
+	}
+
+	expected := `{"database":{"host":"localhost","port":"5432"},"name":"app","server":{"listen":[":80",":443"]}}`
+	if got := string(results[0].Data()); got != expected {
+		t.Errorf("expected %s, got %s", expected, got)
+	}
+}
+
+func TestINIToJSON_Invalid(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+	}{
+		{"missing equals", "[a]\nkey"},
+		{"unterminated section", "[a\nkey = value"},
+		{"empty key", "= value"},
+	}
+
+	tf, err := newINIToJSON(context.Background(), config.Config{Type: "ini_to_json"})
+	if err != nil {
+		t.Fatalf("failed to create ini_to_json transform: %v", err)
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := tf.Transform(context.Background(), message.New().SetData([]byte(tt.input))); err == nil {
+				t.Fatal("expected error, got nil")
+			}
+		})
+	}
+}
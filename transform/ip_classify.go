@@ -0,0 +1,117 @@
+package transform
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/jshlbrd/vibestation/config"
+	"github.com/jshlbrd/vibestation/message"
+)
+
+type IPClassifyConfig struct {
+	// Strict returns an error if the source is not a valid IP address,
+	// otherwise the message is passed through unchanged.
+	Strict bool   `json:"strict"`
+	ID     string `json:"id"`
+}
+
+func (c *IPClassifyConfig) Decode(in interface{}) error {
+	return config.Decode(in, c)
+}
+
+func newIPClassify(_ context.Context, cfg config.Config) (*IPClassify, error) {
+	conf := IPClassifyConfig{}
+	if err := conf.Decode(cfg.Settings); err != nil {
+		return nil, fmt.Errorf("transform ip_classify: %v", err)
+	}
+	if conf.ID == "" {
+		conf.ID = "ip_classify"
+	}
+
+	var sourcePath string
+	if v, ok := cfg.Settings["source"]; ok {
+		if s, ok := v.(string); ok {
+			sourcePath = s
+		}
+	}
+
+	var targetPath string
+	if v, ok := cfg.Settings["target"]; ok {
+		if s, ok := v.(string); ok {
+			targetPath = s
+		}
+	}
+
+	tf := IPClassify{
+		conf:       conf,
+		settings:   cfg.Settings,
+		sourcePath: sourcePath,
+		targetPath: targetPath,
+	}
+	return &tf, nil
+}
+
+// IPClassify writes the version of an IP address and whether it is a private
+// address as a JSON object, e.g. {"version":4,"is_private":true}.
+type IPClassify struct {
+	conf       IPClassifyConfig
+	settings   map[string]interface{}
+	sourcePath string
+	targetPath string
+}
+
+func (tf *IPClassify) Transform(ctx context.Context, msg *message.Message) ([]*message.Message, error) {
+	if msg.IsControl() {
+		return []*message.Message{msg}, nil
+	}
+
+	var inputData []byte
+	if tf.sourcePath != "" {
+		val := msg.GetValue(tf.sourcePath)
+		if val.Exists() {
+			inputData = val.Bytes()
+		}
+	}
+	if inputData == nil {
+		inputData = msg.Data()
+	}
+
+	ip := net.ParseIP(strings.TrimSpace(string(inputData)))
+	if ip == nil {
+		if tf.conf.Strict {
+			return nil, fmt.Errorf("transform %s: invalid IP address %q", tf.conf.ID, string(inputData))
+		}
+		return []*message.Message{msg}, nil
+	}
+
+	version := 6
+	if ip.To4() != nil {
+		version = 4
+	}
+	result := map[string]interface{}{
+		"version":    version,
+		"is_private": ip.IsPrivate(),
+	}
+
+	if tf.targetPath != "" {
+		if err := msg.SetValue(tf.targetPath, result); err != nil {
+			return nil, fmt.Errorf("transform %s: failed to set target: %v", tf.conf.ID, err)
+		}
+	} else {
+		b, err := json.Marshal(result)
+		if err != nil {
+			return nil, fmt.Errorf("transform %s: %v", tf.conf.ID, err)
+		}
+		msg.SetData(b)
+	}
+
+	return []*message.Message{msg}, nil
+}
+
+func (tf *IPClassify) String() string {
+	b, _ := json.Marshal(tf.conf)
+	return string(b)
+}
@@ -0,0 +1,83 @@
+package transform
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jshlbrd/vibestation/config"
+	"github.com/jshlbrd/vibestation/message"
+)
+
+func TestIPClassify(t *testing.T) {
+	tests := []struct {
+		ip        string
+		version   int64
+		isPrivate bool
+	}{
+		{"192.168.1.10", 4, true},
+		{"8.8.8.8", 4, false},
+		{"2001:4860:4860::8888", 6, false},
+		{"fd00::1", 6, true},
+	}
+
+	cfg := config.Config{
+		Type: "ip_classify",
+		Settings: map[string]interface{}{
+			"source": "$.ip",
+			"target": "$.ip_info",
+		},
+	}
+	tf, err := newIPClassify(context.Background(), cfg)
+	if err != nil {
+		t.Fatalf("failed to create ip_classify transform: %v", err)
+	}
+
+	for _, test := range tests {
+		msg := message.New().SetData([]byte(`{"ip":"` + test.ip + `"}`))
+		results, err := tf.Transform(context.Background(), msg)
+		if err != nil {
+			t.Fatalf("transform failed: %v", err)
+		}
+
+		if got := results[0].GetValue("$.ip_info.version").Int(); got != test.version {
+			t.Errorf("%s: expected version %d, got %d", test.ip, test.version, got)
+		}
+		if got := results[0].GetValue("$.ip_info.is_private").Bool(); got != test.isPrivate {
+			t.Errorf("%s: expected is_private %v, got %v", test.ip, test.isPrivate, got)
+		}
+	}
+}
+
+func TestIPClassify_Invalid(t *testing.T) {
+	tests := []struct {
+		strict  bool
+		wantErr bool
+	}{
+		{false, false},
+		{true, true},
+	}
+
+	for _, test := range tests {
+		cfg := config.Config{
+			Type: "ip_classify",
+			Settings: map[string]interface{}{
+				"source": "$.ip",
+				"target": "$.ip_info",
+				"strict": test.strict,
+			},
+		}
+		tf, err := newIPClassify(context.Background(), cfg)
+		if err != nil {
+			t.Fatalf("failed to create ip_classify transform: %v", err)
+		}
+
+		msg := message.New().SetData([]byte(`{"ip":"not-an-ip"}`))
+		results, err := tf.Transform(context.Background(), msg)
+		if (err != nil) != test.wantErr {
+			t.Fatalf("strict %v: expected error %v, got %v", test.strict, test.wantErr, err)
+		}
+		if err == nil && results[0].GetValue("$.ip_info").Exists() {
+			t.Errorf("strict %v: expected no ip_info for invalid IP", test.strict)
+		}
+	}
+}
@@ -0,0 +1,85 @@
+package transform
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/jshlbrd/vibestation/config"
+	"github.com/jshlbrd/vibestation/message"
+)
+
+type JSONToYAMLConfig struct {
+	ID string `json:"id"`
+}
+
+func (c *JSONToYAMLConfig) Decode(in interface{}) error {
+	return config.Decode(in, c)
+}
+
+func newJSONToYAML(_ context.Context, cfg config.Config) (*JSONToYAML, error) {
+	conf := JSONToYAMLConfig{}
+	if err := conf.Decode(cfg.Settings); err != nil {
+		return nil, fmt.Errorf("transform json_to_yaml: %v", err)
+	}
+	if conf.ID == "" {
+		conf.ID = "json_to_yaml"
+	}
+	var sourcePath string
+	if v, ok := cfg.Settings["source"]; ok {
+		if s, ok := v.(string); ok {
+			sourcePath = s
+		}
+	}
+
+	tf := JSONToYAML{
+		conf:       conf,
+		settings:   cfg.Settings,
+		sourcePath: sourcePath,
+	}
+	return &tf, nil
+}
+
+// JSONToYAML converts JSON to a YAML document.
+type JSONToYAML struct {
+	conf       JSONToYAMLConfig
+	settings   map[string]interface{}
+	sourcePath string
+}
+
+func (tf *JSONToYAML) Transform(ctx context.Context, msg *message.Message) ([]*message.Message, error) {
+	if msg.IsControl() {
+		return []*message.Message{msg}, nil
+	}
+
+	var inputData []byte
+	if tf.sourcePath != "" {
+		val := msg.GetValue(tf.sourcePath)
+		if val.Exists() {
+			inputData = val.Bytes()
+		}
+	}
+	if inputData == nil {
+		inputData = msg.Data()
+	}
+
+	var v interface{}
+	if err := json.Unmarshal(inputData, &v); err != nil {
+		return nil, fmt.Errorf("transform %s: %v", tf.conf.ID, err)
+	}
+
+	b, err := yaml.Marshal(v)
+	if err != nil {
+		return nil, fmt.Errorf("transform %s: %v", tf.conf.ID, err)
+	}
+
+	msg.SetData(b)
+	return []*message.Message{msg}, nil
+}
+
+func (tf *JSONToYAML) String() string {
+	b, _ := json.Marshal(tf.conf)
+	return string(b)
+}
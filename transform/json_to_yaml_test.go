@@ -0,0 +1,60 @@
+package transform
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jshlbrd/vibestation/config"
+	"github.com/jshlbrd/vibestation/message"
+)
+
+func TestJSONToYAML(t *testing.T) {
+	tf, err := newJSONToYAML(context.Background(), config.Config{Type: "json_to_yaml"})
+	if err != nil {
+		t.Fatalf("failed to create json_to_yaml transform: %v", err)
+	}
+
+	results, err := tf.Transform(context.Background(), message.New().SetData([]byte(`{"a":{"b":[1,"two"]}}`)))
+	if err != nil {
+		t.Fatalf("transform failed: %v", err)
+	}
+
+	expected := "a:\n    b:\n        - 1\n        - two\n"
+	if got := string(results[0].Data()); got != expected {
+		t.Errorf("expected %q, got %q", expected, got)
+	}
+}
+
+func TestJSONToYAML_RoundTrip(t *testing.T) {
+	toYAML, err := newJSONToYAML(context.Background(), config.Config{Type: "json_to_yaml"})
+	if err != nil {
+		t.Fatalf("failed to create json_to_yaml transform: %v", err)
+	}
+	toJSON, err := newYAMLToJSON(context.Background(), config.Config{Type: "yaml_to_json"})
+	if err != nil {
+		t.Fatalf("failed to create yaml_to_json transform: %v", err)
+	}
+
+	input := `{"meta":{"tags":["x","y"],"version":2},"nested":{"deep":{"enabled":false,"ratio":0.5}}}`
+	results, err := Apply(context.Background(), []Transformer{toYAML, toJSON}, message.New().SetData([]byte(input)))
+	if err != nil {
+		t.Fatalf("apply failed: %v", err)
+	}
+	if len(results) != 1 {
+		t.Fatalf("expected 1 message, got %d", len(results))
+	}
+	if got := string(results[0].Data()); got != input {
+		t.Errorf("expected %s, got %s", input, got)
+	}
+}
+
+func TestJSONToYAML_Invalid(t *testing.T) {
+	tf, err := newJSONToYAML(context.Background(), config.Config{Type: "json_to_yaml"})
+	if err != nil {
+		t.Fatalf("failed to create json_to_yaml transform: %v", err)
+	}
+
+	if _, err := tf.Transform(context.Background(), message.New().SetData([]byte(`{"a":`))); err == nil {
+		t.Fatal("expected error for invalid JSON, got nil")
+	}
+}
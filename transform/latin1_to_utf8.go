@@ -0,0 +1,112 @@
+package transform
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/jshlbrd/vibestation/config"
+	"github.com/jshlbrd/vibestation/message"
+)
+
+type Latin1ToUTF8Config struct {
+	ID string `json:"id"`
+}
+
+func (c *Latin1ToUTF8Config) Decode(in interface{}) error {
+	return config.Decode(in, c)
+}
+
+func newLatin1ToUTF8(_ context.Context, cfg config.Config) (*Latin1ToUTF8, error) {
+	conf := Latin1ToUTF8Config{}
+	if err := conf.Decode(cfg.Settings); err != nil {
+		return nil, fmt.Errorf("transform latin1_to_utf8: %v", err)
+	}
+
+	id := "latin1_to_utf8"
+	if v, ok := cfg.Settings["id"]; ok {
+		if s, ok := v.(string); ok && s != "" {
+			id = s
+		}
+	}
+	conf.ID = id
+
+	var sourcePath string
+	if v, ok := cfg.Settings["source"]; ok {
+		if s, ok := v.(string); ok {
+			sourcePath = s
+		}
+	}
+
+	var targetPath string
+	if v, ok := cfg.Settings["target"]; ok {
+		if s, ok := v.(string); ok {
+			targetPath = s
+		}
+	}
+
+	tf := Latin1ToUTF8{
+		conf:       conf,
+		sourcePath: sourcePath,
+		targetPath: targetPath,
+		settings:   cfg.Settings,
+	}
+
+	return &tf, nil
+}
+
+// Latin1ToUTF8 converts ISO-8859-1 (Latin-1) encoded data to UTF-8.
+type Latin1ToUTF8 struct {
+	conf       Latin1ToUTF8Config
+	sourcePath string
+	targetPath string
+	settings   map[string]interface{}
+}
+
+func (tf *Latin1ToUTF8) Transform(ctx context.Context, msg *message.Message) ([]*message.Message, error) {
+	if msg.IsControl() {
+		return []*message.Message{msg}, nil
+	}
+
+	var inputData []byte
+	if tf.sourcePath != "" {
+		val := msg.GetValue(tf.sourcePath)
+		if val.Exists() {
+			inputData = val.Bytes()
+		}
+	}
+	if inputData == nil {
+		inputData = msg.Data()
+	}
+
+	converted := latin1ToUTF8(inputData)
+
+	if tf.targetPath != "" {
+		err := msg.SetValue(tf.targetPath, converted)
+		if err != nil {
+			return nil, fmt.Errorf("transform %s: failed to set target: %v", tf.conf.ID, err)
+		}
+	} else {
+		msg.SetData([]byte(converted))
+	}
+
+	return []*message.Message{msg}, nil
+}
+
+func (tf *Latin1ToUTF8) String() string {
+	b, _ := json.Marshal(tf.conf)
+	return string(b)
+}
+
+// latin1ToUTF8 converts Latin-1 data to UTF-8. Every Latin-1 byte maps
+// directly to the Unicode code point with the same value.
+func latin1ToUTF8(data []byte) string {
+	var b strings.Builder
+	b.Grow(len(data))
+	for _, c := range data {
+		b.WriteRune(rune(c))
+	}
+
+	return b.String()
+}
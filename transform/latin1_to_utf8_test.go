@@ -0,0 +1,47 @@
+package transform
+
+import (
+	"context"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/jshlbrd/vibestation/config"
+	"github.com/jshlbrd/vibestation/message"
+)
+
+func TestLatin1ToUTF8(t *testing.T) {
+	tf, err := newLatin1ToUTF8(context.Background(), config.Config{Type: "latin1_to_utf8"})
+	if err != nil {
+		t.Fatalf("failed to create latin1_to_utf8 transform: %v", err)
+	}
+
+	// "café" and "ñ" encoded as Latin-1.
+	msg := message.New().SetData([]byte{0x63, 0x61, 0x66, 0xe9, 0x20, 0xf1})
+	results, err := tf.Transform(context.Background(), msg)
+	if err != nil {
+		t.Fatalf("transform failed: %v", err)
+	}
+
+	if !utf8.Valid(results[0].Data()) {
+		t.Fatal("expected valid UTF-8 output")
+	}
+	if string(results[0].Data()) != "café ñ" {
+		t.Errorf("expected %q, got %q", "café ñ", string(results[0].Data()))
+	}
+}
+
+func TestLatin1ToUTF8_ASCII(t *testing.T) {
+	tf, err := newLatin1ToUTF8(context.Background(), config.Config{Type: "latin1_to_utf8"})
+	if err != nil {
+		t.Fatalf("failed to create latin1_to_utf8 transform: %v", err)
+	}
+
+	msg := message.New().SetData([]byte("plain"))
+	results, err := tf.Transform(context.Background(), msg)
+	if err != nil {
+		t.Fatalf("transform failed: %v", err)
+	}
+	if string(results[0].Data()) != "plain" {
+		t.Errorf("expected %q, got %q", "plain", string(results[0].Data()))
+	}
+}
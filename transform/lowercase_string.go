@@ -15,14 +15,7 @@ type LowercaseStringConfig struct {
 }
 
 func (c *LowercaseStringConfig) Decode(in interface{}) error {
-	if in == nil {
-		return nil
-	}
-	b, err := json.Marshal(in)
-	if err != nil {
-		return err
-	}
-	return json.Unmarshal(b, c)
+	return config.Decode(in, c)
 }
 
 func newLowercaseString(_ context.Context, cfg config.Config) (*LowercaseStringTransform, error) {
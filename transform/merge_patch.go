@@ -0,0 +1,102 @@
+package transform
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/jshlbrd/vibestation/config"
+	"github.com/jshlbrd/vibestation/message"
+)
+
+type MergePatchConfig struct {
+	Patch map[string]interface{} `json:"patch"`
+	ID    string                 `json:"id"`
+}
+
+func (c *MergePatchConfig) Decode(in interface{}) error {
+	return config.Decode(in, c)
+}
+
+func (c *MergePatchConfig) Validate() error {
+	if c.Patch == nil {
+		return fmt.Errorf("patch: missing required option")
+	}
+	return nil
+}
+
+func newMergePatch(_ context.Context, cfg config.Config) (*MergePatch, error) {
+	conf := MergePatchConfig{}
+	if err := conf.Decode(cfg.Settings); err != nil {
+		return nil, fmt.Errorf("transform merge_patch: %v", err)
+	}
+	if conf.ID == "" {
+		conf.ID = "merge_patch"
+	}
+	if err := conf.Validate(); err != nil {
+		return nil, fmt.Errorf("transform %s: %v", conf.ID, err)
+	}
+
+	tf := MergePatch{
+		conf:     conf,
+		settings: cfg.Settings,
+	}
+	return &tf, nil
+}
+
+// MergePatch applies an RFC 7386 JSON merge patch to the message data.
+// Objects are merged recursively and null values in the patch delete the
+// corresponding key.
+type MergePatch struct {
+	conf     MergePatchConfig
+	settings map[string]interface{}
+}
+
+func (tf *MergePatch) Transform(ctx context.Context, msg *message.Message) ([]*message.Message, error) {
+	if msg.IsControl() {
+		return []*message.Message{msg}, nil
+	}
+
+	var doc interface{}
+	if len(msg.Data()) > 0 {
+		if err := json.Unmarshal(msg.Data(), &doc); err != nil {
+			return nil, fmt.Errorf("transform %s: %v", tf.conf.ID, err)
+		}
+	}
+
+	b, err := json.Marshal(mergePatch(doc, tf.conf.Patch))
+	if err != nil {
+		return nil, fmt.Errorf("transform %s: %v", tf.conf.ID, err)
+	}
+	msg.SetData(b)
+
+	return []*message.Message{msg}, nil
+}
+
+func (tf *MergePatch) String() string {
+	b, _ := json.Marshal(tf.conf)
+	return string(b)
+}
+
+// mergePatch implements the MergePatch algorithm from RFC 7386.
+func mergePatch(target, patch interface{}) interface{} {
+	p, ok := patch.(map[string]interface{})
+	if !ok {
+		return patch
+	}
+
+	t, ok := target.(map[string]interface{})
+	if !ok {
+		t = make(map[string]interface{})
+	}
+
+	for k, v := range p {
+		if v == nil {
+			delete(t, k)
+			continue
+		}
+		t[k] = mergePatch(t[k], v)
+	}
+
+	return t
+}
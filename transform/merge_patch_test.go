@@ -0,0 +1,71 @@
+package transform
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jshlbrd/vibestation/config"
+	"github.com/jshlbrd/vibestation/message"
+)
+
+func TestMergePatch(t *testing.T) {
+	tests := []struct {
+		name     string
+		data     string
+		patch    map[string]interface{}
+		expected string
+	}{
+		{
+			"nested merge",
+			`{"a":"b","c":{"d":"e","f":"g"}}`,
+			map[string]interface{}{"c": map[string]interface{}{"d": "x", "h": "i"}},
+			`{"a":"b","c":{"d":"x","f":"g","h":"i"}}`,
+		},
+		{
+			"null deletes",
+			`{"a":"b","c":{"d":"e","f":"g"}}`,
+			map[string]interface{}{"a": nil, "c": map[string]interface{}{"f": nil}},
+			`{"c":{"d":"e"}}`,
+		},
+		{
+			"replace non-object",
+			`{"a":[1,2]}`,
+			map[string]interface{}{"a": map[string]interface{}{"b": "c"}},
+			`{"a":{"b":"c"}}`,
+		},
+		{
+			"empty data",
+			``,
+			map[string]interface{}{"a": 1, "b": nil},
+			`{"a":1}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cfg := config.Config{
+				Type:     "merge_patch",
+				Settings: map[string]interface{}{"patch": test.patch},
+			}
+			tf, err := newMergePatch(context.Background(), cfg)
+			if err != nil {
+				t.Fatalf("failed to create merge_patch transform: %v", err)
+			}
+
+			msg := message.New().SetData([]byte(test.data))
+			results, err := tf.Transform(context.Background(), msg)
+			if err != nil {
+				t.Fatalf("transform failed: %v", err)
+			}
+			if string(results[0].Data()) != test.expected {
+				t.Errorf("expected %s, got %s", test.expected, string(results[0].Data()))
+			}
+		})
+	}
+}
+
+func TestMergePatch_MissingPatch(t *testing.T) {
+	if _, err := newMergePatch(context.Background(), config.Config{Type: "merge_patch"}); err == nil {
+		t.Fatal("expected error for missing patch, got nil")
+	}
+}
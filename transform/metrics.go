@@ -0,0 +1,76 @@
+package transform
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"github.com/jshlbrd/vibestation/config"
+	"github.com/jshlbrd/vibestation/message"
+)
+
+type MetricsConfig struct {
+	ID string `json:"id"`
+}
+
+func (c *MetricsConfig) Decode(in interface{}) error {
+	return config.Decode(in, c)
+}
+
+func newMetrics(_ context.Context, cfg config.Config) (*Metrics, error) {
+	conf := MetricsConfig{}
+	if err := conf.Decode(cfg.Settings); err != nil {
+		return nil, fmt.Errorf("transform metrics: %v", err)
+	}
+	if conf.ID == "" {
+		conf.ID = "metrics"
+	}
+
+	tf := Metrics{
+		conf:     conf,
+		settings: cfg.Settings,
+	}
+	return &tf, nil
+}
+
+// Metrics counts the data messages and bytes that pass through it. When a
+// control message is received, it emits {"messages":N,"bytes":M} followed by
+// the control message and resets the counts. Data messages are passed
+// through unchanged.
+type Metrics struct {
+	conf     MetricsConfig
+	settings map[string]interface{}
+
+	mu       sync.Mutex
+	messages int
+	bytes    int
+}
+
+func (tf *Metrics) Transform(ctx context.Context, msg *message.Message) ([]*message.Message, error) {
+	tf.mu.Lock()
+	defer tf.mu.Unlock()
+
+	if !msg.IsControl() {
+		tf.messages++
+		tf.bytes += len(msg.Data())
+		return []*message.Message{msg}, nil
+	}
+
+	b, err := json.Marshal(map[string]int{
+		"messages": tf.messages,
+		"bytes":    tf.bytes,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("transform %s: %v", tf.conf.ID, err)
+	}
+
+	tf.messages = 0
+	tf.bytes = 0
+	return []*message.Message{message.New().SetData(b), msg}, nil
+}
+
+func (tf *Metrics) String() string {
+	b, _ := json.Marshal(tf.conf)
+	return string(b)
+}
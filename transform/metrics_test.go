@@ -0,0 +1,48 @@
+package transform
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jshlbrd/vibestation/config"
+	"github.com/jshlbrd/vibestation/message"
+)
+
+func TestMetrics(t *testing.T) {
+	tf, err := newMetrics(context.Background(), config.Config{Type: "metrics"})
+	if err != nil {
+		t.Fatalf("failed to create metrics transform: %v", err)
+	}
+
+	msgs := []*message.Message{
+		message.New().SetData([]byte("abc")),
+		message.New().SetData([]byte(`{"a":1}`)),
+		message.New().AsControl(),
+	}
+
+	results, err := Apply(context.Background(), []Transformer{tf}, msgs...)
+	if err != nil {
+		t.Fatalf("transform failed: %v", err)
+	}
+	if len(results) != 4 {
+		t.Fatalf("expected 4 results, got %d", len(results))
+	}
+
+	expected := `{"bytes":10,"messages":2}`
+	if got := string(results[2].Data()); got != expected {
+		t.Errorf("expected %s, got %s", expected, got)
+	}
+	if !results[3].IsControl() {
+		t.Error("expected control message after stats")
+	}
+
+	// The counts are reset after a flush.
+	results, err = tf.Transform(context.Background(), message.New().AsControl())
+	if err != nil {
+		t.Fatalf("transform failed: %v", err)
+	}
+	expected = `{"bytes":0,"messages":0}`
+	if got := string(results[0].Data()); got != expected {
+		t.Errorf("expected %s, got %s", expected, got)
+	}
+}
@@ -0,0 +1,166 @@
+package transform
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/jshlbrd/vibestation/config"
+	"github.com/jshlbrd/vibestation/message"
+)
+
+var (
+	defaultTruthy = []string{"true", "t", "yes", "y", "on", "1"}
+	defaultFalsy  = []string{"false", "f", "no", "n", "off", "0"}
+)
+
+type NormalizeBoolConfig struct {
+	// Truthy is the set of values that are converted to true. Matching is
+	// case-insensitive. Defaults to true, t, yes, y, on, and 1.
+	Truthy []string `json:"truthy"`
+	// Falsy is the set of values that are converted to false. Matching is
+	// case-insensitive. Defaults to false, f, no, n, off, and 0.
+	Falsy []string `json:"falsy"`
+	// Strict returns an error if the value is not in either set, otherwise
+	// the message is passed through unchanged.
+	Strict bool   `json:"strict"`
+	ID     string `json:"id"`
+}
+
+func (c *NormalizeBoolConfig) Decode(in interface{}) error {
+	return config.Decode(in, c)
+}
+
+func (c *NormalizeBoolConfig) Validate() error {
+	falsy := make(map[string]bool, len(c.Falsy))
+	for _, v := range c.Falsy {
+		falsy[strings.ToLower(v)] = true
+	}
+	for _, v := range c.Truthy {
+		if falsy[strings.ToLower(v)] {
+			return fmt.Errorf("truthy: value %q is also falsy", v)
+		}
+	}
+	return nil
+}
+
+func newNormalizeBool(_ context.Context, cfg config.Config) (*NormalizeBool, error) {
+	conf := NormalizeBoolConfig{}
+	if err := conf.Decode(cfg.Settings); err != nil {
+		return nil, fmt.Errorf("transform normalize_bool: %v", err)
+	}
+	if conf.ID == "" {
+		conf.ID = "normalize_bool"
+	}
+	if len(conf.Truthy) == 0 {
+		conf.Truthy = defaultTruthy
+	}
+	if len(conf.Falsy) == 0 {
+		conf.Falsy = defaultFalsy
+	}
+	if err := conf.Validate(); err != nil {
+		return nil, fmt.Errorf("transform %s: %v", conf.ID, err)
+	}
+
+	var sourcePath string
+	if v, ok := cfg.Settings["source"]; ok {
+		if s, ok := v.(string); ok {
+			sourcePath = s
+		}
+	}
+
+	// If no target is set, then the source is updated in place.
+	targetPath := sourcePath
+	if v, ok := cfg.Settings["target"]; ok {
+		if s, ok := v.(string); ok && s != "" {
+			targetPath = s
+		}
+	}
+
+	values := make(map[string]bool, len(conf.Truthy)+len(conf.Falsy))
+	for _, v := range conf.Truthy {
+		values[strings.ToLower(v)] = true
+	}
+	for _, v := range conf.Falsy {
+		values[strings.ToLower(v)] = false
+	}
+
+	tf := NormalizeBool{
+		conf:       conf,
+		settings:   cfg.Settings,
+		sourcePath: sourcePath,
+		targetPath: targetPath,
+		values:     values,
+	}
+	return &tf, nil
+}
+
+// NormalizeBool converts boolean-like strings, such as "yes" or "0", to JSON
+// booleans. If no source is set, then the message data is converted. Missing
+// values are skipped.
+type NormalizeBool struct {
+	conf       NormalizeBoolConfig
+	settings   map[string]interface{}
+	sourcePath string
+	targetPath string
+
+	values map[string]bool
+}
+
+func (tf *NormalizeBool) Transform(ctx context.Context, msg *message.Message) ([]*message.Message, error) {
+	if msg.IsControl() {
+		return []*message.Message{msg}, nil
+	}
+
+	var result bool
+	if tf.sourcePath != "" {
+		val := msg.GetValue(tf.sourcePath)
+		if !val.Exists() {
+			return []*message.Message{msg}, nil
+		}
+
+		// Values that are already booleans are kept as they are.
+		if b, ok := val.Value().(bool); ok {
+			result = b
+		} else if result, ok = tf.lookup(val.String()); !ok {
+			return tf.unknown(msg, val.String())
+		}
+	} else {
+		var ok bool
+		if result, ok = tf.lookup(string(msg.Data())); !ok {
+			return tf.unknown(msg, string(msg.Data()))
+		}
+	}
+
+	if tf.targetPath != "" {
+		if err := msg.SetValue(tf.targetPath, result); err != nil {
+			return nil, fmt.Errorf("transform %s: failed to set target: %v", tf.conf.ID, err)
+		}
+	} else {
+		msg.SetData([]byte(strconv.FormatBool(result)))
+	}
+
+	return []*message.Message{msg}, nil
+}
+
+// lookup returns the boolean for a value in the truthy or falsy sets.
+func (tf *NormalizeBool) lookup(s string) (bool, bool) {
+	b, ok := tf.values[strings.ToLower(strings.TrimSpace(s))]
+	return b, ok
+}
+
+// unknown handles a value that is not in either set.
+func (tf *NormalizeBool) unknown(msg *message.Message, s string) ([]*message.Message, error) {
+	if tf.conf.Strict {
+		return nil, fmt.Errorf("transform %s: unknown boolean value %q", tf.conf.ID, s)
+	}
+
+	return []*message.Message{msg}, nil
+}
+
+func (tf *NormalizeBool) String() string {
+	b, _ := json.Marshal(tf.conf)
+	return string(b)
+}
@@ -0,0 +1,155 @@
+package transform
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jshlbrd/vibestation/config"
+	"github.com/jshlbrd/vibestation/message"
+)
+
+func TestNormalizeBool(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`{"a":"yes"}`, `{"a":true}`},
+		{`{"a":"No"}`, `{"a":false}`},
+		{`{"a":"1"}`, `{"a":true}`},
+		{`{"a":"0"}`, `{"a":false}`},
+		{`{"a":1}`, `{"a":true}`},
+		{`{"a":false}`, `{"a":false}`},
+		{`{"a":"maybe"}`, `{"a":"maybe"}`},
+		{`{"b":"yes"}`, `{"b":"yes"}`},
+	}
+
+	cfg := config.Config{
+		Type:     "normalize_bool",
+		Settings: map[string]interface{}{"source": "$.a"},
+	}
+	tf, err := newNormalizeBool(context.Background(), cfg)
+	if err != nil {
+		t.Fatalf("failed to create normalize_bool transform: %v", err)
+	}
+
+	for _, tt := range tests {
+		results, err := tf.Transform(context.Background(), message.New().SetData([]byte(tt.input)))
+		if err != nil {
+			t.Fatalf("transform failed for %s: %v", tt.input, err)
+		}
+		if got := string(results[0].Data()); got != tt.expected {
+			t.Errorf("input %s: expected %s, got %s", tt.input, tt.expected, got)
+		}
+	}
+}
+
+func TestNormalizeBool_CustomSets(t *testing.T) {
+	cfg := config.Config{
+		Type: "normalize_bool",
+		Settings: map[string]interface{}{
+			"source": "$.a",
+			"target": "$.b",
+			"truthy": []interface{}{"Y"},
+			"falsy":  []interface{}{"N"},
+		},
+	}
+	tf, err := newNormalizeBool(context.Background(), cfg)
+	if err != nil {
+		t.Fatalf("failed to create normalize_bool transform: %v", err)
+	}
+
+	results, err := tf.Transform(context.Background(), message.New().SetData([]byte(`{"a":"y"}`)))
+	if err != nil {
+		t.Fatalf("transform failed: %v", err)
+	}
+	if got := string(results[0].Data()); got != `{"a":"y","b":true}` {
+		t.Errorf("expected %s, got %s", `{"a":"y","b":true}`, got)
+	}
+
+	// "yes" is not in the custom set.
+	results, err = tf.Transform(context.Background(), message.New().SetData([]byte(`{"a":"yes"}`)))
+	if err != nil {
+		t.Fatalf("transform failed: %v", err)
+	}
+	if got := string(results[0].Data()); got != `{"a":"yes"}` {
+		t.Errorf("expected data to be unchanged, got %s", got)
+	}
+}
+
+func TestNormalizeBool_Strict(t *testing.T) {
+	cfg := config.Config{
+		Type:     "normalize_bool",
+		Settings: map[string]interface{}{"source": "$.a", "strict": true},
+	}
+	tf, err := newNormalizeBool(context.Background(), cfg)
+	if err != nil {
+		t.Fatalf("failed to create normalize_bool transform: %v", err)
+	}
+
+	if _, err := tf.Transform(context.Background(), message.New().SetData([]byte(`{"a":"unknown"}`))); err == nil {
+		t.Fatal("expected error for unknown value, got nil")
+	}
+}
+
+func TestNormalizeBool_Overlap(t *testing.T) {
+	cfg := config.Config{
+		Type: "normalize_bool",
+		Settings: map[string]interface{}{
+			"truthy": []interface{}{"yes"},
+			"falsy":  []interface{}{"YES"},
+		},
+	}
+	if _, err := newNormalizeBool(context.Background(), cfg); err == nil {
+		t.Fatal("expected error for overlapping sets, got nil")
+	}
+}
+
+func TestNormalizeBool_Data(t *testing.T) {
+	tf, err := newNormalizeBool(context.Background(), config.Config{Type: "normalize_bool"})
+	if err != nil {
+		t.Fatalf("failed to create normalize_bool transform: %v", err)
+	}
+
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"yes", "true"},
+		{" N\n", "false"},
+		{"0", "false"},
+		{"maybe", "maybe"},
+	}
+	for _, tt := range tests {
+		results, err := tf.Transform(context.Background(), message.New().SetData([]byte(tt.input)))
+		if err != nil {
+			t.Fatalf("transform failed for %q: %v", tt.input, err)
+		}
+		if got := string(results[0].Data()); got != tt.expected {
+			t.Errorf("input %q: expected %q, got %q", tt.input, tt.expected, got)
+		}
+	}
+}
+
+func TestNormalizeBool_BoolToTarget(t *testing.T) {
+	cfg := config.Config{
+		Type: "normalize_bool",
+		Settings: map[string]interface{}{
+			"source": "$.a",
+			"target": "$.b",
+			"truthy": []interface{}{"Y"},
+			"falsy":  []interface{}{"N"},
+		},
+	}
+	tf, err := newNormalizeBool(context.Background(), cfg)
+	if err != nil {
+		t.Fatalf("failed to create normalize_bool transform: %v", err)
+	}
+
+	results, err := tf.Transform(context.Background(), message.New().SetData([]byte(`{"a":false}`)))
+	if err != nil {
+		t.Fatalf("transform failed: %v", err)
+	}
+	if got := string(results[0].Data()); got != `{"a":false,"b":false}` {
+		t.Errorf("expected %s, got %s", `{"a":false,"b":false}`, got)
+	}
+}
@@ -0,0 +1,95 @@
+package transform
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/jshlbrd/vibestation/config"
+	"github.com/jshlbrd/vibestation/message"
+)
+
+var whitespaceRun = regexp.MustCompile(`\s+`)
+
+type NormalizeWhitespaceConfig struct {
+	ID string `json:"id"`
+}
+
+func (c *NormalizeWhitespaceConfig) Decode(in interface{}) error {
+	return config.Decode(in, c)
+}
+
+func newNormalizeWhitespace(_ context.Context, cfg config.Config) (*NormalizeWhitespace, error) {
+	conf := NormalizeWhitespaceConfig{}
+	if err := conf.Decode(cfg.Settings); err != nil {
+		return nil, fmt.Errorf("transform normalize_whitespace: %v", err)
+	}
+	if conf.ID == "" {
+		conf.ID = "normalize_whitespace"
+	}
+	var sourcePath string
+	if v, ok := cfg.Settings["source"]; ok {
+		if s, ok := v.(string); ok {
+			sourcePath = s
+		}
+	}
+	var targetPath string
+	if v, ok := cfg.Settings["target"]; ok {
+		if s, ok := v.(string); ok {
+			targetPath = s
+		}
+	}
+
+	tf := NormalizeWhitespace{
+		conf:       conf,
+		settings:   cfg.Settings,
+		sourcePath: sourcePath,
+		targetPath: targetPath,
+	}
+	return &tf, nil
+}
+
+// NormalizeWhitespace collapses runs of whitespace into a single space and
+// trims leading and trailing whitespace.
+type NormalizeWhitespace struct {
+	conf       NormalizeWhitespaceConfig
+	settings   map[string]interface{}
+	sourcePath string
+	targetPath string
+}
+
+func (tf *NormalizeWhitespace) Transform(ctx context.Context, msg *message.Message) ([]*message.Message, error) {
+	if msg.IsControl() {
+		return []*message.Message{msg}, nil
+	}
+
+	var inputData []byte
+	if tf.sourcePath != "" {
+		val := msg.GetValue(tf.sourcePath)
+		if val.Exists() {
+			inputData = val.Bytes()
+		}
+	}
+	if inputData == nil {
+		inputData = msg.Data()
+	}
+
+	normalized := strings.TrimSpace(whitespaceRun.ReplaceAllString(string(inputData), " "))
+
+	if tf.targetPath != "" {
+		if err := msg.SetValue(tf.targetPath, normalized); err != nil {
+			return nil, fmt.Errorf("transform %s: failed to set target: %v", tf.conf.ID, err)
+		}
+	} else {
+		msg.SetData([]byte(normalized))
+	}
+
+	return []*message.Message{msg}, nil
+}
+
+func (tf *NormalizeWhitespace) String() string {
+	b, _ := json.Marshal(tf.conf)
+	return string(b)
+}
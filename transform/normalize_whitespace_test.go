@@ -0,0 +1,62 @@
+package transform
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jshlbrd/vibestation/config"
+	"github.com/jshlbrd/vibestation/message"
+)
+
+func TestNormalizeWhitespace(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{"tabs", "a\t\tb\tc", "a b c"},
+		{"multiple spaces", "  hello    world  ", "hello world"},
+		{"newlines", "line one\n\n  line two\r\n", "line one line two"},
+		{"empty", "   ", ""},
+	}
+
+	tf, err := newNormalizeWhitespace(context.Background(), config.Config{Type: "normalize_whitespace"})
+	if err != nil {
+		t.Fatalf("failed to create normalize_whitespace transform: %v", err)
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			results, err := tf.Transform(context.Background(), message.New().SetData([]byte(tt.input)))
+			if err != nil {
+				t.Fatalf("transform failed: %v", err)
+			}
+			if got := string(results[0].Data()); got != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestNormalizeWhitespace_WithTarget(t *testing.T) {
+	cfg := config.Config{
+		Type: "normalize_whitespace",
+		Settings: map[string]interface{}{
+			"source": "$.text",
+			"target": "$.clean",
+		},
+	}
+	tf, err := newNormalizeWhitespace(context.Background(), cfg)
+	if err != nil {
+		t.Fatalf("failed to create normalize_whitespace transform: %v", err)
+	}
+
+	msg := message.New().SetData([]byte(`{"text":"\ta  b\t"}`))
+	results, err := tf.Transform(context.Background(), msg)
+	if err != nil {
+		t.Fatalf("transform failed: %v", err)
+	}
+	if got := results[0].GetValue("$.clean").String(); got != "a b" {
+		t.Errorf("expected %q, got %q", "a b", got)
+	}
+}
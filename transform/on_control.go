@@ -0,0 +1,96 @@
+package transform
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/jshlbrd/vibestation/config"
+	"github.com/jshlbrd/vibestation/message"
+)
+
+type OnControlConfig struct {
+	// Transforms is the sub-pipeline that is applied when a control message
+	// is received.
+	Transforms []config.Config `json:"transforms"`
+	ID         string          `json:"id"`
+}
+
+func (c *OnControlConfig) Decode(in interface{}) error {
+	return config.Decode(in, c)
+}
+
+func (c *OnControlConfig) Validate() error {
+	if len(c.Transforms) == 0 {
+		return fmt.Errorf("transforms: missing required option")
+	}
+	return nil
+}
+
+func newOnControl(ctx context.Context, cfg config.Config) (*OnControl, error) {
+	conf := OnControlConfig{}
+	if err := conf.Decode(cfg.Settings); err != nil {
+		return nil, fmt.Errorf("transform on_control: %v", err)
+	}
+	if conf.ID == "" {
+		conf.ID = "on_control"
+	}
+	if err := conf.Validate(); err != nil {
+		return nil, fmt.Errorf("transform %s: %v", conf.ID, err)
+	}
+
+	var tforms []Transformer
+	for _, c := range conf.Transforms {
+		t, err := New(ctx, c)
+		if err != nil {
+			return nil, fmt.Errorf("transform %s: %v", conf.ID, err)
+		}
+		tforms = append(tforms, t)
+	}
+
+	tf := OnControl{
+		conf:     conf,
+		settings: cfg.Settings,
+		tforms:   tforms,
+	}
+	return &tf, nil
+}
+
+// OnControl applies a sub-pipeline only when a control message is received.
+// Data messages pass through untouched.
+//
+// Control messages cannot carry data, so the sub-pipeline receives an empty
+// data message in its place. The metadata of the control message is copied to
+// that message. The results of the sub-pipeline are returned
+// followed by the original control message, so downstream transforms still
+// observe the end of the stream.
+type OnControl struct {
+	conf     OnControlConfig
+	settings map[string]interface{}
+	tforms   []Transformer
+}
+
+func (tf *OnControl) Transform(ctx context.Context, msg *message.Message) ([]*message.Message, error) {
+	if !msg.IsControl() {
+		return []*message.Message{msg}, nil
+	}
+
+	sub := message.New()
+	if meta := msg.GetValue("meta.$"); meta.Exists() {
+		if err := sub.SetValue("meta.$", meta.Value()); err != nil {
+			return nil, fmt.Errorf("transform %s: %v", tf.conf.ID, err)
+		}
+	}
+
+	msgs, err := Apply(ctx, tf.tforms, sub)
+	if err != nil {
+		return nil, fmt.Errorf("transform %s: %v", tf.conf.ID, err)
+	}
+
+	return append(msgs, msg), nil
+}
+
+func (tf *OnControl) String() string {
+	b, _ := json.Marshal(tf.conf)
+	return string(b)
+}
@@ -0,0 +1,114 @@
+package transform
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jshlbrd/vibestation/config"
+	"github.com/jshlbrd/vibestation/message"
+)
+
+var onControlTestConfig = config.Config{
+	Type: "on_control",
+	Settings: map[string]interface{}{
+		"transforms": []interface{}{
+			map[string]interface{}{
+				"type": "lowercase_string",
+				"settings": map[string]interface{}{
+					"target": "$.summary",
+				},
+			},
+		},
+	},
+}
+
+func TestOnControl_DataMessage(t *testing.T) {
+	tf, err := newOnControl(context.Background(), onControlTestConfig)
+	if err != nil {
+		t.Fatalf("failed to create on_control transform: %v", err)
+	}
+
+	msg := message.New().SetData([]byte(`{"a":"B"}`))
+	results, err := tf.Transform(context.Background(), msg)
+	if err != nil {
+		t.Fatalf("transform failed: %v", err)
+	}
+	if len(results) != 1 {
+		t.Fatalf("expected 1 result, got %d", len(results))
+	}
+	if string(results[0].Data()) != `{"a":"B"}` {
+		t.Errorf("expected data message to be unchanged, got %s", string(results[0].Data()))
+	}
+}
+
+func TestOnControl_ControlMessage(t *testing.T) {
+	tf, err := newOnControl(context.Background(), onControlTestConfig)
+	if err != nil {
+		t.Fatalf("failed to create on_control transform: %v", err)
+	}
+
+	ctrl := message.New().AsControl()
+	results, err := tf.Transform(context.Background(), ctrl)
+	if err != nil {
+		t.Fatalf("transform failed: %v", err)
+	}
+	if len(results) != 2 {
+		t.Fatalf("expected 2 results, got %d", len(results))
+	}
+
+	if results[0].IsControl() {
+		t.Error("expected sub-pipeline result to be a data message")
+	}
+	if !results[0].GetValue("$.summary").Exists() {
+		t.Errorf("expected sub-pipeline to set $.summary, got %s", string(results[0].Data()))
+	}
+	if !results[1].IsControl() {
+		t.Error("expected control message to be returned after sub-pipeline results")
+	}
+}
+
+func TestOnControl_MissingTransforms(t *testing.T) {
+	if _, err := newOnControl(context.Background(), config.Config{Type: "on_control"}); err == nil {
+		t.Fatal("expected error for missing transforms, got nil")
+	}
+}
+
+func TestOnControl_Metadata(t *testing.T) {
+	cfg := config.Config{
+		Type: "on_control",
+		Settings: map[string]interface{}{
+			"transforms": []interface{}{
+				map[string]interface{}{
+					"type": "assign",
+					"settings": map[string]interface{}{
+						"source": "meta.$.batch",
+						"target": "$.batch",
+					},
+				},
+			},
+		},
+	}
+	tf, err := newOnControl(context.Background(), cfg)
+	if err != nil {
+		t.Fatalf("failed to create on_control transform: %v", err)
+	}
+
+	ctrl := message.New().AsControl()
+	if err := ctrl.SetValue("meta.$.batch", "b1"); err != nil {
+		t.Fatalf("failed to set metadata: %v", err)
+	}
+
+	results, err := tf.Transform(context.Background(), ctrl)
+	if err != nil {
+		t.Fatalf("transform failed: %v", err)
+	}
+	if len(results) != 2 {
+		t.Fatalf("expected 2 results, got %d", len(results))
+	}
+	if got := results[0].GetValue("meta.$.batch").String(); got != "b1" {
+		t.Errorf("expected metadata to be copied, got %q", got)
+	}
+	if got := string(results[0].Data()); got != `{"batch":"b1"}` {
+		t.Errorf("expected %s, got %s", `{"batch":"b1"}`, got)
+	}
+}
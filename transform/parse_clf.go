@@ -0,0 +1,134 @@
+package transform
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/jshlbrd/vibestation/config"
+	"github.com/jshlbrd/vibestation/message"
+)
+
+type ParseCLFConfig struct {
+	// Strict returns an error if the source is not a valid log line,
+	// otherwise the message is passed through unchanged.
+	Strict bool   `json:"strict"`
+	ID     string `json:"id"`
+}
+
+func (c *ParseCLFConfig) Decode(in interface{}) error {
+	return config.Decode(in, c)
+}
+
+func newParseCLF(_ context.Context, cfg config.Config) (*ParseCLF, error) {
+	conf := ParseCLFConfig{}
+	if err := conf.Decode(cfg.Settings); err != nil {
+		return nil, fmt.Errorf("transform parse_clf: %v", err)
+	}
+	if conf.ID == "" {
+		conf.ID = "parse_clf"
+	}
+
+	var sourcePath string
+	if v, ok := cfg.Settings["source"]; ok {
+		if s, ok := v.(string); ok {
+			sourcePath = s
+		}
+	}
+
+	var targetPath string
+	if v, ok := cfg.Settings["target"]; ok {
+		if s, ok := v.(string); ok {
+			targetPath = s
+		}
+	}
+
+	tf := ParseCLF{
+		conf:       conf,
+		settings:   cfg.Settings,
+		sourcePath: sourcePath,
+		targetPath: targetPath,
+	}
+	return &tf, nil
+}
+
+// clfPattern matches the Common Log Format, optionally followed by the
+// referer and user agent fields of the Combined Log Format.
+var clfPattern = regexp.MustCompile(`^(\S+) \S+ \S+ \[([^\]]+)\] "([^"]*)" (\d{3}) (\d+|-)( "([^"]*)" "([^"]*)")?$`)
+
+// ParseCLF parses a Common or Combined Log Format line into a JSON object
+// containing remote_addr, time, request, status, and bytes. Combined format
+// lines also include referer and user_agent. A bytes value of "-" is
+// written as 0.
+type ParseCLF struct {
+	conf       ParseCLFConfig
+	settings   map[string]interface{}
+	sourcePath string
+	targetPath string
+}
+
+func (tf *ParseCLF) Transform(ctx context.Context, msg *message.Message) ([]*message.Message, error) {
+	if msg.IsControl() {
+		return []*message.Message{msg}, nil
+	}
+
+	var inputData []byte
+	if tf.sourcePath != "" {
+		val := msg.GetValue(tf.sourcePath)
+		if val.Exists() {
+			inputData = val.Bytes()
+		}
+	}
+	if inputData == nil {
+		inputData = msg.Data()
+	}
+
+	m := clfPattern.FindStringSubmatch(strings.TrimSpace(string(inputData)))
+	if m == nil {
+		if tf.conf.Strict {
+			return nil, fmt.Errorf("transform %s: malformed log line", tf.conf.ID)
+		}
+		return []*message.Message{msg}, nil
+	}
+
+	// The pattern guarantees that status is numeric.
+	status, _ := strconv.Atoi(m[4])
+	var bytes int
+	if m[5] != "-" {
+		bytes, _ = strconv.Atoi(m[5])
+	}
+
+	result := map[string]interface{}{
+		"remote_addr": m[1],
+		"time":        m[2],
+		"request":     m[3],
+		"status":      status,
+		"bytes":       bytes,
+	}
+	if m[6] != "" {
+		result["referer"] = m[7]
+		result["user_agent"] = m[8]
+	}
+
+	if tf.targetPath != "" {
+		if err := msg.SetValue(tf.targetPath, result); err != nil {
+			return nil, fmt.Errorf("transform %s: failed to set target: %v", tf.conf.ID, err)
+		}
+	} else {
+		b, err := json.Marshal(result)
+		if err != nil {
+			return nil, fmt.Errorf("transform %s: %v", tf.conf.ID, err)
+		}
+		msg.SetData(b)
+	}
+
+	return []*message.Message{msg}, nil
+}
+
+func (tf *ParseCLF) String() string {
+	b, _ := json.Marshal(tf.conf)
+	return string(b)
+}
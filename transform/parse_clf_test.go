@@ -0,0 +1,77 @@
+package transform
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jshlbrd/vibestation/config"
+	"github.com/jshlbrd/vibestation/message"
+)
+
+func TestParseCLF(t *testing.T) {
+	tests := []struct {
+		name     string
+		line     string
+		expected string
+	}{
+		{
+			"combined",
+			`127.0.0.1 - frank [10/Oct/2000:13:55:36 -0700] "GET /apache_pb.gif HTTP/1.0" 200 2326 "http://www.example.com/start.html" "Mozilla/4.08"`,
+			`{"bytes":2326,"referer":"http://www.example.com/start.html","remote_addr":"127.0.0.1","request":"GET /apache_pb.gif HTTP/1.0","status":200,"time":"10/Oct/2000:13:55:36 -0700","user_agent":"Mozilla/4.08"}`,
+		},
+		{
+			"common",
+			`10.0.0.1 - - [10/Oct/2000:13:55:36 -0700] "POST /login HTTP/1.1" 302 -`,
+			`{"bytes":0,"remote_addr":"10.0.0.1","request":"POST /login HTTP/1.1","status":302,"time":"10/Oct/2000:13:55:36 -0700"}`,
+		},
+	}
+
+	tf, err := newParseCLF(context.Background(), config.Config{Type: "parse_clf"})
+	if err != nil {
+		t.Fatalf("failed to create parse_clf transform: %v", err)
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			msg := message.New().SetData([]byte(test.line))
+			results, err := tf.Transform(context.Background(), msg)
+			if err != nil {
+				t.Fatalf("transform failed: %v", err)
+			}
+
+			if got := string(results[0].Data()); got != test.expected {
+				t.Errorf("expected %s, got %s", test.expected, got)
+			}
+		})
+	}
+}
+
+func TestParseCLF_Malformed(t *testing.T) {
+	tests := []struct {
+		strict  bool
+		wantErr bool
+	}{
+		{false, false},
+		{true, true},
+	}
+
+	for _, test := range tests {
+		cfg := config.Config{
+			Type:     "parse_clf",
+			Settings: map[string]interface{}{"strict": test.strict},
+		}
+		tf, err := newParseCLF(context.Background(), cfg)
+		if err != nil {
+			t.Fatalf("failed to create parse_clf transform: %v", err)
+		}
+
+		msg := message.New().SetData([]byte("not a log line"))
+		results, err := tf.Transform(context.Background(), msg)
+		if (err != nil) != test.wantErr {
+			t.Fatalf("strict %v: expected error %v, got %v", test.strict, test.wantErr, err)
+		}
+		if err == nil && string(results[0].Data()) != "not a log line" {
+			t.Errorf("expected message to pass through unchanged, got %s", string(results[0].Data()))
+		}
+	}
+}
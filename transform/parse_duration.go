@@ -0,0 +1,105 @@
+package transform
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jshlbrd/vibestation/config"
+	"github.com/jshlbrd/vibestation/message"
+)
+
+type ParseDurationConfig struct {
+	// Strict returns an error if the source is not a valid duration,
+	// otherwise the message is passed through unchanged.
+	Strict bool   `json:"strict"`
+	ID     string `json:"id"`
+}
+
+func (c *ParseDurationConfig) Decode(in interface{}) error {
+	return config.Decode(in, c)
+}
+
+func newParseDuration(_ context.Context, cfg config.Config) (*ParseDuration, error) {
+	conf := ParseDurationConfig{}
+	if err := conf.Decode(cfg.Settings); err != nil {
+		return nil, fmt.Errorf("transform parse_duration: %v", err)
+	}
+	if conf.ID == "" {
+		conf.ID = "parse_duration"
+	}
+
+	var sourcePath string
+	if v, ok := cfg.Settings["source"]; ok {
+		if s, ok := v.(string); ok {
+			sourcePath = s
+		}
+	}
+
+	var targetPath string
+	if v, ok := cfg.Settings["target"]; ok {
+		if s, ok := v.(string); ok {
+			targetPath = s
+		}
+	}
+
+	tf := ParseDuration{
+		conf:       conf,
+		settings:   cfg.Settings,
+		sourcePath: sourcePath,
+		targetPath: targetPath,
+	}
+	return &tf, nil
+}
+
+// ParseDuration converts a Go duration string (e.g. "1.5s", "200ms") into
+// a number of seconds.
+type ParseDuration struct {
+	conf       ParseDurationConfig
+	settings   map[string]interface{}
+	sourcePath string
+	targetPath string
+}
+
+func (tf *ParseDuration) Transform(ctx context.Context, msg *message.Message) ([]*message.Message, error) {
+	if msg.IsControl() {
+		return []*message.Message{msg}, nil
+	}
+
+	var inputData []byte
+	if tf.sourcePath != "" {
+		val := msg.GetValue(tf.sourcePath)
+		if val.Exists() {
+			inputData = val.Bytes()
+		}
+	}
+	if inputData == nil {
+		inputData = msg.Data()
+	}
+
+	d, err := time.ParseDuration(strings.TrimSpace(string(inputData)))
+	if err != nil {
+		if tf.conf.Strict {
+			return nil, fmt.Errorf("transform %s: %v", tf.conf.ID, err)
+		}
+		return []*message.Message{msg}, nil
+	}
+
+	if tf.targetPath != "" {
+		if err := msg.SetValue(tf.targetPath, d.Seconds()); err != nil {
+			return nil, fmt.Errorf("transform %s: failed to set target: %v", tf.conf.ID, err)
+		}
+	} else {
+		msg.SetData([]byte(strconv.FormatFloat(d.Seconds(), 'f', -1, 64)))
+	}
+
+	return []*message.Message{msg}, nil
+}
+
+func (tf *ParseDuration) String() string {
+	b, _ := json.Marshal(tf.conf)
+	return string(b)
+}
@@ -0,0 +1,77 @@
+package transform
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jshlbrd/vibestation/config"
+	"github.com/jshlbrd/vibestation/message"
+)
+
+func TestParseDuration(t *testing.T) {
+	tests := []struct {
+		duration string
+		expected float64
+	}{
+		{"200ms", 0.2},
+		{"1.5s", 1.5},
+		{"2m", 120},
+		{"1m30s", 90},
+	}
+
+	cfg := config.Config{
+		Type: "parse_duration",
+		Settings: map[string]interface{}{
+			"source": "$.latency",
+			"target": "$.latency_seconds",
+		},
+	}
+	tf, err := newParseDuration(context.Background(), cfg)
+	if err != nil {
+		t.Fatalf("failed to create parse_duration transform: %v", err)
+	}
+
+	for _, test := range tests {
+		t.Run(test.duration, func(t *testing.T) {
+			msg := message.New().SetData([]byte(`{"latency":"` + test.duration + `"}`))
+			results, err := tf.Transform(context.Background(), msg)
+			if err != nil {
+				t.Fatalf("transform failed: %v", err)
+			}
+
+			if got := results[0].GetValue("$.latency_seconds").Float(); got != test.expected {
+				t.Errorf("expected %v, got %v", test.expected, got)
+			}
+		})
+	}
+}
+
+func TestParseDuration_Malformed(t *testing.T) {
+	tests := []struct {
+		strict  bool
+		wantErr bool
+	}{
+		{false, false},
+		{true, true},
+	}
+
+	for _, test := range tests {
+		cfg := config.Config{
+			Type:     "parse_duration",
+			Settings: map[string]interface{}{"strict": test.strict},
+		}
+		tf, err := newParseDuration(context.Background(), cfg)
+		if err != nil {
+			t.Fatalf("failed to create parse_duration transform: %v", err)
+		}
+
+		msg := message.New().SetData([]byte("fast"))
+		results, err := tf.Transform(context.Background(), msg)
+		if (err != nil) != test.wantErr {
+			t.Fatalf("strict %v: expected error %v, got %v", test.strict, test.wantErr, err)
+		}
+		if err == nil && string(results[0].Data()) != "fast" {
+			t.Errorf("expected message to pass through unchanged, got %s", string(results[0].Data()))
+		}
+	}
+}
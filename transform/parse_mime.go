@@ -0,0 +1,114 @@
+package transform
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"strings"
+
+	"github.com/jshlbrd/vibestation/config"
+	"github.com/jshlbrd/vibestation/message"
+)
+
+const (
+	// contentTypeKey is the metadata path that parse_mime reads the boundary
+	// from when no boundary is configured.
+	contentTypeKey = "meta.$.content_type"
+	// mimeHeadersKey is the metadata path where parse_mime writes the headers
+	// of each part.
+	mimeHeadersKey = "meta.$.headers"
+)
+
+type ParseMIMEConfig struct {
+	// Boundary is the multipart boundary. If not set, then it is read from
+	// the Content-Type stored in meta.$.content_type.
+	Boundary string `json:"boundary"`
+	ID       string `json:"id"`
+}
+
+func (c *ParseMIMEConfig) Decode(in interface{}) error {
+	return config.Decode(in, c)
+}
+
+func newParseMIME(_ context.Context, cfg config.Config) (*ParseMIME, error) {
+	conf := ParseMIMEConfig{}
+	if err := conf.Decode(cfg.Settings); err != nil {
+		return nil, fmt.Errorf("transform parse_mime: %v", err)
+	}
+	if conf.ID == "" {
+		conf.ID = "parse_mime"
+	}
+
+	tf := ParseMIME{
+		conf:     conf,
+		settings: cfg.Settings,
+	}
+	return &tf, nil
+}
+
+// ParseMIME splits a multipart MIME body into one message per part. The
+// part body is written to the message data and the part headers are written
+// to metadata at mimeHeadersKey. Headers with several values are joined
+// with ", ".
+type ParseMIME struct {
+	conf     ParseMIMEConfig
+	settings map[string]interface{}
+}
+
+func (tf *ParseMIME) Transform(ctx context.Context, msg *message.Message) ([]*message.Message, error) {
+	if msg.IsControl() {
+		return []*message.Message{msg}, nil
+	}
+
+	boundary := tf.conf.Boundary
+	if boundary == "" {
+		_, params, err := mime.ParseMediaType(msg.GetValue(contentTypeKey).String())
+		if err != nil {
+			return nil, fmt.Errorf("transform %s: content type: %v", tf.conf.ID, err)
+		}
+		boundary = params["boundary"]
+	}
+	if boundary == "" {
+		return nil, fmt.Errorf("transform %s: missing boundary", tf.conf.ID)
+	}
+
+	var result []*message.Message
+	reader := multipart.NewReader(bytes.NewReader(msg.Data()), boundary)
+	for {
+		part, err := reader.NextRawPart()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("transform %s: %v", tf.conf.ID, err)
+		}
+
+		body, err := io.ReadAll(part)
+		if err != nil {
+			return nil, fmt.Errorf("transform %s: %v", tf.conf.ID, err)
+		}
+
+		headers := make(map[string]interface{}, len(part.Header))
+		for k, v := range part.Header {
+			headers[k] = strings.Join(v, ", ")
+		}
+
+		newMsg := message.New().SetData(body)
+		if err := newMsg.SetValue(mimeHeadersKey, headers); err != nil {
+			return nil, fmt.Errorf("transform %s: %v", tf.conf.ID, err)
+		}
+		result = append(result, newMsg)
+	}
+
+	return result, nil
+}
+
+func (tf *ParseMIME) String() string {
+	b, _ := json.Marshal(tf.conf)
+	return string(b)
+}
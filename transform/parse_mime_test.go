@@ -0,0 +1,86 @@
+package transform
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/jshlbrd/vibestation/config"
+	"github.com/jshlbrd/vibestation/message"
+)
+
+var testMultipartBody = strings.ReplaceAll(`--XYZ
+Content-Disposition: form-data; name="event"
+Content-Type: application/json
+
+{"a":1}
+--XYZ
+Content-Disposition: form-data; name="note"
+
+hello world
+--XYZ--
+`, "\n", "\r\n")
+
+func TestParseMIME(t *testing.T) {
+	cfg := config.Config{
+		Type:     "parse_mime",
+		Settings: map[string]interface{}{"boundary": "XYZ"},
+	}
+	tf, err := newParseMIME(context.Background(), cfg)
+	if err != nil {
+		t.Fatalf("failed to create parse_mime transform: %v", err)
+	}
+
+	results, err := tf.Transform(context.Background(), message.New().SetData([]byte(testMultipartBody)))
+	if err != nil {
+		t.Fatalf("transform failed: %v", err)
+	}
+	if len(results) != 2 {
+		t.Fatalf("expected 2 messages, got %d", len(results))
+	}
+
+	if got := string(results[0].Data()); got != `{"a":1}` {
+		t.Errorf("expected part 0 data %q, got %q", `{"a":1}`, got)
+	}
+	if got := results[0].GetValue("meta.$.headers.Content-Type").String(); got != "application/json" {
+		t.Errorf("expected part 0 content type %q, got %q", "application/json", got)
+	}
+
+	if got := string(results[1].Data()); got != "hello world" {
+		t.Errorf("expected part 1 data %q, got %q", "hello world", got)
+	}
+	if got := results[1].GetValue("meta.$.headers.Content-Disposition").String(); got != `form-data; name="note"` {
+		t.Errorf("expected part 1 disposition %q, got %q", `form-data; name="note"`, got)
+	}
+}
+
+func TestParseMIME_ContentTypeBoundary(t *testing.T) {
+	tf, err := newParseMIME(context.Background(), config.Config{Type: "parse_mime"})
+	if err != nil {
+		t.Fatalf("failed to create parse_mime transform: %v", err)
+	}
+
+	msg := message.New().SetData([]byte(testMultipartBody))
+	if err := msg.SetValue("meta.$.content_type", "multipart/form-data; boundary=XYZ"); err != nil {
+		t.Fatalf("failed to set content type: %v", err)
+	}
+
+	results, err := tf.Transform(context.Background(), msg)
+	if err != nil {
+		t.Fatalf("transform failed: %v", err)
+	}
+	if len(results) != 2 {
+		t.Fatalf("expected 2 messages, got %d", len(results))
+	}
+}
+
+func TestParseMIME_MissingBoundary(t *testing.T) {
+	tf, err := newParseMIME(context.Background(), config.Config{Type: "parse_mime"})
+	if err != nil {
+		t.Fatalf("failed to create parse_mime transform: %v", err)
+	}
+
+	if _, err := tf.Transform(context.Background(), message.New().SetData([]byte(testMultipartBody))); err == nil {
+		t.Fatal("expected error for missing boundary, got nil")
+	}
+}
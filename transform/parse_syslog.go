@@ -0,0 +1,155 @@
+package transform
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/jshlbrd/vibestation/config"
+	"github.com/jshlbrd/vibestation/message"
+)
+
+type ParseSyslogConfig struct {
+	// Format is the syslog format, either rfc3164 or rfc5424. Defaults to
+	// rfc5424.
+	Format string `json:"format"`
+	ID     string `json:"id"`
+}
+
+func (c *ParseSyslogConfig) Decode(in interface{}) error {
+	return config.Decode(in, c)
+}
+
+func (c *ParseSyslogConfig) Validate() error {
+	switch c.Format {
+	case "rfc3164", "rfc5424":
+	default:
+		return fmt.Errorf("format: unsupported format %q", c.Format)
+	}
+	return nil
+}
+
+func newParseSyslog(_ context.Context, cfg config.Config) (*ParseSyslog, error) {
+	conf := ParseSyslogConfig{}
+	if err := conf.Decode(cfg.Settings); err != nil {
+		return nil, fmt.Errorf("transform parse_syslog: %v", err)
+	}
+	if conf.ID == "" {
+		conf.ID = "parse_syslog"
+	}
+	if conf.Format == "" {
+		conf.Format = "rfc5424"
+	}
+	if err := conf.Validate(); err != nil {
+		return nil, fmt.Errorf("transform %s: %v", conf.ID, err)
+	}
+
+	var sourcePath string
+	if v, ok := cfg.Settings["source"]; ok {
+		if s, ok := v.(string); ok {
+			sourcePath = s
+		}
+	}
+
+	var targetPath string
+	if v, ok := cfg.Settings["target"]; ok {
+		if s, ok := v.(string); ok {
+			targetPath = s
+		}
+	}
+
+	tf := ParseSyslog{
+		conf:       conf,
+		settings:   cfg.Settings,
+		sourcePath: sourcePath,
+		targetPath: targetPath,
+	}
+	return &tf, nil
+}
+
+var (
+	// rfc3164Pattern matches "<PRI>Mmm dd hh:mm:ss HOST APP[PID]: MSG".
+	rfc3164Pattern = regexp.MustCompile(`^<(\d{1,3})>([A-Z][a-z]{2} [ \d]\d \d{2}:\d{2}:\d{2}) (\S+) ([^\s\[:]+)(?:\[[^\]]*\])?: ?(.*)$`)
+	// rfc5424Pattern matches "<PRI>VERSION TIMESTAMP HOST APP PROCID MSGID SD MSG".
+	rfc5424Pattern = regexp.MustCompile(`^<(\d{1,3})>\d{1,2} (\S+) (\S+) (\S+) \S+ \S+ (?:-|(?:\[[^\]]*\])+)(?: (.*))?$`)
+)
+
+// ParseSyslog parses an RFC 3164 or RFC 5424 syslog line into a JSON object
+// containing priority, timestamp, host, app, and message. RFC 5424 nil
+// values ("-") are written as empty strings. Malformed lines return an
+// error.
+type ParseSyslog struct {
+	conf       ParseSyslogConfig
+	settings   map[string]interface{}
+	sourcePath string
+	targetPath string
+}
+
+func (tf *ParseSyslog) Transform(ctx context.Context, msg *message.Message) ([]*message.Message, error) {
+	if msg.IsControl() {
+		return []*message.Message{msg}, nil
+	}
+
+	var inputData []byte
+	if tf.sourcePath != "" {
+		val := msg.GetValue(tf.sourcePath)
+		if val.Exists() {
+			inputData = val.Bytes()
+		}
+	}
+	if inputData == nil {
+		inputData = msg.Data()
+	}
+
+	line := strings.TrimSpace(string(inputData))
+
+	pattern := rfc5424Pattern
+	if tf.conf.Format == "rfc3164" {
+		pattern = rfc3164Pattern
+	}
+
+	m := pattern.FindStringSubmatch(line)
+	if m == nil {
+		return nil, fmt.Errorf("transform %s: malformed %s line", tf.conf.ID, tf.conf.Format)
+	}
+
+	// The patterns guarantee that priority is numeric.
+	priority, _ := strconv.Atoi(m[1])
+	fields := make([]string, 4)
+	for i, f := range m[2:] {
+		if f == "-" {
+			f = ""
+		}
+		fields[i] = f
+	}
+
+	result := map[string]interface{}{
+		"priority":  priority,
+		"timestamp": fields[0],
+		"host":      fields[1],
+		"app":       fields[2],
+		"message":   fields[3],
+	}
+
+	if tf.targetPath != "" {
+		if err := msg.SetValue(tf.targetPath, result); err != nil {
+			return nil, fmt.Errorf("transform %s: failed to set target: %v", tf.conf.ID, err)
+		}
+	} else {
+		b, err := json.Marshal(result)
+		if err != nil {
+			return nil, fmt.Errorf("transform %s: %v", tf.conf.ID, err)
+		}
+		msg.SetData(b)
+	}
+
+	return []*message.Message{msg}, nil
+}
+
+func (tf *ParseSyslog) String() string {
+	b, _ := json.Marshal(tf.conf)
+	return string(b)
+}
@@ -0,0 +1,73 @@
+package transform
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jshlbrd/vibestation/config"
+	"github.com/jshlbrd/vibestation/message"
+)
+
+func TestParseSyslog(t *testing.T) {
+	tests := []struct {
+		format   string
+		line     string
+		expected string
+	}{
+		{
+			"rfc3164",
+			`<34>Oct 11 22:14:15 mymachine su[123]: 'su root' failed for lonvick on /dev/pts/8`,
+			`{"app":"su","host":"mymachine","message":"'su root' failed for lonvick on /dev/pts/8","priority":34,"timestamp":"Oct 11 22:14:15"}`,
+		},
+		{
+			"rfc5424",
+			`<165>1 2003-10-11T22:14:15.003Z mymachine.example.com evntslog - ID47 [exampleSDID@32473 iut="3"] An application event`,
+			`{"app":"evntslog","host":"mymachine.example.com","message":"An application event","priority":165,"timestamp":"2003-10-11T22:14:15.003Z"}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.format, func(t *testing.T) {
+			cfg := config.Config{
+				Type:     "parse_syslog",
+				Settings: map[string]interface{}{"format": test.format},
+			}
+			tf, err := newParseSyslog(context.Background(), cfg)
+			if err != nil {
+				t.Fatalf("failed to create parse_syslog transform: %v", err)
+			}
+
+			msg := message.New().SetData([]byte(test.line))
+			results, err := tf.Transform(context.Background(), msg)
+			if err != nil {
+				t.Fatalf("transform failed: %v", err)
+			}
+
+			if got := string(results[0].Data()); got != test.expected {
+				t.Errorf("expected %s, got %s", test.expected, got)
+			}
+		})
+	}
+}
+
+func TestParseSyslog_Malformed(t *testing.T) {
+	tf, err := newParseSyslog(context.Background(), config.Config{Type: "parse_syslog"})
+	if err != nil {
+		t.Fatalf("failed to create parse_syslog transform: %v", err)
+	}
+
+	msg := message.New().SetData([]byte("Oct 11 22:14:15 no priority"))
+	if _, err := tf.Transform(context.Background(), msg); err == nil {
+		t.Fatal("expected error for malformed line, got nil")
+	}
+}
+
+func TestParseSyslog_InvalidFormat(t *testing.T) {
+	cfg := config.Config{
+		Type:     "parse_syslog",
+		Settings: map[string]interface{}{"format": "rfc9999"},
+	}
+	if _, err := newParseSyslog(context.Background(), cfg); err == nil {
+		t.Fatal("expected error for unsupported format, got nil")
+	}
+}
@@ -0,0 +1,168 @@
+package transform
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jshlbrd/vibestation/config"
+	"github.com/jshlbrd/vibestation/message"
+)
+
+// timeFormatKey is the metadata path where parse_time_auto records the
+// layout that matched.
+const timeFormatKey = "meta.$._time_format"
+
+// unixLayout is the special layout name for Unix epoch seconds.
+const unixLayout = "unix"
+
+var defaultTimeLayouts = []string{
+	time.RFC3339,
+	time.RFC3339Nano,
+	time.RFC1123Z,
+	time.RFC1123,
+	time.DateTime,
+	unixLayout,
+}
+
+type ParseTimeAutoConfig struct {
+	// Layouts is the ordered list of Go time layouts that are tried. The
+	// special layout "unix" matches Unix epoch seconds. Defaults to RFC 3339,
+	// RFC 1123, "2006-01-02 15:04:05", and unix.
+	Layouts []string `json:"layouts"`
+	// Strict returns an error if no layout matches, otherwise the message is
+	// passed through unchanged.
+	Strict bool   `json:"strict"`
+	ID     string `json:"id"`
+}
+
+func (c *ParseTimeAutoConfig) Decode(in interface{}) error {
+	return config.Decode(in, c)
+}
+
+func (c *ParseTimeAutoConfig) Validate() error {
+	for _, layout := range c.Layouts {
+		if layout == "" {
+			return fmt.Errorf("layouts: empty layout")
+		}
+	}
+	return nil
+}
+
+func newParseTimeAuto(_ context.Context, cfg config.Config) (*ParseTimeAuto, error) {
+	conf := ParseTimeAutoConfig{}
+	if err := conf.Decode(cfg.Settings); err != nil {
+		return nil, fmt.Errorf("transform parse_time_auto: %v", err)
+	}
+	if conf.ID == "" {
+		conf.ID = "parse_time_auto"
+	}
+	if len(conf.Layouts) == 0 {
+		conf.Layouts = defaultTimeLayouts
+	}
+	if err := conf.Validate(); err != nil {
+		return nil, fmt.Errorf("transform %s: %v", conf.ID, err)
+	}
+
+	var sourcePath string
+	if v, ok := cfg.Settings["source"]; ok {
+		if s, ok := v.(string); ok {
+			sourcePath = s
+		}
+	}
+
+	var targetPath string
+	if v, ok := cfg.Settings["target"]; ok {
+		if s, ok := v.(string); ok {
+			targetPath = s
+		}
+	}
+
+	tf := ParseTimeAuto{
+		conf:       conf,
+		settings:   cfg.Settings,
+		sourcePath: sourcePath,
+		targetPath: targetPath,
+	}
+	return &tf, nil
+}
+
+// ParseTimeAuto parses a timestamp by trying each layout in order and
+// writes the first match as an RFC 3339 string. The matched layout is
+// recorded in the message metadata at timeFormatKey.
+type ParseTimeAuto struct {
+	conf       ParseTimeAutoConfig
+	settings   map[string]interface{}
+	sourcePath string
+	targetPath string
+}
+
+func (tf *ParseTimeAuto) Transform(ctx context.Context, msg *message.Message) ([]*message.Message, error) {
+	if msg.IsControl() {
+		return []*message.Message{msg}, nil
+	}
+
+	var inputData []byte
+	if tf.sourcePath != "" {
+		val := msg.GetValue(tf.sourcePath)
+		if val.Exists() {
+			inputData = val.Bytes()
+		}
+	}
+	if inputData == nil {
+		inputData = msg.Data()
+	}
+
+	input := strings.TrimSpace(string(inputData))
+	t, layout, ok := parseTimeLayouts(input, tf.conf.Layouts)
+	if !ok {
+		if tf.conf.Strict {
+			return nil, fmt.Errorf("transform %s: no layout matched %q", tf.conf.ID, input)
+		}
+		return []*message.Message{msg}, nil
+	}
+
+	result := t.Format(time.RFC3339)
+	if tf.targetPath != "" {
+		if err := msg.SetValue(tf.targetPath, result); err != nil {
+			return nil, fmt.Errorf("transform %s: failed to set target: %v", tf.conf.ID, err)
+		}
+	} else {
+		msg.SetData([]byte(result))
+	}
+
+	if err := msg.SetValue(timeFormatKey, layout); err != nil {
+		return nil, fmt.Errorf("transform %s: %v", tf.conf.ID, err)
+	}
+
+	return []*message.Message{msg}, nil
+}
+
+func (tf *ParseTimeAuto) String() string {
+	b, _ := json.Marshal(tf.conf)
+	return string(b)
+}
+
+// parseTimeLayouts returns the time parsed by the first matching layout.
+func parseTimeLayouts(s string, layouts []string) (time.Time, string, bool) {
+	for _, layout := range layouts {
+		if layout == unixLayout {
+			secs, err := strconv.ParseFloat(s, 64)
+			if err != nil || math.IsNaN(secs) || math.IsInf(secs, 0) {
+				continue
+			}
+			whole, frac := math.Modf(secs)
+			return time.Unix(int64(whole), int64(frac*1e9)).UTC(), layout, true
+		}
+
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, layout, true
+		}
+	}
+
+	return time.Time{}, "", false
+}
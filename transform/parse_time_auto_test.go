@@ -0,0 +1,102 @@
+package transform
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jshlbrd/vibestation/config"
+	"github.com/jshlbrd/vibestation/message"
+)
+
+func TestParseTimeAuto(t *testing.T) {
+	tests := []struct {
+		name     string
+		layouts  []interface{}
+		input    string
+		expected string
+		format   string
+	}{
+		{
+			name:     "rfc3339",
+			input:    `{"ts":"2024-03-01T12:30:00+02:00"}`,
+			expected: "2024-03-01T12:30:00+02:00",
+			format:   "2006-01-02T15:04:05Z07:00",
+		},
+		{
+			name:     "unix",
+			input:    `{"ts":1700000000}`,
+			expected: "2023-11-14T22:13:20Z",
+			format:   "unix",
+		},
+		{
+			name:     "custom layout",
+			layouts:  []interface{}{"02/Jan/2006:15:04:05 -0700", "unix"},
+			input:    `{"ts":"10/Oct/2000:13:55:36 -0700"}`,
+			expected: "2000-10-10T13:55:36-07:00",
+			format:   "02/Jan/2006:15:04:05 -0700",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			settings := map[string]interface{}{
+				"source": "$.ts",
+				"target": "$.time",
+			}
+			if tt.layouts != nil {
+				settings["layouts"] = tt.layouts
+			}
+			tf, err := newParseTimeAuto(context.Background(), config.Config{Type: "parse_time_auto", Settings: settings})
+			if err != nil {
+				t.Fatalf("failed to create parse_time_auto transform: %v", err)
+			}
+
+			results, err := tf.Transform(context.Background(), message.New().SetData([]byte(tt.input)))
+			if err != nil {
+				t.Fatalf("transform failed: %v", err)
+			}
+			if got := results[0].GetValue("$.time").String(); got != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, got)
+			}
+			if got := results[0].GetValue(timeFormatKey).String(); got != tt.format {
+				t.Errorf("expected format %q, got %q", tt.format, got)
+			}
+		})
+	}
+}
+
+func TestParseTimeAuto_NoMatch(t *testing.T) {
+	settings := map[string]interface{}{
+		"source": "$.ts",
+		"target": "$.time",
+	}
+
+	tf, err := newParseTimeAuto(context.Background(), config.Config{Type: "parse_time_auto", Settings: settings})
+	if err != nil {
+		t.Fatalf("failed to create parse_time_auto transform: %v", err)
+	}
+	results, err := tf.Transform(context.Background(), message.New().SetData([]byte(`{"ts":"yesterday"}`)))
+	if err != nil {
+		t.Fatalf("transform failed: %v", err)
+	}
+	if results[0].GetValue("$.time").Exists() {
+		t.Error("expected target not to be set")
+	}
+
+	settings["strict"] = true
+	tf, err = newParseTimeAuto(context.Background(), config.Config{Type: "parse_time_auto", Settings: settings})
+	if err != nil {
+		t.Fatalf("failed to create parse_time_auto transform: %v", err)
+	}
+	if _, err := tf.Transform(context.Background(), message.New().SetData([]byte(`{"ts":"yesterday"}`))); err == nil {
+		t.Fatal("expected error in strict mode, got nil")
+	}
+
+	// Non-finite values are not Unix timestamps.
+	for _, ts := range []string{"NaN", "Inf", "-Infinity"} {
+		data := []byte(`{"ts":"` + ts + `"}`)
+		if _, err := tf.Transform(context.Background(), message.New().SetData(data)); err == nil {
+			t.Errorf("expected error for %q in strict mode, got nil", ts)
+		}
+	}
+}
@@ -0,0 +1,130 @@
+package transform
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/jshlbrd/vibestation/config"
+	"github.com/jshlbrd/vibestation/message"
+)
+
+type ParseURLConfig struct {
+	// Strict returns an error if the source is not a valid URL,
+	// otherwise the message is passed through unchanged.
+	Strict bool   `json:"strict"`
+	ID     string `json:"id"`
+}
+
+func (c *ParseURLConfig) Decode(in interface{}) error {
+	return config.Decode(in, c)
+}
+
+func newParseURL(_ context.Context, cfg config.Config) (*ParseURL, error) {
+	conf := ParseURLConfig{}
+	if err := conf.Decode(cfg.Settings); err != nil {
+		return nil, fmt.Errorf("transform parse_url: %v", err)
+	}
+	if conf.ID == "" {
+		conf.ID = "parse_url"
+	}
+
+	var sourcePath string
+	if v, ok := cfg.Settings["source"]; ok {
+		if s, ok := v.(string); ok {
+			sourcePath = s
+		}
+	}
+
+	var targetPath string
+	if v, ok := cfg.Settings["target"]; ok {
+		if s, ok := v.(string); ok {
+			targetPath = s
+		}
+	}
+
+	tf := ParseURL{
+		conf:       conf,
+		settings:   cfg.Settings,
+		sourcePath: sourcePath,
+		targetPath: targetPath,
+	}
+	return &tf, nil
+}
+
+// ParseURL decomposes a URL into a JSON object containing its scheme, host,
+// path, and query. Query parameters with a single value are written as
+// strings and repeated parameters are written as arrays.
+type ParseURL struct {
+	conf       ParseURLConfig
+	settings   map[string]interface{}
+	sourcePath string
+	targetPath string
+}
+
+func (tf *ParseURL) Transform(ctx context.Context, msg *message.Message) ([]*message.Message, error) {
+	if msg.IsControl() {
+		return []*message.Message{msg}, nil
+	}
+
+	var inputData []byte
+	if tf.sourcePath != "" {
+		val := msg.GetValue(tf.sourcePath)
+		if val.Exists() {
+			inputData = val.Bytes()
+		}
+	}
+	if inputData == nil {
+		inputData = msg.Data()
+	}
+
+	u, err := url.Parse(strings.TrimSpace(string(inputData)))
+	if err != nil {
+		if tf.conf.Strict {
+			return nil, fmt.Errorf("transform %s: %v", tf.conf.ID, err)
+		}
+		return []*message.Message{msg}, nil
+	}
+
+	query := make(map[string]interface{})
+	for k, v := range u.Query() {
+		if len(v) == 1 {
+			query[k] = v[0]
+			continue
+		}
+
+		values := make([]interface{}, len(v))
+		for i, s := range v {
+			values[i] = s
+		}
+		query[k] = values
+	}
+
+	result := map[string]interface{}{
+		"scheme": u.Scheme,
+		"host":   u.Host,
+		"path":   u.Path,
+		"query":  query,
+	}
+
+	if tf.targetPath != "" {
+		if err := msg.SetValue(tf.targetPath, result); err != nil {
+			return nil, fmt.Errorf("transform %s: failed to set target: %v", tf.conf.ID, err)
+		}
+	} else {
+		b, err := json.Marshal(result)
+		if err != nil {
+			return nil, fmt.Errorf("transform %s: %v", tf.conf.ID, err)
+		}
+		msg.SetData(b)
+	}
+
+	return []*message.Message{msg}, nil
+}
+
+func (tf *ParseURL) String() string {
+	b, _ := json.Marshal(tf.conf)
+	return string(b)
+}
@@ -0,0 +1,84 @@
+package transform
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jshlbrd/vibestation/config"
+	"github.com/jshlbrd/vibestation/message"
+)
+
+func TestParseURL(t *testing.T) {
+	tests := []struct {
+		name     string
+		url      string
+		expected string
+	}{
+		{
+			"full",
+			"https://example.com:8443/a/b?x=1&y=2&y=3#frag",
+			`{"host":"example.com:8443","path":"/a/b","query":{"x":"1","y":["2","3"]},"scheme":"https"}`,
+		},
+		{
+			"relative",
+			"/search?q=go",
+			`{"host":"","path":"/search","query":{"q":"go"},"scheme":""}`,
+		},
+	}
+
+	cfg := config.Config{
+		Type: "parse_url",
+		Settings: map[string]interface{}{
+			"source": "$.url",
+			"target": "$.url_parts",
+		},
+	}
+	tf, err := newParseURL(context.Background(), cfg)
+	if err != nil {
+		t.Fatalf("failed to create parse_url transform: %v", err)
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			msg := message.New().SetData([]byte(`{"url":"` + test.url + `"}`))
+			results, err := tf.Transform(context.Background(), msg)
+			if err != nil {
+				t.Fatalf("transform failed: %v", err)
+			}
+
+			if got := results[0].GetValue("$.url_parts").String(); got != test.expected {
+				t.Errorf("expected %s, got %s", test.expected, got)
+			}
+		})
+	}
+}
+
+func TestParseURL_Malformed(t *testing.T) {
+	tests := []struct {
+		strict  bool
+		wantErr bool
+	}{
+		{false, false},
+		{true, true},
+	}
+
+	for _, test := range tests {
+		cfg := config.Config{
+			Type:     "parse_url",
+			Settings: map[string]interface{}{"strict": test.strict},
+		}
+		tf, err := newParseURL(context.Background(), cfg)
+		if err != nil {
+			t.Fatalf("failed to create parse_url transform: %v", err)
+		}
+
+		msg := message.New().SetData([]byte("http://[::1"))
+		results, err := tf.Transform(context.Background(), msg)
+		if (err != nil) != test.wantErr {
+			t.Fatalf("strict %v: expected error %v, got %v", test.strict, test.wantErr, err)
+		}
+		if err == nil && string(results[0].Data()) != "http://[::1" {
+			t.Errorf("strict %v: expected data to be unchanged, got %s", test.strict, string(results[0].Data()))
+		}
+	}
+}
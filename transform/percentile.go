@@ -0,0 +1,136 @@
+package transform
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"sync"
+
+	"github.com/jshlbrd/vibestation/config"
+	"github.com/jshlbrd/vibestation/message"
+)
+
+type PercentileConfig struct {
+	// Percentiles is the list of percentiles to compute, between 0 and 100.
+	// Defaults to [50, 95, 99].
+	Percentiles []float64 `json:"percentiles"`
+	ID          string    `json:"id"`
+}
+
+func (c *PercentileConfig) Decode(in interface{}) error {
+	return config.Decode(in, c)
+}
+
+func (c *PercentileConfig) Validate() error {
+	for _, p := range c.Percentiles {
+		if p < 0 || p > 100 {
+			return fmt.Errorf("percentiles: %v is not between 0 and 100", p)
+		}
+	}
+	return nil
+}
+
+func newPercentile(_ context.Context, cfg config.Config) (*Percentile, error) {
+	conf := PercentileConfig{}
+	if err := conf.Decode(cfg.Settings); err != nil {
+		return nil, fmt.Errorf("transform percentile: %v", err)
+	}
+	if conf.ID == "" {
+		conf.ID = "percentile"
+	}
+	if len(conf.Percentiles) == 0 {
+		conf.Percentiles = []float64{50, 95, 99}
+	}
+	if err := conf.Validate(); err != nil {
+		return nil, fmt.Errorf("transform %s: %v", conf.ID, err)
+	}
+
+	sourcePath := "$"
+	if v, ok := cfg.Settings["source"]; ok {
+		if s, ok := v.(string); ok && s != "" {
+			sourcePath = s
+		}
+	}
+
+	tf := Percentile{
+		conf:       conf,
+		settings:   cfg.Settings,
+		sourcePath: sourcePath,
+	}
+	return &tf, nil
+}
+
+// Percentile buffers a numeric value from each data message. When a control
+// message is received, it emits an object with a key for each percentile
+// (e.g. {"p50":...,"p95":...,"p99":...}) followed by the control message, and
+// resets the buffer. Percentiles are linearly interpolated between the
+// closest ranks. Data messages are passed through unchanged, and a data message
+// whose value is missing or not numeric is an error.
+type Percentile struct {
+	conf       PercentileConfig
+	settings   map[string]interface{}
+	sourcePath string
+
+	mu     sync.Mutex
+	values []float64
+}
+
+func (tf *Percentile) Transform(ctx context.Context, msg *message.Message) ([]*message.Message, error) {
+	tf.mu.Lock()
+	defer tf.mu.Unlock()
+
+	if !msg.IsControl() {
+		val := msg.GetValue(tf.sourcePath)
+		if !val.Exists() {
+			return nil, fmt.Errorf("transform %s: source %s not found", tf.conf.ID, tf.sourcePath)
+		}
+
+		f, ok := numericValue(val)
+		if !ok {
+			return nil, fmt.Errorf("transform %s: source %s is not a number", tf.conf.ID, tf.sourcePath)
+		}
+
+		tf.values = append(tf.values, f)
+		return []*message.Message{msg}, nil
+	}
+
+	if len(tf.values) == 0 {
+		return []*message.Message{msg}, nil
+	}
+
+	sort.Float64s(tf.values)
+	result := make(map[string]float64)
+	for _, p := range tf.conf.Percentiles {
+		key := "p" + strconv.FormatFloat(p, 'f', -1, 64)
+		result[key] = percentile(tf.values, p)
+	}
+
+	b, err := json.Marshal(result)
+	if err != nil {
+		return nil, fmt.Errorf("transform %s: %v", tf.conf.ID, err)
+	}
+
+	tf.values = nil
+	return []*message.Message{message.New().SetData(b), msg}, nil
+}
+
+func (tf *Percentile) String() string {
+	b, _ := json.Marshal(tf.conf)
+	return string(b)
+}
+
+// percentile returns the p-th percentile of the sorted values, linearly
+// interpolating between the closest ranks.
+func percentile(sorted []float64, p float64) float64 {
+	rank := p / 100 * float64(len(sorted)-1)
+	lower := int(math.Floor(rank))
+	upper := int(math.Ceil(rank))
+	if lower == upper {
+		return sorted[lower]
+	}
+
+	return sorted[lower] + (rank-float64(lower))*(sorted[upper]-sorted[lower])
+}
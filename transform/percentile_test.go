@@ -0,0 +1,109 @@
+package transform
+
+import (
+	"context"
+	"strconv"
+	"testing"
+
+	"github.com/jshlbrd/vibestation/config"
+	"github.com/jshlbrd/vibestation/message"
+)
+
+func TestPercentile(t *testing.T) {
+	cfg := config.Config{
+		Type: "percentile",
+		Settings: map[string]interface{}{
+			"source":      "$.latency",
+			"percentiles": []interface{}{0, 50, 95, 99, 100},
+		},
+	}
+	tf, err := newPercentile(context.Background(), cfg)
+	if err != nil {
+		t.Fatalf("failed to create percentile transform: %v", err)
+	}
+
+	// The values 1 through 101 in reverse order, so that the p-th percentile
+	// is exactly p+1.
+	var msgs []*message.Message
+	for i := 101; i >= 1; i-- {
+		msgs = append(msgs, message.New().SetData([]byte(`{"latency":`+strconv.Itoa(i)+`}`)))
+	}
+	msgs = append(msgs, message.New().AsControl())
+
+	results, err := Apply(context.Background(), []Transformer{tf}, msgs...)
+	if err != nil {
+		t.Fatalf("transform failed: %v", err)
+	}
+	// Data messages are passed through, followed by the percentiles and the
+	// control message.
+	if len(results) != 103 {
+		t.Fatalf("expected 103 results, got %d", len(results))
+	}
+	if got := string(results[0].Data()); got != `{"latency":101}` {
+		t.Errorf("expected data message to be unchanged, got %s", got)
+	}
+
+	expected := `{"p0":1,"p100":101,"p50":51,"p95":96,"p99":100}`
+	if got := string(results[101].Data()); got != expected {
+		t.Errorf("expected %s, got %s", expected, got)
+	}
+	if !results[102].IsControl() {
+		t.Error("expected control message after percentiles")
+	}
+}
+
+func TestPercentile_Interpolate(t *testing.T) {
+	tf, err := newPercentile(context.Background(), config.Config{Type: "percentile"})
+	if err != nil {
+		t.Fatalf("failed to create percentile transform: %v", err)
+	}
+
+	msgs := []*message.Message{
+		message.New().SetData([]byte("10")),
+		message.New().SetData([]byte("20")),
+		message.New().AsControl(),
+	}
+
+	results, err := Apply(context.Background(), []Transformer{tf}, msgs...)
+	if err != nil {
+		t.Fatalf("transform failed: %v", err)
+	}
+
+	expected := `{"p50":15,"p95":19.5,"p99":19.9}`
+	if got := string(results[2].Data()); got != expected {
+		t.Errorf("expected %s, got %s", expected, got)
+	}
+}
+
+func TestPercentile_NonNumeric(t *testing.T) {
+	cfg := config.Config{
+		Type:     "percentile",
+		Settings: map[string]interface{}{"source": "$.latency"},
+	}
+	tf, err := newPercentile(context.Background(), cfg)
+	if err != nil {
+		t.Fatalf("failed to create percentile transform: %v", err)
+	}
+
+	for _, input := range []string{`{"latency":"slow"}`, `{"latency":true}`} {
+		if _, err := tf.Transform(context.Background(), message.New().SetData([]byte(input))); err == nil {
+			t.Errorf("expected error for %s, got nil", input)
+		}
+	}
+
+	// Rejected values are not buffered.
+	msgs := []*message.Message{
+		message.New().SetData([]byte(`{"latency":"4"}`)),
+		message.New().SetData([]byte(`{"latency":2}`)),
+		message.New().AsControl(),
+	}
+	results, err := Apply(context.Background(), []Transformer{tf}, msgs...)
+	if err != nil {
+		t.Fatalf("transform failed: %v", err)
+	}
+
+	expected := `{"p50":3,"p95":3.9,"p99":3.98}`
+	if got := string(results[2].Data()); got != expected {
+		t.Errorf("expected %s, got %s", expected, got)
+	}
+}
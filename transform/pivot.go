@@ -0,0 +1,152 @@
+package transform
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/jshlbrd/vibestation/config"
+	"github.com/jshlbrd/vibestation/message"
+)
+
+type PivotConfig struct {
+	// KeyField is the field in each element used as the object key. Defaults
+	// to key.
+	KeyField string `json:"key_field"`
+	// ValueField is the field in each element used as the object value.
+	// Defaults to value.
+	ValueField string `json:"value_field"`
+	// Merge controls how duplicate keys are handled:
+	//   - last (default): the last value is kept
+	//   - first: the first value is kept
+	//   - array: all values are collected into an array
+	//   - error: an error is returned
+	Merge string `json:"merge"`
+	ID    string `json:"id"`
+}
+
+func (c *PivotConfig) Decode(in interface{}) error {
+	return config.Decode(in, c)
+}
+
+func (c *PivotConfig) Validate() error {
+	switch c.Merge {
+	case "last", "first", "array", "error":
+	default:
+		return fmt.Errorf("merge: unsupported value %q", c.Merge)
+	}
+	return nil
+}
+
+func newPivot(_ context.Context, cfg config.Config) (*Pivot, error) {
+	conf := PivotConfig{}
+	if err := conf.Decode(cfg.Settings); err != nil {
+		return nil, fmt.Errorf("transform pivot: %v", err)
+	}
+	if conf.ID == "" {
+		conf.ID = "pivot"
+	}
+	if conf.KeyField == "" {
+		conf.KeyField = "key"
+	}
+	if conf.ValueField == "" {
+		conf.ValueField = "value"
+	}
+	if conf.Merge == "" {
+		conf.Merge = "last"
+	}
+	if err := conf.Validate(); err != nil {
+		return nil, fmt.Errorf("transform %s: %v", conf.ID, err)
+	}
+
+	sourcePath := "$"
+	if v, ok := cfg.Settings["source"]; ok {
+		if s, ok := v.(string); ok && s != "" {
+			sourcePath = s
+		}
+	}
+
+	var targetPath string
+	if v, ok := cfg.Settings["target"]; ok {
+		if s, ok := v.(string); ok {
+			targetPath = s
+		}
+	}
+
+	tf := Pivot{
+		conf:       conf,
+		settings:   cfg.Settings,
+		sourcePath: sourcePath,
+		targetPath: targetPath,
+	}
+	return &tf, nil
+}
+
+// Pivot converts an array of key-value objects into a single object, such as
+// [{"key":"a","value":1},{"key":"b","value":2}] into {"a":1,"b":2}.
+type Pivot struct {
+	conf       PivotConfig
+	settings   map[string]interface{}
+	sourcePath string
+	targetPath string
+}
+
+func (tf *Pivot) Transform(ctx context.Context, msg *message.Message) ([]*message.Message, error) {
+	if msg.IsControl() {
+		return []*message.Message{msg}, nil
+	}
+
+	val := msg.GetValue(tf.sourcePath)
+	if !val.IsArray() {
+		return nil, fmt.Errorf("transform %s: source %s is not an array", tf.conf.ID, tf.sourcePath)
+	}
+
+	result := make(map[string]interface{})
+	for i, elem := range val.Array() {
+		m := elem.Map()
+		k, ok := m[tf.conf.KeyField]
+		if !ok {
+			return nil, fmt.Errorf("transform %s: element %d is missing %s", tf.conf.ID, i, tf.conf.KeyField)
+		}
+		key := k.String()
+		value := m[tf.conf.ValueField].Value()
+
+		prev, exists := result[key]
+		if !exists {
+			if tf.conf.Merge == "array" {
+				result[key] = []interface{}{value}
+			} else {
+				result[key] = value
+			}
+			continue
+		}
+
+		switch tf.conf.Merge {
+		case "last":
+			result[key] = value
+		case "array":
+			result[key] = append(prev.([]interface{}), value)
+		case "error":
+			return nil, fmt.Errorf("transform %s: duplicate key %q", tf.conf.ID, key)
+		}
+	}
+
+	if tf.targetPath != "" {
+		if err := msg.SetValue(tf.targetPath, result); err != nil {
+			return nil, fmt.Errorf("transform %s: failed to set target: %v", tf.conf.ID, err)
+		}
+	} else {
+		b, err := json.Marshal(result)
+		if err != nil {
+			return nil, fmt.Errorf("transform %s: %v", tf.conf.ID, err)
+		}
+		msg.SetData(b)
+	}
+
+	return []*message.Message{msg}, nil
+}
+
+func (tf *Pivot) String() string {
+	b, _ := json.Marshal(tf.conf)
+	return string(b)
+}
@@ -0,0 +1,75 @@
+package transform
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jshlbrd/vibestation/config"
+	"github.com/jshlbrd/vibestation/message"
+)
+
+func TestPivot(t *testing.T) {
+	cfg := config.Config{
+		Type: "pivot",
+		Settings: map[string]interface{}{
+			"key_field":   "k",
+			"value_field": "v",
+			"source":      "$.pairs",
+			"target":      "$.object",
+		},
+	}
+	tf, err := newPivot(context.Background(), cfg)
+	if err != nil {
+		t.Fatalf("failed to create pivot transform: %v", err)
+	}
+
+	msg := message.New().SetData([]byte(`{"pairs":[{"k":"a","v":1},{"k":"b","v":{"c":2}}]}`))
+	results, err := tf.Transform(context.Background(), msg)
+	if err != nil {
+		t.Fatalf("transform failed: %v", err)
+	}
+
+	expected := `{"a":1,"b":{"c":2}}`
+	if got := results[0].GetValue("$.object").String(); got != expected {
+		t.Errorf("expected %s, got %s", expected, got)
+	}
+}
+
+func TestPivot_DuplicateKeys(t *testing.T) {
+	tests := []struct {
+		merge    string
+		expected string
+		wantErr  bool
+	}{
+		{"last", `{"a":3,"b":2}`, false},
+		{"first", `{"a":1,"b":2}`, false},
+		{"array", `{"a":[1,3],"b":[2]}`, false},
+		{"error", "", true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.merge, func(t *testing.T) {
+			cfg := config.Config{
+				Type:     "pivot",
+				Settings: map[string]interface{}{"merge": test.merge},
+			}
+			tf, err := newPivot(context.Background(), cfg)
+			if err != nil {
+				t.Fatalf("failed to create pivot transform: %v", err)
+			}
+
+			msg := message.New().SetData([]byte(`[{"key":"a","value":1},{"key":"b","value":2},{"key":"a","value":3}]`))
+			results, err := tf.Transform(context.Background(), msg)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("expected error %v, got %v", test.wantErr, err)
+			}
+			if err != nil {
+				return
+			}
+
+			if got := string(results[0].Data()); got != test.expected {
+				t.Errorf("expected %s, got %s", test.expected, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,80 @@
+package transform
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/jshlbrd/vibestation/config"
+	"github.com/jshlbrd/vibestation/message"
+)
+
+type PrefixMetadataConfig struct {
+	Prefix string `json:"prefix"`
+	ID     string `json:"id"`
+}
+
+func (c *PrefixMetadataConfig) Decode(in interface{}) error {
+	return config.Decode(in, c)
+}
+
+func (c *PrefixMetadataConfig) Validate() error {
+	if c.Prefix == "" {
+		return fmt.Errorf("prefix: missing required option")
+	}
+	return nil
+}
+
+func newPrefixMetadata(_ context.Context, cfg config.Config) (*PrefixMetadata, error) {
+	conf := PrefixMetadataConfig{}
+	if err := conf.Decode(cfg.Settings); err != nil {
+		return nil, fmt.Errorf("transform prefix_metadata: %v", err)
+	}
+	if conf.ID == "" {
+		conf.ID = "prefix_metadata"
+	}
+	if err := conf.Validate(); err != nil {
+		return nil, fmt.Errorf("transform %s: %v", conf.ID, err)
+	}
+
+	tf := PrefixMetadata{
+		conf:     conf,
+		settings: cfg.Settings,
+	}
+	return &tf, nil
+}
+
+// PrefixMetadata rewrites every top-level metadata key to "<prefix>_<key>".
+type PrefixMetadata struct {
+	conf     PrefixMetadataConfig
+	settings map[string]interface{}
+}
+
+func (tf *PrefixMetadata) Transform(ctx context.Context, msg *message.Message) ([]*message.Message, error) {
+	if msg.IsControl() || len(msg.Metadata()) == 0 {
+		return []*message.Message{msg}, nil
+	}
+
+	var meta map[string]interface{}
+	if err := json.Unmarshal(msg.Metadata(), &meta); err != nil {
+		return nil, fmt.Errorf("transform %s: metadata is not a JSON object: %v", tf.conf.ID, err)
+	}
+
+	prefixed := make(map[string]interface{}, len(meta))
+	for k, v := range meta {
+		prefixed[tf.conf.Prefix+"_"+k] = v
+	}
+
+	b, err := json.Marshal(prefixed)
+	if err != nil {
+		return nil, fmt.Errorf("transform %s: %v", tf.conf.ID, err)
+	}
+	msg.SetMetadata(b)
+
+	return []*message.Message{msg}, nil
+}
+
+func (tf *PrefixMetadata) String() string {
+	b, _ := json.Marshal(tf.conf)
+	return string(b)
+}
@@ -0,0 +1,45 @@
+package transform
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jshlbrd/vibestation/config"
+	"github.com/jshlbrd/vibestation/message"
+)
+
+func TestPrefixMetadata(t *testing.T) {
+	cfg := config.Config{
+		Type: "prefix_metadata",
+		Settings: map[string]interface{}{
+			"prefix": "src",
+		},
+	}
+	tf, err := newPrefixMetadata(context.Background(), cfg)
+	if err != nil {
+		t.Fatalf("failed to create prefix_metadata transform: %v", err)
+	}
+
+	msg := message.New().
+		SetData([]byte(`{"a":1}`)).
+		SetMetadata([]byte(`{"host":"web1","nested":{"x":1}}`))
+
+	results, err := tf.Transform(context.Background(), msg)
+	if err != nil {
+		t.Fatalf("transform failed: %v", err)
+	}
+
+	expected := `{"src_host":"web1","src_nested":{"x":1}}`
+	if string(results[0].Metadata()) != expected {
+		t.Errorf("expected %s, got %s", expected, string(results[0].Metadata()))
+	}
+	if string(results[0].Data()) != `{"a":1}` {
+		t.Errorf("expected data to be unchanged, got %s", string(results[0].Data()))
+	}
+}
+
+func TestPrefixMetadata_MissingPrefix(t *testing.T) {
+	if _, err := newPrefixMetadata(context.Background(), config.Config{Type: "prefix_metadata"}); err == nil {
+		t.Fatal("expected error for missing prefix, got nil")
+	}
+}
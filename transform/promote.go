@@ -0,0 +1,72 @@
+package transform
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/jshlbrd/vibestation/config"
+	"github.com/jshlbrd/vibestation/message"
+)
+
+type PromoteConfig struct {
+	ID string `json:"id"`
+}
+
+func (c *PromoteConfig) Decode(in interface{}) error {
+	return config.Decode(in, c)
+}
+
+func newPromote(_ context.Context, cfg config.Config) (*Promote, error) {
+	conf := PromoteConfig{}
+	if err := conf.Decode(cfg.Settings); err != nil {
+		return nil, fmt.Errorf("transform promote: %v", err)
+	}
+	if conf.ID == "" {
+		conf.ID = "promote"
+	}
+	var sourcePath string
+	if v, ok := cfg.Settings["source"]; ok {
+		if s, ok := v.(string); ok {
+			sourcePath = s
+		}
+	}
+	if sourcePath == "" {
+		return nil, fmt.Errorf("transform %s: source: missing required option", conf.ID)
+	}
+
+	tf := Promote{
+		conf:       conf,
+		settings:   cfg.Settings,
+		sourcePath: sourcePath,
+	}
+	return &tf, nil
+}
+
+// Promote replaces the message data with the value at a path, dropping
+// everything else. Strings are written raw and all other values are written
+// as JSON.
+type Promote struct {
+	conf       PromoteConfig
+	settings   map[string]interface{}
+	sourcePath string
+}
+
+func (tf *Promote) Transform(ctx context.Context, msg *message.Message) ([]*message.Message, error) {
+	if msg.IsControl() {
+		return []*message.Message{msg}, nil
+	}
+
+	val := msg.GetValue(tf.sourcePath)
+	if !val.Exists() {
+		return nil, fmt.Errorf("transform %s: source %q does not exist", tf.conf.ID, tf.sourcePath)
+	}
+
+	msg.SetData(val.Bytes())
+	return []*message.Message{msg}, nil
+}
+
+func (tf *Promote) String() string {
+	b, _ := json.Marshal(tf.conf)
+	return string(b)
+}
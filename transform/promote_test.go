@@ -0,0 +1,66 @@
+package transform
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jshlbrd/vibestation/config"
+	"github.com/jshlbrd/vibestation/message"
+)
+
+func TestPromote(t *testing.T) {
+	tests := []struct {
+		name     string
+		data     string
+		expected string
+	}{
+		{"object", `{"id":1,"result":{"value":{"a":[1,2]}}}`, `{"a":[1,2]}`},
+		{"string", `{"id":1,"result":{"value":"done"}}`, `done`},
+		{"number", `{"id":1,"result":{"value":42}}`, `42`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := config.Config{
+				Type:     "promote",
+				Settings: map[string]interface{}{"source": "$.result.value"},
+			}
+			tf, err := newPromote(context.Background(), cfg)
+			if err != nil {
+				t.Fatalf("failed to create promote transform: %v", err)
+			}
+
+			results, err := tf.Transform(context.Background(), message.New().SetData([]byte(tt.data)))
+			if err != nil {
+				t.Fatalf("transform failed: %v", err)
+			}
+			if len(results) != 1 {
+				t.Fatalf("expected 1 message, got %d", len(results))
+			}
+			if got := string(results[0].Data()); got != tt.expected {
+				t.Errorf("expected %s, got %s", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestPromote_Missing(t *testing.T) {
+	cfg := config.Config{
+		Type:     "promote",
+		Settings: map[string]interface{}{"source": "$.result.value"},
+	}
+	tf, err := newPromote(context.Background(), cfg)
+	if err != nil {
+		t.Fatalf("failed to create promote transform: %v", err)
+	}
+
+	if _, err := tf.Transform(context.Background(), message.New().SetData([]byte(`{"result":{}}`))); err == nil {
+		t.Fatal("expected error for missing source, got nil")
+	}
+}
+
+func TestPromote_MissingSource(t *testing.T) {
+	if _, err := newPromote(context.Background(), config.Config{Type: "promote"}); err == nil {
+		t.Fatal("expected error for missing source option, got nil")
+	}
+}
@@ -0,0 +1,96 @@
+package transform
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/jshlbrd/vibestation/config"
+	"github.com/jshlbrd/vibestation/message"
+)
+
+type RateLimitConfig struct {
+	// PerSecond is the maximum number of messages that pass per second.
+	PerSecond float64 `json:"per_second"`
+	ID        string  `json:"id"`
+}
+
+func (c *RateLimitConfig) Decode(in interface{}) error {
+	return config.Decode(in, c)
+}
+
+func (c *RateLimitConfig) Validate() error {
+	if c.PerSecond <= 0 {
+		return fmt.Errorf("per_second: must be greater than 0")
+	}
+	return nil
+}
+
+func newRateLimit(_ context.Context, cfg config.Config) (*RateLimit, error) {
+	conf := RateLimitConfig{}
+	if err := conf.Decode(cfg.Settings); err != nil {
+		return nil, fmt.Errorf("transform rate_limit: %v", err)
+	}
+	if conf.ID == "" {
+		conf.ID = "rate_limit"
+	}
+	if err := conf.Validate(); err != nil {
+		return nil, fmt.Errorf("transform %s: %v", conf.ID, err)
+	}
+
+	tf := RateLimit{
+		conf:     conf,
+		settings: cfg.Settings,
+		interval: time.Duration(float64(time.Second) / conf.PerSecond),
+	}
+	return &tf, nil
+}
+
+// RateLimit blocks until a token is available, limiting data messages to
+// per_second. The bucket holds a single token, so bursts are not allowed.
+// Control messages are not limited.
+type RateLimit struct {
+	conf     RateLimitConfig
+	settings map[string]interface{}
+	interval time.Duration
+
+	mu   sync.Mutex
+	next time.Time
+}
+
+func (tf *RateLimit) Transform(ctx context.Context, msg *message.Message) ([]*message.Message, error) {
+	if msg.IsControl() {
+		return []*message.Message{msg}, nil
+	}
+
+	// Reserve the next token before waiting so that concurrent callers are
+	// spaced out by the interval.
+	tf.mu.Lock()
+	now := time.Now()
+	if tf.next.Before(now) {
+		tf.next = now
+	}
+	wait := tf.next.Sub(now)
+	tf.next = tf.next.Add(tf.interval)
+	tf.mu.Unlock()
+
+	if wait > 0 {
+		timer := time.NewTimer(wait)
+		defer timer.Stop()
+
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("transform %s: %v", tf.conf.ID, ctx.Err())
+		case <-timer.C:
+		}
+	}
+
+	return []*message.Message{msg}, nil
+}
+
+func (tf *RateLimit) String() string {
+	b, _ := json.Marshal(tf.conf)
+	return string(b)
+}
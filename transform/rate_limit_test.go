@@ -0,0 +1,66 @@
+package transform
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jshlbrd/vibestation/config"
+	"github.com/jshlbrd/vibestation/message"
+)
+
+func TestRateLimit(t *testing.T) {
+	cfg := config.Config{
+		Type:     "rate_limit",
+		Settings: map[string]interface{}{"per_second": 50},
+	}
+	tf, err := newRateLimit(context.Background(), cfg)
+	if err != nil {
+		t.Fatalf("failed to create rate_limit transform: %v", err)
+	}
+
+	// The first message passes immediately, and each following message waits
+	// 20ms, so 6 messages take at least 100ms.
+	start := time.Now()
+	for i := 0; i < 6; i++ {
+		results, err := tf.Transform(context.Background(), message.New().SetData([]byte(`{"a":1}`)))
+		if err != nil {
+			t.Fatalf("transform failed: %v", err)
+		}
+		if len(results) != 1 {
+			t.Fatalf("expected 1 result, got %d", len(results))
+		}
+	}
+
+	if elapsed := time.Since(start); elapsed < 100*time.Millisecond {
+		t.Errorf("expected at least 100ms, got %v", elapsed)
+	}
+}
+
+func TestRateLimit_Context(t *testing.T) {
+	cfg := config.Config{
+		Type:     "rate_limit",
+		Settings: map[string]interface{}{"per_second": 0.1},
+	}
+	tf, err := newRateLimit(context.Background(), cfg)
+	if err != nil {
+		t.Fatalf("failed to create rate_limit transform: %v", err)
+	}
+
+	if _, err := tf.Transform(context.Background(), message.New().SetData([]byte(`{"a":1}`))); err != nil {
+		t.Fatalf("transform failed: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := tf.Transform(ctx, message.New().SetData([]byte(`{"a":1}`))); err == nil {
+		t.Fatal("expected error for cancelled context, got nil")
+	}
+}
+
+func TestRateLimit_InvalidRate(t *testing.T) {
+	cfg := config.Config{Type: "rate_limit"}
+	if _, err := newRateLimit(context.Background(), cfg); err == nil {
+		t.Fatal("expected error for missing per_second, got nil")
+	}
+}
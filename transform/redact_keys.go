@@ -0,0 +1,129 @@
+package transform
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+
+	"github.com/jshlbrd/vibestation/config"
+	"github.com/jshlbrd/vibestation/message"
+)
+
+type RedactKeysConfig struct {
+	// Pattern is a regular expression matched against each key.
+	Pattern string `json:"pattern"`
+	// Replacement is the value written to matching keys. Defaults to
+	// "[REDACTED]".
+	Replacement string `json:"replacement"`
+	ID          string `json:"id"`
+}
+
+func (c *RedactKeysConfig) Decode(in interface{}) error {
+	return config.Decode(in, c)
+}
+
+func (c *RedactKeysConfig) Validate() error {
+	if c.Pattern == "" {
+		return fmt.Errorf("pattern: missing required option")
+	}
+	return nil
+}
+
+func newRedactKeys(_ context.Context, cfg config.Config) (*RedactKeys, error) {
+	conf := RedactKeysConfig{}
+	if err := conf.Decode(cfg.Settings); err != nil {
+		return nil, fmt.Errorf("transform redact_keys: %v", err)
+	}
+	if conf.ID == "" {
+		conf.ID = "redact_keys"
+	}
+	if conf.Replacement == "" {
+		conf.Replacement = "[REDACTED]"
+	}
+	if err := conf.Validate(); err != nil {
+		return nil, fmt.Errorf("transform %s: %v", conf.ID, err)
+	}
+
+	re, err := regexp.Compile(conf.Pattern)
+	if err != nil {
+		return nil, fmt.Errorf("transform %s: pattern: %v", conf.ID, err)
+	}
+
+	tf := RedactKeys{
+		conf:     conf,
+		settings: cfg.Settings,
+		re:       re,
+	}
+	return &tf, nil
+}
+
+// RedactKeys replaces the value of every key in the data that matches the
+// pattern, including keys in nested objects and arrays of objects.
+type RedactKeys struct {
+	conf     RedactKeysConfig
+	settings map[string]interface{}
+	re       *regexp.Regexp
+}
+
+func (tf *RedactKeys) Transform(ctx context.Context, msg *message.Message) ([]*message.Message, error) {
+	if msg.IsControl() {
+		return []*message.Message{msg}, nil
+	}
+
+	data, err := decodeJSONNumber(msg.Data())
+	if err != nil {
+		return nil, fmt.Errorf("transform %s: %v", tf.conf.ID, err)
+	}
+
+	data = replaceKeys(data, tf.re, func(interface{}) interface{} {
+		return tf.conf.Replacement
+	})
+
+	b, err := json.Marshal(data)
+	if err != nil {
+		return nil, fmt.Errorf("transform %s: %v", tf.conf.ID, err)
+	}
+	msg.SetData(b)
+
+	return []*message.Message{msg}, nil
+}
+
+func (tf *RedactKeys) String() string {
+	b, _ := json.Marshal(tf.conf)
+	return string(b)
+}
+
+// decodeJSONNumber decodes JSON data, preserving numbers as json.Number so
+// that they are re-encoded without loss of precision.
+func decodeJSONNumber(data []byte) (interface{}, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+
+	var v interface{}
+	if err := dec.Decode(&v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// replaceKeys walks v and replaces the value of every object key that
+// matches re with the result of fn.
+func replaceKeys(v interface{}, re *regexp.Regexp, fn func(interface{}) interface{}) interface{} {
+	switch t := v.(type) {
+	case map[string]interface{}:
+		for k, val := range t {
+			if re.MatchString(k) {
+				t[k] = fn(val)
+				continue
+			}
+			t[k] = replaceKeys(val, re, fn)
+		}
+	case []interface{}:
+		for i, val := range t {
+			t[i] = replaceKeys(val, re, fn)
+		}
+	}
+	return v
+}
@@ -0,0 +1,68 @@
+package transform
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jshlbrd/vibestation/config"
+	"github.com/jshlbrd/vibestation/message"
+)
+
+func TestRedactKeys(t *testing.T) {
+	cfg := config.Config{
+		Type: "redact_keys",
+		Settings: map[string]interface{}{
+			"pattern": "(?i)(password|api_key)",
+		},
+	}
+	tf, err := newRedactKeys(context.Background(), cfg)
+	if err != nil {
+		t.Fatalf("failed to create redact_keys transform: %v", err)
+	}
+
+	msg := message.New().SetData([]byte(`{"user":"alice","password":"hunter2","id":12345678901234567890,"config":{"api_key":"abc","region":"us"},"hosts":[{"db_password":"x","name":"db1"}]}`))
+	results, err := tf.Transform(context.Background(), msg)
+	if err != nil {
+		t.Fatalf("transform failed: %v", err)
+	}
+
+	expected := `{"config":{"api_key":"[REDACTED]","region":"us"},"hosts":[{"db_password":"[REDACTED]","name":"db1"}],"id":12345678901234567890,"password":"[REDACTED]","user":"alice"}`
+	if got := string(results[0].Data()); got != expected {
+		t.Errorf("expected %s, got %s", expected, got)
+	}
+}
+
+func TestRedactKeys_Replacement(t *testing.T) {
+	cfg := config.Config{
+		Type: "redact_keys",
+		Settings: map[string]interface{}{
+			"pattern":     "^secret$",
+			"replacement": "***",
+		},
+	}
+	tf, err := newRedactKeys(context.Background(), cfg)
+	if err != nil {
+		t.Fatalf("failed to create redact_keys transform: %v", err)
+	}
+
+	msg := message.New().SetData([]byte(`{"secret":{"a":1},"not_secret":"b"}`))
+	results, err := tf.Transform(context.Background(), msg)
+	if err != nil {
+		t.Fatalf("transform failed: %v", err)
+	}
+
+	expected := `{"not_secret":"b","secret":"***"}`
+	if got := string(results[0].Data()); got != expected {
+		t.Errorf("expected %s, got %s", expected, got)
+	}
+}
+
+func TestRedactKeys_InvalidPattern(t *testing.T) {
+	cfg := config.Config{
+		Type:     "redact_keys",
+		Settings: map[string]interface{}{"pattern": "("},
+	}
+	if _, err := newRedactKeys(context.Background(), cfg); err == nil {
+		t.Fatal("expected error for invalid pattern, got nil")
+	}
+}
@@ -0,0 +1,161 @@
+package transform
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/jshlbrd/vibestation/config"
+	"github.com/jshlbrd/vibestation/message"
+)
+
+type ReduceArrayConfig struct {
+	// Operation is one of max, min, sum, or avg.
+	Operation string `json:"operation"`
+	// Field is a JSON path (e.g. $.score) applied to each element to get the
+	// number that is reduced. If not set, then elements must be numbers.
+	Field string `json:"field"`
+	ID    string `json:"id"`
+}
+
+func (c *ReduceArrayConfig) Decode(in interface{}) error {
+	return config.Decode(in, c)
+}
+
+func (c *ReduceArrayConfig) Validate() error {
+	switch c.Operation {
+	case "max", "min", "sum", "avg":
+	default:
+		return fmt.Errorf("operation: unsupported operation %q", c.Operation)
+	}
+	return nil
+}
+
+func newReduceArray(_ context.Context, cfg config.Config) (*ReduceArray, error) {
+	conf := ReduceArrayConfig{}
+	if err := conf.Decode(cfg.Settings); err != nil {
+		return nil, fmt.Errorf("transform reduce_array: %v", err)
+	}
+	if conf.ID == "" {
+		conf.ID = "reduce_array"
+	}
+	if err := conf.Validate(); err != nil {
+		return nil, fmt.Errorf("transform %s: %v", conf.ID, err)
+	}
+
+	sourcePath := "$"
+	if v, ok := cfg.Settings["source"]; ok {
+		if s, ok := v.(string); ok && s != "" {
+			sourcePath = s
+		}
+	}
+
+	var targetPath string
+	if v, ok := cfg.Settings["target"]; ok {
+		if s, ok := v.(string); ok {
+			targetPath = s
+		}
+	}
+
+	tf := ReduceArray{
+		conf:       conf,
+		settings:   cfg.Settings,
+		sourcePath: sourcePath,
+		targetPath: targetPath,
+	}
+	return &tf, nil
+}
+
+// ReduceArray reduces an array to a single value. The max and min operations
+// return the element with the largest or smallest number, so with Field set
+// they return the whole record (e.g. the item with the highest score). The
+// sum and avg operations return a number.
+type ReduceArray struct {
+	conf       ReduceArrayConfig
+	settings   map[string]interface{}
+	sourcePath string
+	targetPath string
+}
+
+func (tf *ReduceArray) Transform(ctx context.Context, msg *message.Message) ([]*message.Message, error) {
+	if msg.IsControl() {
+		return []*message.Message{msg}, nil
+	}
+
+	val := msg.GetValue(tf.sourcePath)
+	if !val.IsArray() {
+		return nil, fmt.Errorf("transform %s: source %s is not an array", tf.conf.ID, tf.sourcePath)
+	}
+
+	elems := val.Array()
+	if len(elems) == 0 {
+		return nil, fmt.Errorf("transform %s: source %s is empty", tf.conf.ID, tf.sourcePath)
+	}
+
+	var (
+		best    interface{}
+		bestNum float64
+		sum     float64
+	)
+	for i, elem := range elems {
+		n, err := tf.number(elem)
+		if err != nil {
+			return nil, fmt.Errorf("transform %s: element %d: %v", tf.conf.ID, i, err)
+		}
+
+		sum += n
+		if i == 0 || (tf.conf.Operation == "max" && n > bestNum) || (tf.conf.Operation == "min" && n < bestNum) {
+			best = elem.Value()
+			bestNum = n
+		}
+	}
+
+	var result interface{}
+	switch tf.conf.Operation {
+	case "max", "min":
+		result = best
+	case "sum":
+		result = sum
+	case "avg":
+		result = sum / float64(len(elems))
+	}
+
+	if tf.targetPath != "" {
+		if err := msg.SetValue(tf.targetPath, result); err != nil {
+			return nil, fmt.Errorf("transform %s: failed to set target: %v", tf.conf.ID, err)
+		}
+	} else {
+		b, err := json.Marshal(result)
+		if err != nil {
+			return nil, fmt.Errorf("transform %s: %v", tf.conf.ID, err)
+		}
+		msg.SetData(b)
+	}
+
+	return []*message.Message{msg}, nil
+}
+
+// number returns the number used to reduce elem.
+func (tf *ReduceArray) number(elem message.Value) (float64, error) {
+	if tf.conf.Field != "" {
+		b, err := json.Marshal(elem.Value())
+		if err != nil {
+			return 0, err
+		}
+
+		elem = message.New().SetData(b).GetValue(tf.conf.Field)
+		if !elem.Exists() {
+			return 0, fmt.Errorf("field %s not found", tf.conf.Field)
+		}
+	}
+
+	if f, ok := numericValue(elem); ok {
+		return f, nil
+	}
+	return 0, fmt.Errorf("value is not a number")
+}
+
+func (tf *ReduceArray) String() string {
+	b, _ := json.Marshal(tf.conf)
+	return string(b)
+}
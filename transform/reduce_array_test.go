@@ -0,0 +1,80 @@
+package transform
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jshlbrd/vibestation/config"
+	"github.com/jshlbrd/vibestation/message"
+)
+
+func TestReduceArray(t *testing.T) {
+	tests := []struct {
+		name      string
+		operation string
+		field     string
+		data      string
+		expected  string
+	}{
+		{
+			"max by field",
+			"max",
+			"$.score",
+			`{"items":[{"id":"a","score":3},{"id":"b","score":7},{"id":"c","score":5}]}`,
+			`{"id":"b","score":7}`,
+		},
+		{
+			"min by field",
+			"min",
+			"$.score",
+			`{"items":[{"id":"a","score":3},{"id":"b","score":7},{"id":"c","score":5}]}`,
+			`{"id":"a","score":3}`,
+		},
+		{"sum of scalars", "sum", "", `{"items":[1,2,3.5]}`, "6.5"},
+		{"avg of scalars", "avg", "", `{"items":[1,2,3]}`, "2"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cfg := config.Config{
+				Type: "reduce_array",
+				Settings: map[string]interface{}{
+					"operation": test.operation,
+					"field":     test.field,
+					"source":    "$.items",
+					"target":    "$.result",
+				},
+			}
+			tf, err := newReduceArray(context.Background(), cfg)
+			if err != nil {
+				t.Fatalf("failed to create reduce_array transform: %v", err)
+			}
+
+			results, err := tf.Transform(context.Background(), message.New().SetData([]byte(test.data)))
+			if err != nil {
+				t.Fatalf("transform failed: %v", err)
+			}
+
+			if got := results[0].GetValue("$.result").String(); got != test.expected {
+				t.Errorf("expected %s, got %s", test.expected, got)
+			}
+		})
+	}
+}
+
+func TestReduceArray_NotNumber(t *testing.T) {
+	cfg := config.Config{
+		Type:     "reduce_array",
+		Settings: map[string]interface{}{"operation": "sum"},
+	}
+	tf, err := newReduceArray(context.Background(), cfg)
+	if err != nil {
+		t.Fatalf("failed to create reduce_array transform: %v", err)
+	}
+
+	for _, data := range []string{`[1,"x"]`, `[1,"NaN"]`, `[1,"Inf"]`} {
+		if _, err := tf.Transform(context.Background(), message.New().SetData([]byte(data))); err == nil {
+			t.Errorf("%s: expected error for non-numeric element, got nil", data)
+		}
+	}
+}
@@ -0,0 +1,185 @@
+package transform
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/jshlbrd/vibestation/config"
+	"github.com/jshlbrd/vibestation/message"
+)
+
+type RenameCaseConfig struct {
+	// Style is the key style, one of snake (snake_case), camel (camelCase),
+	// kebab (kebab-case), or upper (UPPER_SNAKE_CASE).
+	Style string `json:"style"`
+	// Recursive also renames the keys of nested objects.
+	Recursive bool   `json:"recursive"`
+	ID        string `json:"id"`
+}
+
+func (c *RenameCaseConfig) Decode(in interface{}) error {
+	return config.Decode(in, c)
+}
+
+func (c *RenameCaseConfig) Validate() error {
+	switch c.Style {
+	case "snake", "camel", "kebab", "upper":
+	default:
+		return fmt.Errorf("style: unsupported style %q", c.Style)
+	}
+	return nil
+}
+
+func newRenameCase(_ context.Context, cfg config.Config) (*RenameCase, error) {
+	conf := RenameCaseConfig{}
+	if err := conf.Decode(cfg.Settings); err != nil {
+		return nil, fmt.Errorf("transform rename_case: %v", err)
+	}
+	if conf.ID == "" {
+		conf.ID = "rename_case"
+	}
+	if err := conf.Validate(); err != nil {
+		return nil, fmt.Errorf("transform %s: %v", conf.ID, err)
+	}
+
+	tf := RenameCase{
+		conf:     conf,
+		settings: cfg.Settings,
+	}
+	return &tf, nil
+}
+
+// RenameCase rewrites the keys of the data object into a different case
+// style. Keys are split into words at underscores, hyphens, spaces, and case
+// changes, so "userID", "user_id", and "user-id" are all treated alike. If two
+// keys rename to the same key, then an error is returned.
+type RenameCase struct {
+	conf     RenameCaseConfig
+	settings map[string]interface{}
+}
+
+func (tf *RenameCase) Transform(ctx context.Context, msg *message.Message) ([]*message.Message, error) {
+	if msg.IsControl() {
+		return []*message.Message{msg}, nil
+	}
+
+	v, err := decodeJSONNumber(msg.Data())
+	if err != nil {
+		return nil, fmt.Errorf("transform %s: %v", tf.conf.ID, err)
+	}
+	if _, ok := v.(map[string]interface{}); !ok {
+		return nil, fmt.Errorf("transform %s: data is not an object", tf.conf.ID)
+	}
+
+	renamed, err := tf.rename(v, true)
+	if err != nil {
+		return nil, fmt.Errorf("transform %s: %v", tf.conf.ID, err)
+	}
+
+	b, err := json.Marshal(renamed)
+	if err != nil {
+		return nil, fmt.Errorf("transform %s: %v", tf.conf.ID, err)
+	}
+
+	msg.SetData(b)
+	return []*message.Message{msg}, nil
+}
+
+func (tf *RenameCase) String() string {
+	b, _ := json.Marshal(tf.conf)
+	return string(b)
+}
+
+func (tf *RenameCase) rename(v interface{}, top bool) (interface{}, error) {
+	if !top && !tf.conf.Recursive {
+		return v, nil
+	}
+
+	switch val := v.(type) {
+	case map[string]interface{}:
+		m := make(map[string]interface{}, len(val))
+		for k, elem := range val {
+			key := convertCase(k, tf.conf.Style)
+			if _, ok := m[key]; ok {
+				return nil, fmt.Errorf("duplicate key %q after renaming", key)
+			}
+
+			renamed, err := tf.rename(elem, false)
+			if err != nil {
+				return nil, err
+			}
+			m[key] = renamed
+		}
+		return m, nil
+	case []interface{}:
+		for i, elem := range val {
+			renamed, err := tf.rename(elem, false)
+			if err != nil {
+				return nil, err
+			}
+			val[i] = renamed
+		}
+		return val, nil
+	default:
+		return val, nil
+	}
+}
+
+// convertCase converts s into the named case style.
+func convertCase(s, style string) string {
+	words := splitWords(s)
+	switch style {
+	case "camel":
+		for i, w := range words {
+			if i > 0 {
+				r := []rune(w)
+				r[0] = unicode.ToUpper(r[0])
+				words[i] = string(r)
+			}
+		}
+		return strings.Join(words, "")
+	case "kebab":
+		return strings.Join(words, "-")
+	case "upper":
+		return strings.ToUpper(strings.Join(words, "_"))
+	default:
+		return strings.Join(words, "_")
+	}
+}
+
+// splitWords splits s into lowercase words at separators and case changes.
+// A run of capitals is treated as one word, so "HTTPServer" becomes "http"
+// and "server".
+func splitWords(s string) []string {
+	var words []string
+	var current []rune
+
+	runes := []rune(s)
+	for i, r := range runes {
+		if r == '_' || r == '-' || unicode.IsSpace(r) {
+			if len(current) > 0 {
+				words = append(words, string(current))
+				current = nil
+			}
+			continue
+		}
+
+		if unicode.IsUpper(r) && len(current) > 0 {
+			prev := runes[i-1]
+			nextLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if !unicode.IsUpper(prev) || nextLower {
+				words = append(words, string(current))
+				current = nil
+			}
+		}
+		current = append(current, unicode.ToLower(r))
+	}
+	if len(current) > 0 {
+		words = append(words, string(current))
+	}
+
+	return words
+}
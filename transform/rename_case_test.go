@@ -0,0 +1,75 @@
+package transform
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jshlbrd/vibestation/config"
+	"github.com/jshlbrd/vibestation/message"
+)
+
+func TestRenameCase(t *testing.T) {
+	tests := []struct {
+		name      string
+		style     string
+		recursive bool
+		data      string
+		expected  string
+	}{
+		{"snake to camel", "camel", false, `{"first_name":"a","user_id":1}`, `{"firstName":"a","userId":1}`},
+		{"camel to snake", "snake", false, `{"firstName":"a","userID":1}`, `{"first_name":"a","user_id":1}`},
+		{"kebab", "kebab", false, `{"HTTPServer":1}`, `{"http-server":1}`},
+		{"upper", "upper", false, `{"max-retries":3}`, `{"MAX_RETRIES":3}`},
+		{"not recursive", "camel", false, `{"outer_key":{"inner_key":1}}`, `{"outerKey":{"inner_key":1}}`},
+		{"recursive", "camel", true, `{"outer_key":[{"inner_key":1}]}`, `{"outerKey":[{"innerKey":1}]}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := config.Config{
+				Type: "rename_case",
+				Settings: map[string]interface{}{
+					"style":     tt.style,
+					"recursive": tt.recursive,
+				},
+			}
+			tf, err := newRenameCase(context.Background(), cfg)
+			if err != nil {
+				t.Fatalf("failed to create rename_case transform: %v", err)
+			}
+
+			results, err := tf.Transform(context.Background(), message.New().SetData([]byte(tt.data)))
+			if err != nil {
+				t.Fatalf("transform failed: %v", err)
+			}
+			if got := string(results[0].Data()); got != tt.expected {
+				t.Errorf("expected %s, got %s", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestRenameCase_Collision(t *testing.T) {
+	cfg := config.Config{
+		Type:     "rename_case",
+		Settings: map[string]interface{}{"style": "snake"},
+	}
+	tf, err := newRenameCase(context.Background(), cfg)
+	if err != nil {
+		t.Fatalf("failed to create rename_case transform: %v", err)
+	}
+
+	if _, err := tf.Transform(context.Background(), message.New().SetData([]byte(`{"userId":1,"user_id":2}`))); err == nil {
+		t.Fatal("expected error for duplicate keys, got nil")
+	}
+}
+
+func TestRenameCase_InvalidStyle(t *testing.T) {
+	cfg := config.Config{
+		Type:     "rename_case",
+		Settings: map[string]interface{}{"style": "title"},
+	}
+	if _, err := newRenameCase(context.Background(), cfg); err == nil {
+		t.Fatal("expected error for invalid style, got nil")
+	}
+}
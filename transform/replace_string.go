@@ -0,0 +1,114 @@
+package transform
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/jshlbrd/vibestation/config"
+	"github.com/jshlbrd/vibestation/message"
+)
+
+type ReplaceStringConfig struct {
+	// Old is the text that is replaced.
+	Old string `json:"old"`
+	// New is the replacement text.
+	New string `json:"new"`
+	// Count is the maximum number of replacements. Defaults to -1, which
+	// replaces all occurrences.
+	Count *int   `json:"count"`
+	ID    string `json:"id"`
+}
+
+func (c *ReplaceStringConfig) Decode(in interface{}) error {
+	return config.Decode(in, c)
+}
+
+func (c *ReplaceStringConfig) Validate() error {
+	if c.Old == "" {
+		return fmt.Errorf("old: missing required option")
+	}
+	return nil
+}
+
+func newReplaceString(_ context.Context, cfg config.Config) (*ReplaceString, error) {
+	conf := ReplaceStringConfig{}
+	if err := conf.Decode(cfg.Settings); err != nil {
+		return nil, fmt.Errorf("transform replace_string: %v", err)
+	}
+	if conf.ID == "" {
+		conf.ID = "replace_string"
+	}
+	if conf.Count == nil {
+		count := -1
+		conf.Count = &count
+	}
+	if err := conf.Validate(); err != nil {
+		return nil, fmt.Errorf("transform %s: %v", conf.ID, err)
+	}
+
+	var sourcePath string
+	if v, ok := cfg.Settings["source"]; ok {
+		if s, ok := v.(string); ok {
+			sourcePath = s
+		}
+	}
+
+	var targetPath string
+	if v, ok := cfg.Settings["target"]; ok {
+		if s, ok := v.(string); ok {
+			targetPath = s
+		}
+	}
+
+	tf := ReplaceString{
+		conf:       conf,
+		settings:   cfg.Settings,
+		sourcePath: sourcePath,
+		targetPath: targetPath,
+	}
+	return &tf, nil
+}
+
+// ReplaceString replaces occurrences of Old with New, up to Count times.
+type ReplaceString struct {
+	conf       ReplaceStringConfig
+	settings   map[string]interface{}
+	sourcePath string
+	targetPath string
+}
+
+func (tf *ReplaceString) Transform(ctx context.Context, msg *message.Message) ([]*message.Message, error) {
+	if msg.IsControl() {
+		return []*message.Message{msg}, nil
+	}
+
+	var inputData []byte
+	if tf.sourcePath != "" {
+		val := msg.GetValue(tf.sourcePath)
+		if val.Exists() {
+			inputData = val.Bytes()
+		}
+	}
+	if inputData == nil {
+		inputData = msg.Data()
+	}
+
+	replaced := strings.Replace(string(inputData), tf.conf.Old, tf.conf.New, *tf.conf.Count)
+
+	if tf.targetPath != "" {
+		if err := msg.SetValue(tf.targetPath, replaced); err != nil {
+			return nil, fmt.Errorf("transform %s: failed to set target: %v", tf.conf.ID, err)
+		}
+	} else {
+		msg.SetData([]byte(replaced))
+	}
+
+	return []*message.Message{msg}, nil
+}
+
+func (tf *ReplaceString) String() string {
+	b, _ := json.Marshal(tf.conf)
+	return string(b)
+}
@@ -0,0 +1,75 @@
+package transform
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jshlbrd/vibestation/config"
+	"github.com/jshlbrd/vibestation/message"
+)
+
+func TestReplaceString(t *testing.T) {
+	tests := []struct {
+		name     string
+		settings map[string]interface{}
+		expected string
+	}{
+		{"all occurrences", map[string]interface{}{"old": "a", "new": "o"}, "bonono"},
+		{"count limited", map[string]interface{}{"old": "a", "new": "o", "count": 2}, "bonona"},
+		{"zero count", map[string]interface{}{"old": "a", "new": "o", "count": 0}, "banana"},
+		{"delete", map[string]interface{}{"old": "an"}, "ba"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tf, err := newReplaceString(context.Background(), config.Config{Type: "replace_string", Settings: tt.settings})
+			if err != nil {
+				t.Fatalf("failed to create replace_string transform: %v", err)
+			}
+
+			results, err := tf.Transform(context.Background(), message.New().SetData([]byte("banana")))
+			if err != nil {
+				t.Fatalf("transform failed: %v", err)
+			}
+			if got := string(results[0].Data()); got != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestReplaceString_NestedField(t *testing.T) {
+	cfg := config.Config{
+		Type: "replace_string",
+		Settings: map[string]interface{}{
+			"source": "$.nested.field",
+			"target": "$.nested.field",
+			"old":    "-",
+			"new":    "_",
+		},
+	}
+	tf, err := newReplaceString(context.Background(), cfg)
+	if err != nil {
+		t.Fatalf("failed to create replace_string transform: %v", err)
+	}
+
+	results, err := tf.Transform(context.Background(), message.New().SetData([]byte(`{"nested":{"field":"a-b-c","other":1}}`)))
+	if err != nil {
+		t.Fatalf("transform failed: %v", err)
+	}
+
+	expected := `{"nested":{"field":"a_b_c","other":1}}`
+	if got := string(results[0].Data()); got != expected {
+		t.Errorf("expected %s, got %s", expected, got)
+	}
+}
+
+func TestReplaceString_MissingOld(t *testing.T) {
+	cfg := config.Config{
+		Type:     "replace_string",
+		Settings: map[string]interface{}{"new": "x"},
+	}
+	if _, err := newReplaceString(context.Background(), cfg); err == nil {
+		t.Fatal("expected error for missing old, got nil")
+	}
+}
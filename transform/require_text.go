@@ -0,0 +1,92 @@
+package transform
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"unicode/utf8"
+
+	"github.com/jshlbrd/vibestation/config"
+	"github.com/jshlbrd/vibestation/message"
+)
+
+type RequireTextConfig struct {
+	// MaxNullBytes is the number of null bytes that are allowed in the data.
+	// Defaults to 0.
+	MaxNullBytes int `json:"max_null_bytes"`
+	// Mode determines what happens to messages that are not text, either
+	// "error" (default) or "drop".
+	Mode string `json:"mode"`
+	ID   string `json:"id"`
+}
+
+func (c *RequireTextConfig) Decode(in interface{}) error {
+	return config.Decode(in, c)
+}
+
+func (c *RequireTextConfig) Validate() error {
+	if c.MaxNullBytes < 0 {
+		return fmt.Errorf("max_null_bytes: must not be negative")
+	}
+	if c.Mode != "error" && c.Mode != "drop" {
+		return fmt.Errorf("mode: unsupported value %q", c.Mode)
+	}
+	return nil
+}
+
+func newRequireText(_ context.Context, cfg config.Config) (*RequireText, error) {
+	conf := RequireTextConfig{}
+	if err := conf.Decode(cfg.Settings); err != nil {
+		return nil, fmt.Errorf("transform require_text: %v", err)
+	}
+	if conf.ID == "" {
+		conf.ID = "require_text"
+	}
+	if conf.Mode == "" {
+		conf.Mode = "error"
+	}
+	if err := conf.Validate(); err != nil {
+		return nil, fmt.Errorf("transform %s: %v", conf.ID, err)
+	}
+
+	tf := RequireText{
+		conf:     conf,
+		settings: cfg.Settings,
+	}
+	return &tf, nil
+}
+
+// RequireText rejects data messages that are not valid UTF-8 or that contain
+// more than MaxNullBytes null bytes.
+type RequireText struct {
+	conf     RequireTextConfig
+	settings map[string]interface{}
+}
+
+func (tf *RequireText) Transform(ctx context.Context, msg *message.Message) ([]*message.Message, error) {
+	if msg.IsControl() {
+		return []*message.Message{msg}, nil
+	}
+
+	var reason string
+	switch {
+	case !utf8.Valid(msg.Data()):
+		reason = "invalid UTF-8"
+	case bytes.Count(msg.Data(), []byte{0}) > tf.conf.MaxNullBytes:
+		reason = "too many null bytes"
+	default:
+		return []*message.Message{msg}, nil
+	}
+
+	if tf.conf.Mode == "drop" {
+		return nil, nil
+	}
+
+	return nil, fmt.Errorf("transform %s: %s", tf.conf.ID, reason)
+}
+
+func (tf *RequireText) String() string {
+	b, _ := json.Marshal(tf.conf)
+	return string(b)
+}
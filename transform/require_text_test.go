@@ -0,0 +1,56 @@
+package transform
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jshlbrd/vibestation/config"
+	"github.com/jshlbrd/vibestation/message"
+)
+
+func TestRequireText(t *testing.T) {
+	tests := []struct {
+		name      string
+		settings  map[string]interface{}
+		data      string
+		wantCount int
+		wantErr   bool
+	}{
+		{"utf-8", map[string]interface{}{}, "héllo, 世界", 1, false},
+		{"invalid utf-8", map[string]interface{}{}, "abc\xff\xfe", 0, true},
+		{"invalid utf-8 drop", map[string]interface{}{"mode": "drop"}, "abc\xff\xfe", 0, false},
+		{"null bytes", map[string]interface{}{}, "a\x00b", 0, true},
+		{"null bytes under threshold", map[string]interface{}{"max_null_bytes": 2}, "a\x00b\x00", 1, false},
+		{"null bytes over threshold", map[string]interface{}{"max_null_bytes": 1}, "a\x00b\x00", 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tf, err := newRequireText(context.Background(), config.Config{Type: "require_text", Settings: tt.settings})
+			if err != nil {
+				t.Fatalf("failed to create require_text transform: %v", err)
+			}
+
+			results, err := tf.Transform(context.Background(), message.New().SetData([]byte(tt.data)))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("expected error=%v, got %v", tt.wantErr, err)
+			}
+			if len(results) != tt.wantCount {
+				t.Errorf("expected %d messages, got %d", tt.wantCount, len(results))
+			}
+		})
+	}
+}
+
+func TestRequireText_InvalidConfig(t *testing.T) {
+	tests := []map[string]interface{}{
+		{"mode": "ignore"},
+		{"max_null_bytes": -1},
+	}
+
+	for _, settings := range tests {
+		if _, err := newRequireText(context.Background(), config.Config{Type: "require_text", Settings: settings}); err == nil {
+			t.Errorf("expected error for settings %v, got nil", settings)
+		}
+	}
+}
@@ -0,0 +1,58 @@
+package transform
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/jshlbrd/vibestation/config"
+	"github.com/jshlbrd/vibestation/message"
+)
+
+type RestoreFromMetaConfig struct {
+	ID string `json:"id"`
+}
+
+func (c *RestoreFromMetaConfig) Decode(in interface{}) error {
+	return config.Decode(in, c)
+}
+
+func newRestoreFromMeta(_ context.Context, cfg config.Config) (*RestoreFromMeta, error) {
+	conf := RestoreFromMetaConfig{}
+	if err := conf.Decode(cfg.Settings); err != nil {
+		return nil, fmt.Errorf("transform restore_from_meta: %v", err)
+	}
+	if conf.ID == "" {
+		conf.ID = "restore_from_meta"
+	}
+
+	tf := RestoreFromMeta{
+		conf:     conf,
+		settings: cfg.Settings,
+	}
+	return &tf, nil
+}
+
+// RestoreFromMeta replaces the message data with the snapshot stored by
+// snapshot_to_meta. If there is no snapshot, then the message is unchanged.
+type RestoreFromMeta struct {
+	conf     RestoreFromMetaConfig
+	settings map[string]interface{}
+}
+
+func (tf *RestoreFromMeta) Transform(ctx context.Context, msg *message.Message) ([]*message.Message, error) {
+	if msg.IsControl() {
+		return []*message.Message{msg}, nil
+	}
+
+	if val := msg.GetValue(originalKey); val.Exists() {
+		msg.SetData(val.Bytes())
+	}
+
+	return []*message.Message{msg}, nil
+}
+
+func (tf *RestoreFromMeta) String() string {
+	b, _ := json.Marshal(tf.conf)
+	return string(b)
+}
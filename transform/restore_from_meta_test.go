@@ -0,0 +1,50 @@
+package transform
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jshlbrd/vibestation/config"
+	"github.com/jshlbrd/vibestation/message"
+)
+
+func TestRestoreFromMeta(t *testing.T) {
+	ctx := context.Background()
+
+	snapshot, err := newSnapshotToMeta(ctx, config.Config{Type: "snapshot_to_meta"})
+	if err != nil {
+		t.Fatalf("failed to create snapshot_to_meta transform: %v", err)
+	}
+	lower, err := newLowercaseString(ctx, config.Config{Type: "lowercase_string"})
+	if err != nil {
+		t.Fatalf("failed to create lowercase_string transform: %v", err)
+	}
+	restore, err := newRestoreFromMeta(ctx, config.Config{Type: "restore_from_meta"})
+	if err != nil {
+		t.Fatalf("failed to create restore_from_meta transform: %v", err)
+	}
+
+	data := `{"Name": "ALICE"}`
+	results, err := Apply(ctx, []Transformer{snapshot, lower, restore}, message.New().SetData([]byte(data)))
+	if err != nil {
+		t.Fatalf("apply failed: %v", err)
+	}
+	if got := string(results[0].Data()); got != data {
+		t.Errorf("expected %s, got %s", data, got)
+	}
+}
+
+func TestRestoreFromMeta_NoSnapshot(t *testing.T) {
+	tf, err := newRestoreFromMeta(context.Background(), config.Config{Type: "restore_from_meta"})
+	if err != nil {
+		t.Fatalf("failed to create restore_from_meta transform: %v", err)
+	}
+
+	results, err := tf.Transform(context.Background(), message.New().SetData([]byte(`{"a":1}`)))
+	if err != nil {
+		t.Fatalf("transform failed: %v", err)
+	}
+	if got := string(results[0].Data()); got != `{"a":1}` {
+		t.Errorf("expected data to be unchanged, got %s", got)
+	}
+}
@@ -0,0 +1,108 @@
+package transform
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/jshlbrd/vibestation/config"
+	"github.com/jshlbrd/vibestation/message"
+)
+
+type RotateArrayConfig struct {
+	// By is the number of positions to rotate. Positive values rotate left
+	// and negative values rotate right.
+	By int    `json:"by"`
+	ID string `json:"id"`
+}
+
+func (c *RotateArrayConfig) Decode(in interface{}) error {
+	return config.Decode(in, c)
+}
+
+func newRotateArray(_ context.Context, cfg config.Config) (*RotateArray, error) {
+	conf := RotateArrayConfig{}
+	if err := conf.Decode(cfg.Settings); err != nil {
+		return nil, fmt.Errorf("transform rotate_array: %v", err)
+	}
+	if conf.ID == "" {
+		conf.ID = "rotate_array"
+	}
+
+	sourcePath := "$"
+	if v, ok := cfg.Settings["source"]; ok {
+		if s, ok := v.(string); ok && s != "" {
+			sourcePath = s
+		}
+	}
+
+	var targetPath string
+	if v, ok := cfg.Settings["target"]; ok {
+		if s, ok := v.(string); ok {
+			targetPath = s
+		}
+	}
+
+	tf := RotateArray{
+		conf:       conf,
+		settings:   cfg.Settings,
+		sourcePath: sourcePath,
+		targetPath: targetPath,
+	}
+	return &tf, nil
+}
+
+type RotateArray struct {
+	conf       RotateArrayConfig
+	settings   map[string]interface{}
+	sourcePath string
+	targetPath string
+}
+
+func (tf *RotateArray) Transform(ctx context.Context, msg *message.Message) ([]*message.Message, error) {
+	if msg.IsControl() {
+		return []*message.Message{msg}, nil
+	}
+
+	val := msg.GetValue(tf.sourcePath)
+	if !val.IsArray() {
+		return nil, fmt.Errorf("transform %s: source %s is not an array", tf.conf.ID, tf.sourcePath)
+	}
+
+	var items []interface{}
+	for _, item := range val.Array() {
+		items = append(items, item.Value())
+	}
+	rotated := rotateArray(items, tf.conf.By)
+
+	if tf.targetPath != "" {
+		if err := msg.SetValue(tf.targetPath, rotated); err != nil {
+			return nil, fmt.Errorf("transform %s: failed to set target: %v", tf.conf.ID, err)
+		}
+	} else {
+		b, err := json.Marshal(rotated)
+		if err != nil {
+			return nil, fmt.Errorf("transform %s: %v", tf.conf.ID, err)
+		}
+		msg.SetData(b)
+	}
+
+	return []*message.Message{msg}, nil
+}
+
+func (tf *RotateArray) String() string {
+	b, _ := json.Marshal(tf.conf)
+	return string(b)
+}
+
+// rotateArray rotates items left by n positions, or right if n is negative.
+func rotateArray(items []interface{}, n int) []interface{} {
+	result := make([]interface{}, 0, len(items))
+	if len(items) == 0 {
+		return result
+	}
+
+	k := ((n % len(items)) + len(items)) % len(items)
+	result = append(result, items[k:]...)
+	return append(result, items[:k]...)
+}
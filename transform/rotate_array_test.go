@@ -0,0 +1,71 @@
+package transform
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jshlbrd/vibestation/config"
+	"github.com/jshlbrd/vibestation/message"
+)
+
+func TestRotateArray(t *testing.T) {
+	tests := []struct {
+		name     string
+		by       int
+		expected string
+	}{
+		{"positive", 1, `[2,3,4,1]`},
+		{"negative", -1, `[4,1,2,3]`},
+		{"oversized", 6, `[3,4,1,2]`},
+		{"zero", 0, `[1,2,3,4]`},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cfg := config.Config{
+				Type: "rotate_array",
+				Settings: map[string]interface{}{
+					"source": "$.items",
+					"target": "$.rotated",
+					"by":     test.by,
+				},
+			}
+			tf, err := newRotateArray(context.Background(), cfg)
+			if err != nil {
+				t.Fatalf("failed to create rotate_array transform: %v", err)
+			}
+
+			msg := message.New().SetData([]byte(`{"items":[1,2,3,4]}`))
+			results, err := tf.Transform(context.Background(), msg)
+			if err != nil {
+				t.Fatalf("transform failed: %v", err)
+			}
+
+			if got := results[0].GetValue("$.rotated").String(); got != test.expected {
+				t.Errorf("expected %s, got %s", test.expected, got)
+			}
+		})
+	}
+}
+
+func TestRotateArray_Data(t *testing.T) {
+	cfg := config.Config{
+		Type:     "rotate_array",
+		Settings: map[string]interface{}{"by": 2},
+	}
+	tf, err := newRotateArray(context.Background(), cfg)
+	if err != nil {
+		t.Fatalf("failed to create rotate_array transform: %v", err)
+	}
+
+	msg := message.New().SetData([]byte(`["a","b","c"]`))
+	results, err := tf.Transform(context.Background(), msg)
+	if err != nil {
+		t.Fatalf("transform failed: %v", err)
+	}
+
+	expected := `["c","a","b"]`
+	if string(results[0].Data()) != expected {
+		t.Errorf("expected %s, got %s", expected, string(results[0].Data()))
+	}
+}
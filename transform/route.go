@@ -0,0 +1,121 @@
+package transform
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/jshlbrd/vibestation/condition"
+	"github.com/jshlbrd/vibestation/config"
+	"github.com/jshlbrd/vibestation/message"
+)
+
+// RouteRule labels messages that match Condition.
+type RouteRule struct {
+	Condition config.Config `json:"condition"`
+	Label     string        `json:"label"`
+}
+
+type RouteConfig struct {
+	Rules []RouteRule `json:"rules"`
+	// Default is the label used when no rule matches. If empty, then
+	// unmatched messages are not labeled.
+	Default string `json:"default"`
+	ID      string `json:"id"`
+}
+
+func (c *RouteConfig) Decode(in interface{}) error {
+	return config.Decode(in, c)
+}
+
+func (c *RouteConfig) Validate() error {
+	if len(c.Rules) == 0 {
+		return fmt.Errorf("rules: missing required option")
+	}
+	for i, r := range c.Rules {
+		if r.Label == "" {
+			return fmt.Errorf("rules[%d]: missing label", i)
+		}
+	}
+	return nil
+}
+
+func newRoute(ctx context.Context, cfg config.Config) (*Route, error) {
+	conf := RouteConfig{}
+	if err := conf.Decode(cfg.Settings); err != nil {
+		return nil, fmt.Errorf("transform route: %v", err)
+	}
+	if conf.ID == "" {
+		conf.ID = "route"
+	}
+	if err := conf.Validate(); err != nil {
+		return nil, fmt.Errorf("transform %s: %v", conf.ID, err)
+	}
+
+	var conds []condition.Conditioner
+	for _, r := range conf.Rules {
+		c, err := condition.New(ctx, r.Condition)
+		if err != nil {
+			return nil, fmt.Errorf("transform %s: %v", conf.ID, err)
+		}
+		conds = append(conds, c)
+	}
+
+	targetPath := "meta.$._route"
+	if v, ok := cfg.Settings["target"]; ok {
+		if s, ok := v.(string); ok && s != "" {
+			targetPath = s
+		}
+	}
+
+	tf := Route{
+		conf:       conf,
+		conds:      conds,
+		settings:   cfg.Settings,
+		targetPath: targetPath,
+	}
+	return &tf, nil
+}
+
+// Route labels each message with the label of the first matching rule, or
+// the default label. The label is written to meta.$._route unless a target
+// is configured.
+type Route struct {
+	conf       RouteConfig
+	conds      []condition.Conditioner
+	settings   map[string]interface{}
+	targetPath string
+}
+
+func (tf *Route) Transform(ctx context.Context, msg *message.Message) ([]*message.Message, error) {
+	if msg.IsControl() {
+		return []*message.Message{msg}, nil
+	}
+
+	label := tf.conf.Default
+	for i, c := range tf.conds {
+		ok, err := c.Condition(ctx, msg)
+		if err != nil {
+			return nil, fmt.Errorf("transform %s: %v", tf.conf.ID, err)
+		}
+		if ok {
+			label = tf.conf.Rules[i].Label
+			break
+		}
+	}
+
+	if label == "" {
+		return []*message.Message{msg}, nil
+	}
+
+	if err := msg.SetValue(tf.targetPath, label); err != nil {
+		return nil, fmt.Errorf("transform %s: failed to set target: %v", tf.conf.ID, err)
+	}
+
+	return []*message.Message{msg}, nil
+}
+
+func (tf *Route) String() string {
+	b, _ := json.Marshal(tf.conf)
+	return string(b)
+}
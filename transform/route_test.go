@@ -0,0 +1,105 @@
+package transform
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jshlbrd/vibestation/config"
+	"github.com/jshlbrd/vibestation/message"
+)
+
+func TestRoute(t *testing.T) {
+	cfg := config.Config{
+		Type: "route",
+		Settings: map[string]interface{}{
+			"rules": []interface{}{
+				map[string]interface{}{
+					"condition": map[string]interface{}{
+						"type":     "string_equal_to",
+						"settings": map[string]interface{}{"source": "$.level", "value": "error"},
+					},
+					"label": "alerts",
+				},
+				map[string]interface{}{
+					"condition": map[string]interface{}{
+						"type":     "string_contains",
+						"settings": map[string]interface{}{"source": "$.path", "value": "/api/"},
+					},
+					"label": "api",
+				},
+			},
+			"default": "archive",
+		},
+	}
+	tf, err := newRoute(context.Background(), cfg)
+	if err != nil {
+		t.Fatalf("failed to create route transform: %v", err)
+	}
+
+	tests := []struct {
+		data     string
+		expected string
+	}{
+		{`{"level":"error","path":"/api/v1"}`, "alerts"},
+		{`{"level":"info","path":"/api/v1"}`, "api"},
+		{`{"level":"info","path":"/index.html"}`, "archive"},
+	}
+
+	for _, test := range tests {
+		msg := message.New().SetData([]byte(test.data))
+		results, err := tf.Transform(context.Background(), msg)
+		if err != nil {
+			t.Fatalf("transform failed: %v", err)
+		}
+		if got := results[0].GetValue("meta.$._route").String(); got != test.expected {
+			t.Errorf("%s: expected %q, got %q", test.data, test.expected, got)
+		}
+	}
+}
+
+func TestRoute_NoDefault(t *testing.T) {
+	cfg := config.Config{
+		Type: "route",
+		Settings: map[string]interface{}{
+			"rules": []interface{}{
+				map[string]interface{}{
+					"condition": map[string]interface{}{
+						"type":     "exists",
+						"settings": map[string]interface{}{"source": "$.error"},
+					},
+					"label": "errors",
+				},
+			},
+		},
+	}
+	tf, err := newRoute(context.Background(), cfg)
+	if err != nil {
+		t.Fatalf("failed to create route transform: %v", err)
+	}
+
+	msg := message.New().SetData([]byte(`{"ok":true}`))
+	results, err := tf.Transform(context.Background(), msg)
+	if err != nil {
+		t.Fatalf("transform failed: %v", err)
+	}
+	if results[0].GetValue("meta.$._route").Exists() {
+		t.Error("expected unmatched message not to be labeled")
+	}
+}
+
+func TestRoute_InvalidCondition(t *testing.T) {
+	cfg := config.Config{
+		Type: "route",
+		Settings: map[string]interface{}{
+			"rules": []interface{}{
+				map[string]interface{}{
+					"condition": map[string]interface{}{"type": "bogus"},
+					"label":     "x",
+				},
+			},
+		},
+	}
+	if _, err := newRoute(context.Background(), cfg); err == nil {
+		t.Fatal("expected error for unsupported condition, got nil")
+	}
+}
@@ -0,0 +1,89 @@
+package transform
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/jshlbrd/vibestation/config"
+	"github.com/jshlbrd/vibestation/message"
+)
+
+type SampleConfig struct {
+	// Rate is the fraction of messages that are kept, between 0.0 and 1.0.
+	Rate float64 `json:"rate"`
+	// Seed initializes the random number generator. If not set, then the
+	// current time is used.
+	Seed *int64 `json:"seed"`
+	ID   string `json:"id"`
+}
+
+func (c *SampleConfig) Decode(in interface{}) error {
+	return config.Decode(in, c)
+}
+
+func (c *SampleConfig) Validate() error {
+	if c.Rate < 0 || c.Rate > 1 {
+		return fmt.Errorf("rate: must be between 0.0 and 1.0")
+	}
+	return nil
+}
+
+func newSample(_ context.Context, cfg config.Config) (*Sample, error) {
+	conf := SampleConfig{}
+	if err := conf.Decode(cfg.Settings); err != nil {
+		return nil, fmt.Errorf("transform sample: %v", err)
+	}
+	if conf.ID == "" {
+		conf.ID = "sample"
+	}
+	if err := conf.Validate(); err != nil {
+		return nil, fmt.Errorf("transform %s: %v", conf.ID, err)
+	}
+
+	seed := time.Now().UnixNano()
+	if conf.Seed != nil {
+		seed = *conf.Seed
+	}
+
+	tf := Sample{
+		conf:     conf,
+		settings: cfg.Settings,
+		rng:      rand.New(rand.NewSource(seed)),
+	}
+	return &tf, nil
+}
+
+// Sample keeps a random fraction of data messages and drops the rest.
+// Control messages are always kept.
+type Sample struct {
+	conf     SampleConfig
+	settings map[string]interface{}
+
+	mu  sync.Mutex
+	rng *rand.Rand
+}
+
+func (tf *Sample) Transform(ctx context.Context, msg *message.Message) ([]*message.Message, error) {
+	if msg.IsControl() {
+		return []*message.Message{msg}, nil
+	}
+
+	tf.mu.Lock()
+	keep := tf.rng.Float64() < tf.conf.Rate
+	tf.mu.Unlock()
+
+	if !keep {
+		return nil, nil
+	}
+
+	return []*message.Message{msg}, nil
+}
+
+func (tf *Sample) String() string {
+	b, _ := json.Marshal(tf.conf)
+	return string(b)
+}
@@ -0,0 +1,71 @@
+package transform
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jshlbrd/vibestation/config"
+	"github.com/jshlbrd/vibestation/message"
+)
+
+func TestSample(t *testing.T) {
+	cfg := config.Config{
+		Type: "sample",
+		Settings: map[string]interface{}{
+			"rate": 0.5,
+			"seed": 42,
+		},
+	}
+	tf, err := newSample(context.Background(), cfg)
+	if err != nil {
+		t.Fatalf("failed to create sample transform: %v", err)
+	}
+
+	expected := []bool{true, true, false, true, true, true, false, true, true, false}
+	for i, keep := range expected {
+		results, err := tf.Transform(context.Background(), message.New().SetData([]byte(`{"a":1}`)))
+		if err != nil {
+			t.Fatalf("transform failed: %v", err)
+		}
+		if got := len(results) == 1; got != keep {
+			t.Errorf("message %d: expected kept=%v, got kept=%v", i, keep, got)
+		}
+	}
+}
+
+func TestSample_Control(t *testing.T) {
+	cfg := config.Config{
+		Type:     "sample",
+		Settings: map[string]interface{}{"rate": 0.0, "seed": 1},
+	}
+	tf, err := newSample(context.Background(), cfg)
+	if err != nil {
+		t.Fatalf("failed to create sample transform: %v", err)
+	}
+
+	results, err := tf.Transform(context.Background(), message.New().AsControl())
+	if err != nil {
+		t.Fatalf("transform failed: %v", err)
+	}
+	if len(results) != 1 || !results[0].IsControl() {
+		t.Error("expected control message to pass")
+	}
+
+	results, err = tf.Transform(context.Background(), message.New().SetData([]byte(`{"a":1}`)))
+	if err != nil {
+		t.Fatalf("transform failed: %v", err)
+	}
+	if len(results) != 0 {
+		t.Error("expected data message to be dropped with rate 0")
+	}
+}
+
+func TestSample_InvalidRate(t *testing.T) {
+	cfg := config.Config{
+		Type:     "sample",
+		Settings: map[string]interface{}{"rate": 1.5},
+	}
+	if _, err := newSample(context.Background(), cfg); err == nil {
+		t.Fatal("expected error for invalid rate, got nil")
+	}
+}
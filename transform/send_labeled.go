@@ -0,0 +1,106 @@
+package transform
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/jshlbrd/vibestation/config"
+	"github.com/jshlbrd/vibestation/message"
+)
+
+type SendLabeledConfig struct {
+	// Label is written before each output line.
+	Label string `json:"label"`
+	ID    string `json:"id"`
+}
+
+func (c *SendLabeledConfig) Decode(in interface{}) error {
+	return config.Decode(in, c)
+}
+
+func (c *SendLabeledConfig) Validate() error {
+	if c.Label == "" {
+		return fmt.Errorf("label: missing required option")
+	}
+	return nil
+}
+
+func newSendLabeled(_ context.Context, cfg config.Config) (*SendLabeled, error) {
+	conf := SendLabeledConfig{}
+	if err := conf.Decode(cfg.Settings); err != nil {
+		return nil, fmt.Errorf("transform send_labeled: %v", err)
+	}
+	if conf.ID == "" {
+		conf.ID = "send_labeled"
+	}
+	if err := conf.Validate(); err != nil {
+		return nil, fmt.Errorf("transform %s: %v", conf.ID, err)
+	}
+
+	var sourcePath string
+	if v, ok := cfg.Settings["source"]; ok {
+		if s, ok := v.(string); ok {
+			sourcePath = s
+		}
+	}
+
+	tf := SendLabeled{
+		conf:       conf,
+		settings:   cfg.Settings,
+		sourcePath: sourcePath,
+		w:          os.Stdout,
+	}
+	return &tf, nil
+}
+
+// SendLabeled writes message data to stdout with a label before each line,
+// which helps distinguish the outputs of parallel pipeline branches.
+type SendLabeled struct {
+	conf       SendLabeledConfig
+	settings   map[string]interface{}
+	sourcePath string
+
+	mu sync.Mutex
+	w  io.Writer
+}
+
+func (tf *SendLabeled) Transform(ctx context.Context, msg *message.Message) ([]*message.Message, error) {
+	if msg.IsControl() {
+		return []*message.Message{msg}, nil
+	}
+
+	var inputData []byte
+	if tf.sourcePath != "" {
+		val := msg.GetValue(tf.sourcePath)
+		if val.Exists() {
+			inputData = val.Bytes()
+		}
+	}
+	if inputData == nil {
+		inputData = msg.Data()
+	}
+
+	var b strings.Builder
+	for _, line := range strings.Split(strings.TrimSuffix(string(inputData), "\n"), "\n") {
+		fmt.Fprintf(&b, "%s %s\n", tf.conf.Label, line)
+	}
+
+	tf.mu.Lock()
+	defer tf.mu.Unlock()
+
+	if _, err := io.WriteString(tf.w, b.String()); err != nil {
+		return nil, fmt.Errorf("transform %s: %v", tf.conf.ID, err)
+	}
+
+	return []*message.Message{msg}, nil
+}
+
+func (tf *SendLabeled) String() string {
+	b, _ := json.Marshal(tf.conf)
+	return string(b)
+}
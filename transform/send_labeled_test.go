@@ -0,0 +1,70 @@
+package transform
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/jshlbrd/vibestation/config"
+	"github.com/jshlbrd/vibestation/message"
+)
+
+func TestSendLabeled(t *testing.T) {
+	cfg := config.Config{
+		Type:     "send_labeled",
+		Settings: map[string]interface{}{"label": "[branch-a]"},
+	}
+	tf, err := newSendLabeled(context.Background(), cfg)
+	if err != nil {
+		t.Fatalf("failed to create send_labeled transform: %v", err)
+	}
+
+	var buf bytes.Buffer
+	tf.w = &buf
+
+	for _, data := range []string{`{"a":1}`, "line one\nline two\n"} {
+		results, err := tf.Transform(context.Background(), message.New().SetData([]byte(data)))
+		if err != nil {
+			t.Fatalf("transform failed: %v", err)
+		}
+		if len(results) != 1 || string(results[0].Data()) != data {
+			t.Errorf("expected message to pass through unchanged")
+		}
+	}
+
+	expected := "[branch-a] {\"a\":1}\n[branch-a] line one\n[branch-a] line two\n"
+	if got := buf.String(); got != expected {
+		t.Errorf("expected %q, got %q", expected, got)
+	}
+}
+
+func TestSendLabeled_Control(t *testing.T) {
+	cfg := config.Config{
+		Type:     "send_labeled",
+		Settings: map[string]interface{}{"label": "x"},
+	}
+	tf, err := newSendLabeled(context.Background(), cfg)
+	if err != nil {
+		t.Fatalf("failed to create send_labeled transform: %v", err)
+	}
+
+	var buf bytes.Buffer
+	tf.w = &buf
+
+	results, err := tf.Transform(context.Background(), message.New().AsControl())
+	if err != nil {
+		t.Fatalf("transform failed: %v", err)
+	}
+	if len(results) != 1 || !results[0].IsControl() {
+		t.Error("expected control message to pass")
+	}
+	if buf.Len() != 0 {
+		t.Errorf("expected no output for control message, got %q", buf.String())
+	}
+}
+
+func TestSendLabeled_MissingLabel(t *testing.T) {
+	if _, err := newSendLabeled(context.Background(), config.Config{Type: "send_labeled"}); err == nil {
+		t.Fatal("expected error for missing label, got nil")
+	}
+}
@@ -15,16 +15,7 @@ type SendStdoutConfig struct {
 }
 
 func (c *SendStdoutConfig) Decode(in interface{}) error {
-	if in == nil {
-		return nil
-	}
-
-	b, err := json.Marshal(in)
-	if err != nil {
-		return err
-	}
-
-	return json.Unmarshal(b, c)
+	return config.Decode(in, c)
 }
 
 func newSendStdout(_ context.Context, cfg config.Config) (*SendStdout, error) {
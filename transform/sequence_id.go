@@ -0,0 +1,87 @@
+package transform
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"sync"
+
+	"github.com/jshlbrd/vibestation/config"
+	"github.com/jshlbrd/vibestation/message"
+)
+
+type SequenceIDConfig struct {
+	// Prefix is prepended to each sequence number.
+	Prefix string `json:"prefix"`
+	// Start is the first sequence number. Defaults to 1.
+	Start *int64 `json:"start"`
+	ID    string `json:"id"`
+}
+
+func (c *SequenceIDConfig) Decode(in interface{}) error {
+	return config.Decode(in, c)
+}
+
+func newSequenceID(_ context.Context, cfg config.Config) (*SequenceID, error) {
+	conf := SequenceIDConfig{}
+	if err := conf.Decode(cfg.Settings); err != nil {
+		return nil, fmt.Errorf("transform sequence_id: %v", err)
+	}
+	if conf.ID == "" {
+		conf.ID = "sequence_id"
+	}
+
+	next := int64(1)
+	if conf.Start != nil {
+		next = *conf.Start
+	}
+
+	targetPath := "$.id"
+	if v, ok := cfg.Settings["target"]; ok {
+		if s, ok := v.(string); ok && s != "" {
+			targetPath = s
+		}
+	}
+
+	tf := SequenceID{
+		conf:       conf,
+		settings:   cfg.Settings,
+		targetPath: targetPath,
+		next:       next,
+	}
+	return &tf, nil
+}
+
+// SequenceID writes an incrementing identifier (e.g. "evt-1", "evt-2") to
+// each data message. The sequence is shared by all callers of the transform.
+type SequenceID struct {
+	conf       SequenceIDConfig
+	settings   map[string]interface{}
+	targetPath string
+
+	mu   sync.Mutex
+	next int64
+}
+
+func (tf *SequenceID) Transform(ctx context.Context, msg *message.Message) ([]*message.Message, error) {
+	if msg.IsControl() {
+		return []*message.Message{msg}, nil
+	}
+
+	tf.mu.Lock()
+	n := tf.next
+	tf.next++
+	tf.mu.Unlock()
+
+	if err := msg.SetValue(tf.targetPath, tf.conf.Prefix+strconv.FormatInt(n, 10)); err != nil {
+		return nil, fmt.Errorf("transform %s: failed to set target: %v", tf.conf.ID, err)
+	}
+
+	return []*message.Message{msg}, nil
+}
+
+func (tf *SequenceID) String() string {
+	b, _ := json.Marshal(tf.conf)
+	return string(b)
+}
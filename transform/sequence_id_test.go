@@ -0,0 +1,89 @@
+package transform
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/jshlbrd/vibestation/config"
+	"github.com/jshlbrd/vibestation/message"
+)
+
+func TestSequenceID(t *testing.T) {
+	cfg := config.Config{
+		Type:     "sequence_id",
+		Settings: map[string]interface{}{"prefix": "evt-"},
+	}
+	tf, err := newSequenceID(context.Background(), cfg)
+	if err != nil {
+		t.Fatalf("failed to create sequence_id transform: %v", err)
+	}
+
+	for _, want := range []string{"evt-1", "evt-2", "evt-3"} {
+		results, err := tf.Transform(context.Background(), message.New().SetData([]byte(`{"a":1}`)))
+		if err != nil {
+			t.Fatalf("transform failed: %v", err)
+		}
+		if got := results[0].GetValue("$.id").String(); got != want {
+			t.Errorf("expected %q, got %q", want, got)
+		}
+	}
+}
+
+func TestSequenceID_StartAndTarget(t *testing.T) {
+	cfg := config.Config{
+		Type: "sequence_id",
+		Settings: map[string]interface{}{
+			"start":  0,
+			"target": "$.seq",
+		},
+	}
+	tf, err := newSequenceID(context.Background(), cfg)
+	if err != nil {
+		t.Fatalf("failed to create sequence_id transform: %v", err)
+	}
+
+	results, err := tf.Transform(context.Background(), message.New().SetData([]byte(`{}`)))
+	if err != nil {
+		t.Fatalf("transform failed: %v", err)
+	}
+	if got := results[0].GetValue("$.seq").String(); got != "0" {
+		t.Errorf("expected %q, got %q", "0", got)
+	}
+}
+
+func TestSequenceID_Concurrent(t *testing.T) {
+	tf, err := newSequenceID(context.Background(), config.Config{Type: "sequence_id"})
+	if err != nil {
+		t.Fatalf("failed to create sequence_id transform: %v", err)
+	}
+
+	const n = 100
+	var wg sync.WaitGroup
+	ids := make(chan string, n)
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results, err := tf.Transform(context.Background(), message.New().SetData([]byte(`{}`)))
+			if err != nil {
+				t.Errorf("transform failed: %v", err)
+				return
+			}
+			ids <- results[0].GetValue("$.id").String()
+		}()
+	}
+	wg.Wait()
+	close(ids)
+
+	seen := make(map[string]bool)
+	for id := range ids {
+		if seen[id] {
+			t.Errorf("duplicate id %q", id)
+		}
+		seen[id] = true
+	}
+	if len(seen) != n {
+		t.Errorf("expected %d unique ids, got %d", n, len(seen))
+	}
+}
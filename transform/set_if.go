@@ -0,0 +1,101 @@
+package transform
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/jshlbrd/vibestation/condition"
+	"github.com/jshlbrd/vibestation/config"
+	"github.com/jshlbrd/vibestation/message"
+)
+
+type SetIfConfig struct {
+	Condition config.Config `json:"condition"`
+	// Value is written to the target when the condition matches.
+	Value interface{} `json:"value"`
+	// ElseValue is written to the target when the condition does not match.
+	// If not set, then the target is left unchanged.
+	ElseValue interface{} `json:"else_value"`
+	ID        string      `json:"id"`
+}
+
+func (c *SetIfConfig) Decode(in interface{}) error {
+	return config.Decode(in, c)
+}
+
+func newSetIf(ctx context.Context, cfg config.Config) (*SetIf, error) {
+	conf := SetIfConfig{}
+	if err := conf.Decode(cfg.Settings); err != nil {
+		return nil, fmt.Errorf("transform set_if: %v", err)
+	}
+	if conf.ID == "" {
+		conf.ID = "set_if"
+	}
+
+	cnd, err := condition.New(ctx, conf.Condition)
+	if err != nil {
+		return nil, fmt.Errorf("transform %s: %v", conf.ID, err)
+	}
+
+	var targetPath string
+	if v, ok := cfg.Settings["target"]; ok {
+		if s, ok := v.(string); ok {
+			targetPath = s
+		}
+	}
+	if targetPath == "" {
+		return nil, fmt.Errorf("transform %s: target: missing required option", conf.ID)
+	}
+
+	_, hasElse := cfg.Settings["else_value"]
+
+	tf := SetIf{
+		conf:       conf,
+		cnd:        cnd,
+		settings:   cfg.Settings,
+		targetPath: targetPath,
+		hasElse:    hasElse,
+	}
+	return &tf, nil
+}
+
+// SetIf writes Value to the target when the condition matches, and
+// ElseValue (if set) when it does not.
+type SetIf struct {
+	conf       SetIfConfig
+	cnd        condition.Conditioner
+	settings   map[string]interface{}
+	targetPath string
+	hasElse    bool
+}
+
+func (tf *SetIf) Transform(ctx context.Context, msg *message.Message) ([]*message.Message, error) {
+	if msg.IsControl() {
+		return []*message.Message{msg}, nil
+	}
+
+	ok, err := tf.cnd.Condition(ctx, msg)
+	if err != nil {
+		return nil, fmt.Errorf("transform %s: %v", tf.conf.ID, err)
+	}
+
+	value := tf.conf.Value
+	if !ok {
+		if !tf.hasElse {
+			return []*message.Message{msg}, nil
+		}
+		value = tf.conf.ElseValue
+	}
+
+	if err := msg.SetValue(tf.targetPath, value); err != nil {
+		return nil, fmt.Errorf("transform %s: failed to set target: %v", tf.conf.ID, err)
+	}
+
+	return []*message.Message{msg}, nil
+}
+
+func (tf *SetIf) String() string {
+	b, _ := json.Marshal(tf.conf)
+	return string(b)
+}
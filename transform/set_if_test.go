@@ -0,0 +1,76 @@
+package transform
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jshlbrd/vibestation/config"
+	"github.com/jshlbrd/vibestation/message"
+)
+
+func TestSetIf(t *testing.T) {
+	cfg := config.Config{
+		Type: "set_if",
+		Settings: map[string]interface{}{
+			"condition": map[string]interface{}{
+				"type":     "number_greater_than_or_equal_to",
+				"settings": map[string]interface{}{"source": "$.status", "value": 400},
+			},
+			"target":     "$.is_error",
+			"value":      true,
+			"else_value": false,
+		},
+	}
+	tf, err := newSetIf(context.Background(), cfg)
+	if err != nil {
+		t.Fatalf("failed to create set_if transform: %v", err)
+	}
+
+	tests := []struct {
+		data     string
+		expected string
+	}{
+		{`{"status":500}`, "true"},
+		{`{"status":400}`, "true"},
+		{`{"status":399.5}`, "false"},
+		{`{"status":200}`, "false"},
+	}
+
+	for _, test := range tests {
+		msg := message.New().SetData([]byte(test.data))
+		results, err := tf.Transform(context.Background(), msg)
+		if err != nil {
+			t.Fatalf("transform failed: %v", err)
+		}
+		if got := results[0].GetValue("$.is_error").String(); got != test.expected {
+			t.Errorf("%s: expected %s, got %s", test.data, test.expected, got)
+		}
+	}
+}
+
+func TestSetIf_NoElse(t *testing.T) {
+	cfg := config.Config{
+		Type: "set_if",
+		Settings: map[string]interface{}{
+			"condition": map[string]interface{}{
+				"type":     "number_greater_than",
+				"settings": map[string]interface{}{"source": "$.status", "value": 399},
+			},
+			"target": "$.is_error",
+			"value":  true,
+		},
+	}
+	tf, err := newSetIf(context.Background(), cfg)
+	if err != nil {
+		t.Fatalf("failed to create set_if transform: %v", err)
+	}
+
+	msg := message.New().SetData([]byte(`{"status":200}`))
+	results, err := tf.Transform(context.Background(), msg)
+	if err != nil {
+		t.Fatalf("transform failed: %v", err)
+	}
+	if results[0].GetValue("$.is_error").Exists() {
+		t.Error("expected target not to be set when the condition does not match")
+	}
+}
@@ -0,0 +1,166 @@
+package transform
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/jshlbrd/vibestation/config"
+	"github.com/jshlbrd/vibestation/message"
+)
+
+type SetOpsConfig struct {
+	// Left is the JSON path to the first array.
+	Left string `json:"left"`
+	// Right is the JSON path to the second array.
+	Right string `json:"right"`
+	// Operation is one of union, intersection, or difference.
+	Operation string `json:"operation"`
+	ID        string `json:"id"`
+}
+
+func (c *SetOpsConfig) Decode(in interface{}) error {
+	return config.Decode(in, c)
+}
+
+func (c *SetOpsConfig) Validate() error {
+	if c.Left == "" {
+		return fmt.Errorf("left: missing required option")
+	}
+	if c.Right == "" {
+		return fmt.Errorf("right: missing required option")
+	}
+	switch c.Operation {
+	case "union", "intersection", "difference":
+	default:
+		return fmt.Errorf("operation: unsupported operation %q", c.Operation)
+	}
+	return nil
+}
+
+func newSetOps(_ context.Context, cfg config.Config) (*SetOps, error) {
+	conf := SetOpsConfig{}
+	if err := conf.Decode(cfg.Settings); err != nil {
+		return nil, fmt.Errorf("transform set_ops: %v", err)
+	}
+	if conf.ID == "" {
+		conf.ID = "set_ops"
+	}
+	if err := conf.Validate(); err != nil {
+		return nil, fmt.Errorf("transform %s: %v", conf.ID, err)
+	}
+
+	var targetPath string
+	if v, ok := cfg.Settings["target"]; ok {
+		if s, ok := v.(string); ok {
+			targetPath = s
+		}
+	}
+
+	tf := SetOps{
+		conf:       conf,
+		settings:   cfg.Settings,
+		targetPath: targetPath,
+	}
+	return &tf, nil
+}
+
+// SetOps computes the union, intersection, or difference (left minus right)
+// of two arrays. Items are compared by their JSON encoding, the result has no
+// duplicates, and items keep the order in which they were first seen.
+type SetOps struct {
+	conf       SetOpsConfig
+	settings   map[string]interface{}
+	targetPath string
+}
+
+func (tf *SetOps) Transform(ctx context.Context, msg *message.Message) ([]*message.Message, error) {
+	if msg.IsControl() {
+		return []*message.Message{msg}, nil
+	}
+
+	left := msg.GetValue(tf.conf.Left)
+	if !left.IsArray() {
+		return nil, fmt.Errorf("transform %s: left %s is not an array", tf.conf.ID, tf.conf.Left)
+	}
+	right := msg.GetValue(tf.conf.Right)
+	if !right.IsArray() {
+		return nil, fmt.Errorf("transform %s: right %s is not an array", tf.conf.ID, tf.conf.Right)
+	}
+
+	result, err := setOperation(tf.conf.Operation, left.Array(), right.Array())
+	if err != nil {
+		return nil, fmt.Errorf("transform %s: %v", tf.conf.ID, err)
+	}
+
+	if tf.targetPath != "" {
+		if err := msg.SetValue(tf.targetPath, result); err != nil {
+			return nil, fmt.Errorf("transform %s: failed to set target: %v", tf.conf.ID, err)
+		}
+	} else {
+		b, err := json.Marshal(result)
+		if err != nil {
+			return nil, fmt.Errorf("transform %s: %v", tf.conf.ID, err)
+		}
+		msg.SetData(b)
+	}
+
+	return []*message.Message{msg}, nil
+}
+
+func (tf *SetOps) String() string {
+	b, _ := json.Marshal(tf.conf)
+	return string(b)
+}
+
+func setOperation(op string, left, right []message.Value) ([]interface{}, error) {
+	inRight := make(map[string]struct{}, len(right))
+	for _, item := range right {
+		b, err := json.Marshal(item.Value())
+		if err != nil {
+			return nil, err
+		}
+		inRight[string(b)] = struct{}{}
+	}
+
+	seen := make(map[string]struct{})
+	result := make([]interface{}, 0, len(left))
+	add := func(item message.Value, keep func(key string) bool) error {
+		b, err := json.Marshal(item.Value())
+		if err != nil {
+			return err
+		}
+		key := string(b)
+		if _, ok := seen[key]; ok || !keep(key) {
+			return nil
+		}
+		seen[key] = struct{}{}
+		result = append(result, item.Value())
+		return nil
+	}
+
+	var keep func(string) bool
+	switch op {
+	case "union":
+		keep = func(string) bool { return true }
+	case "intersection":
+		keep = func(key string) bool { _, ok := inRight[key]; return ok }
+	case "difference":
+		keep = func(key string) bool { _, ok := inRight[key]; return !ok }
+	}
+
+	for _, item := range left {
+		if err := add(item, keep); err != nil {
+			return nil, err
+		}
+	}
+	if op == "union" {
+		for _, item := range right {
+			if err := add(item, keep); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return result, nil
+}
@@ -0,0 +1,80 @@
+package transform
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jshlbrd/vibestation/config"
+	"github.com/jshlbrd/vibestation/message"
+)
+
+func TestSetOps(t *testing.T) {
+	tests := []struct {
+		operation string
+		expected  string
+	}{
+		{"union", `[1,2,{"a":1,"b":2},3,"x"]`},
+		{"intersection", `[2,{"a":1,"b":2}]`},
+		{"difference", `[1]`},
+	}
+
+	data := `{"left":[1,2,{"a":1,"b":2},2],"right":[{"b":2,"a":1},2.0,3,"x"]}`
+	for _, tt := range tests {
+		t.Run(tt.operation, func(t *testing.T) {
+			cfg := config.Config{
+				Type: "set_ops",
+				Settings: map[string]interface{}{
+					"left":      "$.left",
+					"right":     "$.right",
+					"operation": tt.operation,
+					"target":    "$.result",
+				},
+			}
+			tf, err := newSetOps(context.Background(), cfg)
+			if err != nil {
+				t.Fatalf("failed to create set_ops transform: %v", err)
+			}
+
+			results, err := tf.Transform(context.Background(), message.New().SetData([]byte(data)))
+			if err != nil {
+				t.Fatalf("transform failed: %v", err)
+			}
+			if got := results[0].GetValue("$.result").String(); got != tt.expected {
+				t.Errorf("expected %s, got %s", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestSetOps_NotArray(t *testing.T) {
+	cfg := config.Config{
+		Type: "set_ops",
+		Settings: map[string]interface{}{
+			"left":      "$.left",
+			"right":     "$.right",
+			"operation": "union",
+		},
+	}
+	tf, err := newSetOps(context.Background(), cfg)
+	if err != nil {
+		t.Fatalf("failed to create set_ops transform: %v", err)
+	}
+
+	if _, err := tf.Transform(context.Background(), message.New().SetData([]byte(`{"left":[1],"right":"x"}`))); err == nil {
+		t.Fatal("expected error for non-array input, got nil")
+	}
+}
+
+func TestSetOps_InvalidOperation(t *testing.T) {
+	cfg := config.Config{
+		Type: "set_ops",
+		Settings: map[string]interface{}{
+			"left":      "$.left",
+			"right":     "$.right",
+			"operation": "xor",
+		},
+	}
+	if _, err := newSetOps(context.Background(), cfg); err == nil {
+		t.Fatal("expected error for invalid operation, got nil")
+	}
+}
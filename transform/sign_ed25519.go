@@ -0,0 +1,125 @@
+package transform
+
+import (
+	"context"
+	"crypto/ed25519"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+
+	"github.com/jshlbrd/vibestation/config"
+	"github.com/jshlbrd/vibestation/message"
+)
+
+type SignEd25519Config struct {
+	// Key is the base64-encoded private key, either the 64-byte private key
+	// or the 32-byte seed.
+	Key string `json:"key"`
+	ID  string `json:"id"`
+}
+
+func (c *SignEd25519Config) Decode(in interface{}) error {
+	return config.Decode(in, c)
+}
+
+func (c *SignEd25519Config) Validate() error {
+	if c.Key == "" {
+		return fmt.Errorf("key: missing required option")
+	}
+	return nil
+}
+
+func newSignEd25519(_ context.Context, cfg config.Config) (*SignEd25519, error) {
+	conf := SignEd25519Config{}
+	if err := conf.Decode(cfg.Settings); err != nil {
+		return nil, fmt.Errorf("transform sign_ed25519: %v", err)
+	}
+	if conf.ID == "" {
+		conf.ID = "sign_ed25519"
+	}
+	if err := conf.Validate(); err != nil {
+		return nil, fmt.Errorf("transform %s: %v", conf.ID, err)
+	}
+
+	key, err := base64.StdEncoding.DecodeString(conf.Key)
+	if err != nil {
+		return nil, fmt.Errorf("transform %s: key: %v", conf.ID, err)
+	}
+
+	var privateKey ed25519.PrivateKey
+	switch len(key) {
+	case ed25519.PrivateKeySize:
+		privateKey = ed25519.PrivateKey(key)
+	case ed25519.SeedSize:
+		privateKey = ed25519.NewKeyFromSeed(key)
+	default:
+		return nil, fmt.Errorf("transform %s: key: invalid length %d", conf.ID, len(key))
+	}
+
+	var sourcePath string
+	if v, ok := cfg.Settings["source"]; ok {
+		if s, ok := v.(string); ok {
+			sourcePath = s
+		}
+	}
+
+	var targetPath string
+	if v, ok := cfg.Settings["target"]; ok {
+		if s, ok := v.(string); ok {
+			targetPath = s
+		}
+	}
+
+	tf := SignEd25519{
+		conf:       conf,
+		settings:   cfg.Settings,
+		sourcePath: sourcePath,
+		targetPath: targetPath,
+		privateKey: privateKey,
+	}
+	return &tf, nil
+}
+
+// SignEd25519 signs the data with an Ed25519 private key and writes the
+// base64-encoded signature.
+type SignEd25519 struct {
+	conf       SignEd25519Config
+	settings   map[string]interface{}
+	sourcePath string
+	targetPath string
+	privateKey ed25519.PrivateKey
+}
+
+func (tf *SignEd25519) Transform(ctx context.Context, msg *message.Message) ([]*message.Message, error) {
+	if msg.IsControl() {
+		return []*message.Message{msg}, nil
+	}
+
+	var inputData []byte
+	if tf.sourcePath != "" {
+		val := msg.GetValue(tf.sourcePath)
+		if val.Exists() {
+			inputData = val.Bytes()
+		}
+	}
+	if inputData == nil {
+		inputData = msg.Data()
+	}
+
+	sig := base64.StdEncoding.EncodeToString(ed25519.Sign(tf.privateKey, inputData))
+
+	if tf.targetPath != "" {
+		if err := msg.SetValue(tf.targetPath, sig); err != nil {
+			return nil, fmt.Errorf("transform %s: failed to set target: %v", tf.conf.ID, err)
+		}
+	} else {
+		msg.SetData([]byte(sig))
+	}
+
+	return []*message.Message{msg}, nil
+}
+
+func (tf *SignEd25519) String() string {
+	b, _ := json.Marshal(tf.conf)
+	return string(b)
+}
@@ -0,0 +1,53 @@
+package transform
+
+import (
+	"context"
+	"crypto/ed25519"
+	"encoding/base64"
+	"testing"
+
+	"github.com/jshlbrd/vibestation/config"
+	"github.com/jshlbrd/vibestation/message"
+)
+
+// testEd25519Seed is a fixed seed so that signatures are reproducible.
+var testEd25519Seed = []byte("0123456789abcdef0123456789abcdef")
+
+func TestSignEd25519(t *testing.T) {
+	cfg := config.Config{
+		Type: "sign_ed25519",
+		Settings: map[string]interface{}{
+			"key":    base64.StdEncoding.EncodeToString(testEd25519Seed),
+			"source": "$.payload",
+			"target": "$.sig",
+		},
+	}
+	tf, err := newSignEd25519(context.Background(), cfg)
+	if err != nil {
+		t.Fatalf("failed to create sign_ed25519 transform: %v", err)
+	}
+
+	results, err := tf.Transform(context.Background(), message.New().SetData([]byte(`{"payload":"hello"}`)))
+	if err != nil {
+		t.Fatalf("transform failed: %v", err)
+	}
+
+	sig, err := base64.StdEncoding.DecodeString(results[0].GetValue("$.sig").String())
+	if err != nil {
+		t.Fatalf("signature is not base64: %v", err)
+	}
+	pub := ed25519.NewKeyFromSeed(testEd25519Seed).Public().(ed25519.PublicKey)
+	if !ed25519.Verify(pub, []byte("hello"), sig) {
+		t.Error("expected signature to verify")
+	}
+}
+
+func TestSignEd25519_InvalidKey(t *testing.T) {
+	cfg := config.Config{
+		Type:     "sign_ed25519",
+		Settings: map[string]interface{}{"key": base64.StdEncoding.EncodeToString([]byte("short"))},
+	}
+	if _, err := newSignEd25519(context.Background(), cfg); err == nil {
+		t.Fatal("expected error for invalid key length, got nil")
+	}
+}
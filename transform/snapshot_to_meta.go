@@ -0,0 +1,63 @@
+package transform
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/jshlbrd/vibestation/config"
+	"github.com/jshlbrd/vibestation/message"
+)
+
+// originalKey is the metadata path where snapshot_to_meta stores the original
+// message data and where restore_from_meta reads it from.
+const originalKey = "meta.$._original"
+
+type SnapshotToMetaConfig struct {
+	ID string `json:"id"`
+}
+
+func (c *SnapshotToMetaConfig) Decode(in interface{}) error {
+	return config.Decode(in, c)
+}
+
+func newSnapshotToMeta(_ context.Context, cfg config.Config) (*SnapshotToMeta, error) {
+	conf := SnapshotToMetaConfig{}
+	if err := conf.Decode(cfg.Settings); err != nil {
+		return nil, fmt.Errorf("transform snapshot_to_meta: %v", err)
+	}
+	if conf.ID == "" {
+		conf.ID = "snapshot_to_meta"
+	}
+
+	tf := SnapshotToMeta{
+		conf:     conf,
+		settings: cfg.Settings,
+	}
+	return &tf, nil
+}
+
+// SnapshotToMeta copies the message data verbatim into metadata at
+// originalKey so that it can be recovered after later transforms change the
+// data. It should run early in a pipeline.
+type SnapshotToMeta struct {
+	conf     SnapshotToMetaConfig
+	settings map[string]interface{}
+}
+
+func (tf *SnapshotToMeta) Transform(ctx context.Context, msg *message.Message) ([]*message.Message, error) {
+	if msg.IsControl() {
+		return []*message.Message{msg}, nil
+	}
+
+	if err := msg.SetValue(originalKey, string(msg.Data())); err != nil {
+		return nil, fmt.Errorf("transform %s: %v", tf.conf.ID, err)
+	}
+
+	return []*message.Message{msg}, nil
+}
+
+func (tf *SnapshotToMeta) String() string {
+	b, _ := json.Marshal(tf.conf)
+	return string(b)
+}
@@ -0,0 +1,35 @@
+package transform
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jshlbrd/vibestation/config"
+	"github.com/jshlbrd/vibestation/message"
+)
+
+func TestSnapshotToMeta(t *testing.T) {
+	ctx := context.Background()
+
+	snapshot, err := newSnapshotToMeta(ctx, config.Config{Type: "snapshot_to_meta"})
+	if err != nil {
+		t.Fatalf("failed to create snapshot_to_meta transform: %v", err)
+	}
+	lower, err := newLowercaseString(ctx, config.Config{Type: "lowercase_string"})
+	if err != nil {
+		t.Fatalf("failed to create lowercase_string transform: %v", err)
+	}
+
+	data := `{"Name": "ALICE"}`
+	results, err := Apply(ctx, []Transformer{snapshot, lower}, message.New().SetData([]byte(data)))
+	if err != nil {
+		t.Fatalf("apply failed: %v", err)
+	}
+
+	if got := string(results[0].Data()); got != `{"name": "alice"}` {
+		t.Errorf("expected data to be changed, got %s", got)
+	}
+	if got := results[0].GetValue(originalKey).String(); got != data {
+		t.Errorf("expected snapshot %s, got %s", data, got)
+	}
+}
@@ -0,0 +1,136 @@
+package transform
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"unicode/utf8"
+
+	"github.com/jshlbrd/vibestation/config"
+	"github.com/jshlbrd/vibestation/message"
+)
+
+type SplitCSVRecordsConfig struct {
+	// Delimiter is the field delimiter. Defaults to ",".
+	Delimiter string `json:"delimiter"`
+	// Header treats the first record as a header row. Each following record
+	// is emitted as an object keyed by the header, instead of an array.
+	Header bool   `json:"header"`
+	ID     string `json:"id"`
+}
+
+func (c *SplitCSVRecordsConfig) Decode(in interface{}) error {
+	return config.Decode(in, c)
+}
+
+func (c *SplitCSVRecordsConfig) Validate() error {
+	if utf8.RuneCountInString(c.Delimiter) != 1 {
+		return fmt.Errorf("delimiter: must be a single character")
+	}
+	return nil
+}
+
+func newSplitCSVRecords(_ context.Context, cfg config.Config) (*SplitCSVRecords, error) {
+	conf := SplitCSVRecordsConfig{}
+	if err := conf.Decode(cfg.Settings); err != nil {
+		return nil, fmt.Errorf("transform split_csv_records: %v", err)
+	}
+	if conf.ID == "" {
+		conf.ID = "split_csv_records"
+	}
+	if conf.Delimiter == "" {
+		conf.Delimiter = ","
+	}
+	if err := conf.Validate(); err != nil {
+		return nil, fmt.Errorf("transform %s: %v", conf.ID, err)
+	}
+
+	var sourcePath string
+	if v, ok := cfg.Settings["source"]; ok {
+		if s, ok := v.(string); ok {
+			sourcePath = s
+		}
+	}
+
+	tf := SplitCSVRecords{
+		conf:       conf,
+		settings:   cfg.Settings,
+		sourcePath: sourcePath,
+	}
+	return &tf, nil
+}
+
+// SplitCSVRecords splits CSV data into one message per record. Unlike
+// split_string, quoted fields that contain newlines stay in a single record.
+// Each record is emitted as a JSON array of fields, or as an object if Header
+// is set.
+type SplitCSVRecords struct {
+	conf       SplitCSVRecordsConfig
+	settings   map[string]interface{}
+	sourcePath string
+}
+
+func (tf *SplitCSVRecords) Transform(ctx context.Context, msg *message.Message) ([]*message.Message, error) {
+	if msg.IsControl() {
+		return []*message.Message{msg}, nil
+	}
+
+	var inputData []byte
+	if tf.sourcePath != "" {
+		val := msg.GetValue(tf.sourcePath)
+		if val.Exists() {
+			inputData = val.Bytes()
+		}
+	}
+	if inputData == nil {
+		inputData = msg.Data()
+	}
+
+	r := csv.NewReader(bytes.NewReader(inputData))
+	r.Comma, _ = utf8.DecodeRuneInString(tf.conf.Delimiter)
+	r.FieldsPerRecord = -1
+
+	var header []string
+	var result []*message.Message
+	for {
+		record, err := r.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("transform %s: %v", tf.conf.ID, err)
+		}
+
+		if tf.conf.Header && header == nil {
+			header = record
+			continue
+		}
+
+		var v interface{} = record
+		if tf.conf.Header {
+			obj := make(map[string]string)
+			for i, field := range record {
+				if i < len(header) {
+					obj[header[i]] = field
+				}
+			}
+			v = obj
+		}
+
+		b, err := json.Marshal(v)
+		if err != nil {
+			return nil, fmt.Errorf("transform %s: %v", tf.conf.ID, err)
+		}
+		result = append(result, message.New().SetData(b))
+	}
+
+	return result, nil
+}
+
+func (tf *SplitCSVRecords) String() string {
+	b, _ := json.Marshal(tf.conf)
+	return string(b)
+}
@@ -0,0 +1,74 @@
+package transform
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jshlbrd/vibestation/config"
+	"github.com/jshlbrd/vibestation/message"
+)
+
+func TestSplitCSVRecords(t *testing.T) {
+	tf, err := newSplitCSVRecords(context.Background(), config.Config{Type: "split_csv_records"})
+	if err != nil {
+		t.Fatalf("failed to create split_csv_records transform: %v", err)
+	}
+
+	data := "1,\"first line\nsecond line\"\n2,plain\n"
+	results, err := tf.Transform(context.Background(), message.New().SetData([]byte(data)))
+	if err != nil {
+		t.Fatalf("transform failed: %v", err)
+	}
+	if len(results) != 2 {
+		t.Fatalf("expected 2 records, got %d", len(results))
+	}
+
+	expected := []string{
+		`["1","first line\nsecond line"]`,
+		`["2","plain"]`,
+	}
+	for i, r := range results {
+		if string(r.Data()) != expected[i] {
+			t.Errorf("record %d: expected %s, got %s", i, expected[i], string(r.Data()))
+		}
+	}
+}
+
+func TestSplitCSVRecords_Header(t *testing.T) {
+	cfg := config.Config{
+		Type: "split_csv_records",
+		Settings: map[string]interface{}{
+			"header":    true,
+			"delimiter": ";",
+		},
+	}
+	tf, err := newSplitCSVRecords(context.Background(), cfg)
+	if err != nil {
+		t.Fatalf("failed to create split_csv_records transform: %v", err)
+	}
+
+	data := "id;note\n1;\"a;b\nc\"\n"
+	results, err := tf.Transform(context.Background(), message.New().SetData([]byte(data)))
+	if err != nil {
+		t.Fatalf("transform failed: %v", err)
+	}
+	if len(results) != 1 {
+		t.Fatalf("expected 1 record, got %d", len(results))
+	}
+
+	expected := `{"id":"1","note":"a;b\nc"}`
+	if got := string(results[0].Data()); got != expected {
+		t.Errorf("expected %s, got %s", expected, got)
+	}
+}
+
+func TestSplitCSVRecords_Malformed(t *testing.T) {
+	tf, err := newSplitCSVRecords(context.Background(), config.Config{Type: "split_csv_records"})
+	if err != nil {
+		t.Fatalf("failed to create split_csv_records transform: %v", err)
+	}
+
+	if _, err := tf.Transform(context.Background(), message.New().SetData([]byte("a,\"unterminated\n"))); err == nil {
+		t.Fatal("expected error for unterminated quote, got nil")
+	}
+}
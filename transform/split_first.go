@@ -0,0 +1,110 @@
+package transform
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/jshlbrd/vibestation/config"
+	"github.com/jshlbrd/vibestation/message"
+)
+
+type SplitFirstConfig struct {
+	Separator string `json:"separator"`
+	// TargetKey is the JSON path where the text before the separator is written.
+	TargetKey string `json:"target_key"`
+	// TargetValue is the JSON path where the text after the separator is written.
+	TargetValue string `json:"target_value"`
+	ID          string `json:"id"`
+}
+
+func (c *SplitFirstConfig) Decode(in interface{}) error {
+	return config.Decode(in, c)
+}
+
+func (c *SplitFirstConfig) Validate() error {
+	if c.Separator == "" {
+		return fmt.Errorf("separator: missing required option")
+	}
+	if c.TargetKey == "" {
+		return fmt.Errorf("target_key: missing required option")
+	}
+	if c.TargetValue == "" {
+		return fmt.Errorf("target_value: missing required option")
+	}
+	return nil
+}
+
+func newSplitFirst(_ context.Context, cfg config.Config) (*SplitFirst, error) {
+	conf := SplitFirstConfig{}
+	if err := conf.Decode(cfg.Settings); err != nil {
+		return nil, fmt.Errorf("transform split_first: %v", err)
+	}
+	if conf.ID == "" {
+		conf.ID = "split_first"
+	}
+	if err := conf.Validate(); err != nil {
+		return nil, fmt.Errorf("transform %s: %v", conf.ID, err)
+	}
+
+	var sourcePath string
+	if v, ok := cfg.Settings["source"]; ok {
+		if s, ok := v.(string); ok {
+			sourcePath = s
+		}
+	}
+
+	tf := SplitFirst{
+		conf:       conf,
+		settings:   cfg.Settings,
+		sourcePath: sourcePath,
+	}
+	return &tf, nil
+}
+
+// SplitFirst splits data on the first occurrence of the separator, writing
+// the head to TargetKey and the tail to TargetValue. If the separator is not
+// found, then the entire input is written to TargetKey and TargetValue is empty.
+type SplitFirst struct {
+	conf       SplitFirstConfig
+	settings   map[string]interface{}
+	sourcePath string
+}
+
+func (tf *SplitFirst) Transform(ctx context.Context, msg *message.Message) ([]*message.Message, error) {
+	if msg.IsControl() {
+		return []*message.Message{msg}, nil
+	}
+
+	var inputData []byte
+	if tf.sourcePath != "" {
+		val := msg.GetValue(tf.sourcePath)
+		if val.Exists() {
+			inputData = val.Bytes()
+		}
+	}
+	if inputData == nil {
+		inputData = msg.Data()
+	}
+
+	parts := strings.SplitN(string(inputData), tf.conf.Separator, 2)
+	key, value := parts[0], ""
+	if len(parts) == 2 {
+		value = parts[1]
+	}
+
+	if err := msg.SetValue(tf.conf.TargetKey, key); err != nil {
+		return nil, fmt.Errorf("transform %s: failed to set target_key: %v", tf.conf.ID, err)
+	}
+	if err := msg.SetValue(tf.conf.TargetValue, value); err != nil {
+		return nil, fmt.Errorf("transform %s: failed to set target_value: %v", tf.conf.ID, err)
+	}
+
+	return []*message.Message{msg}, nil
+}
+
+func (tf *SplitFirst) String() string {
+	b, _ := json.Marshal(tf.conf)
+	return string(b)
+}
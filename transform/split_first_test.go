@@ -0,0 +1,66 @@
+package transform
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jshlbrd/vibestation/config"
+	"github.com/jshlbrd/vibestation/message"
+)
+
+func TestSplitFirst(t *testing.T) {
+	tests := []struct {
+		input string
+		key   string
+		value string
+	}{
+		{"key=value with = signs", "key", "value with = signs"},
+		{"a=b", "a", "b"},
+		{"novalue", "novalue", ""},
+	}
+
+	cfg := config.Config{
+		Type: "split_first",
+		Settings: map[string]interface{}{
+			"source":       "$.pair",
+			"separator":    "=",
+			"target_key":   "$.key",
+			"target_value": "$.value",
+		},
+	}
+	tf, err := newSplitFirst(context.Background(), cfg)
+	if err != nil {
+		t.Fatalf("failed to create split_first transform: %v", err)
+	}
+
+	for _, test := range tests {
+		msg := message.New()
+		if err := msg.SetValue("$.pair", test.input); err != nil {
+			t.Fatal(err)
+		}
+
+		results, err := tf.Transform(context.Background(), msg)
+		if err != nil {
+			t.Fatalf("transform failed: %v", err)
+		}
+		if got := results[0].GetValue("$.key").String(); got != test.key {
+			t.Errorf("%q: expected key %q, got %q", test.input, test.key, got)
+		}
+		if got := results[0].GetValue("$.value").String(); got != test.value {
+			t.Errorf("%q: expected value %q, got %q", test.input, test.value, got)
+		}
+	}
+}
+
+func TestSplitFirst_MissingSeparator(t *testing.T) {
+	cfg := config.Config{
+		Type: "split_first",
+		Settings: map[string]interface{}{
+			"target_key":   "$.key",
+			"target_value": "$.value",
+		},
+	}
+	if _, err := newSplitFirst(context.Background(), cfg); err == nil {
+		t.Fatal("expected error for missing separator, got nil")
+	}
+}
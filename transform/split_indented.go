@@ -0,0 +1,120 @@
+package transform
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/jshlbrd/vibestation/config"
+	"github.com/jshlbrd/vibestation/message"
+)
+
+type SplitIndentedConfig struct {
+	ID string `json:"id"`
+}
+
+func (c *SplitIndentedConfig) Decode(in interface{}) error {
+	return config.Decode(in, c)
+}
+
+func newSplitIndented(_ context.Context, cfg config.Config) (*SplitIndented, error) {
+	conf := SplitIndentedConfig{}
+	if err := conf.Decode(cfg.Settings); err != nil {
+		return nil, fmt.Errorf("transform split_indented: %v", err)
+	}
+	if conf.ID == "" {
+		conf.ID = "split_indented"
+	}
+	var sourcePath string
+	if v, ok := cfg.Settings["source"]; ok {
+		if s, ok := v.(string); ok {
+			sourcePath = s
+		}
+	}
+	var targetPath string
+	if v, ok := cfg.Settings["target"]; ok {
+		if s, ok := v.(string); ok {
+			targetPath = s
+		}
+	}
+
+	tf := SplitIndented{
+		conf:       conf,
+		settings:   cfg.Settings,
+		sourcePath: sourcePath,
+		targetPath: targetPath,
+	}
+	return &tf, nil
+}
+
+// SplitIndented splits multiline text into records, where each record is a
+// top-level line followed by any indented (space or tab) continuation lines,
+// such as a stack trace. Blank lines are skipped.
+type SplitIndented struct {
+	conf       SplitIndentedConfig
+	settings   map[string]interface{}
+	sourcePath string
+	targetPath string
+}
+
+func (tf *SplitIndented) Transform(ctx context.Context, msg *message.Message) ([]*message.Message, error) {
+	if msg.IsControl() {
+		return []*message.Message{msg}, nil
+	}
+
+	var inputData []byte
+	if tf.sourcePath != "" {
+		val := msg.GetValue(tf.sourcePath)
+		if val.Exists() {
+			inputData = val.Bytes()
+		}
+	}
+	if inputData == nil {
+		inputData = msg.Data()
+	}
+
+	var result []*message.Message
+	for _, block := range splitIndented(string(inputData)) {
+		var newMsg *message.Message
+		if tf.targetPath != "" {
+			newMsg = message.New().SetData([]byte("{}"))
+			if err := newMsg.SetValue(tf.targetPath, block); err != nil {
+				return nil, fmt.Errorf("transform %s: failed to set target: %v", tf.conf.ID, err)
+			}
+		} else {
+			newMsg = message.New().SetData([]byte(block))
+		}
+		result = append(result, newMsg)
+	}
+
+	return result, nil
+}
+
+func (tf *SplitIndented) String() string {
+	b, _ := json.Marshal(tf.conf)
+	return string(b)
+}
+
+// splitIndented groups lines into blocks that start at an unindented line.
+// Indented lines that appear before any unindented line form their own block.
+func splitIndented(s string) []string {
+	var blocks []string
+	var current []string
+	for _, line := range strings.Split(strings.ReplaceAll(s, "\r\n", "\n"), "\n") {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		indented := line[0] == ' ' || line[0] == '\t'
+		if !indented && len(current) > 0 {
+			blocks = append(blocks, strings.Join(current, "\n"))
+			current = nil
+		}
+		current = append(current, line)
+	}
+	if len(current) > 0 {
+		blocks = append(blocks, strings.Join(current, "\n"))
+	}
+
+	return blocks
+}
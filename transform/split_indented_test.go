@@ -0,0 +1,71 @@
+package transform
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jshlbrd/vibestation/config"
+	"github.com/jshlbrd/vibestation/message"
+)
+
+func TestSplitIndented(t *testing.T) {
+	tf, err := newSplitIndented(context.Background(), config.Config{Type: "split_indented"})
+	if err != nil {
+		t.Fatalf("failed to create split_indented transform: %v", err)
+	}
+
+	input := "Exception in thread main\n" +
+		"    at com.example.A.run(A.java:10)\n" +
+		"\tat com.example.B.run(B.java:20)\n" +
+		"\n" +
+		"Error: connection refused\n" +
+		"  retrying in 5s\n" +
+		"  giving up\n"
+
+	results, err := tf.Transform(context.Background(), message.New().SetData([]byte(input)))
+	if err != nil {
+		t.Fatalf("transform failed: %v", err)
+	}
+
+	expected := []string{
+		"Exception in thread main\n    at com.example.A.run(A.java:10)\n\tat com.example.B.run(B.java:20)",
+		"Error: connection refused\n  retrying in 5s\n  giving up",
+	}
+	if len(results) != len(expected) {
+		t.Fatalf("expected %d messages, got %d", len(expected), len(results))
+	}
+	for i, want := range expected {
+		if got := string(results[i].Data()); got != want {
+			t.Errorf("message %d: expected %q, got %q", i, want, got)
+		}
+	}
+}
+
+func TestSplitIndented_Target(t *testing.T) {
+	cfg := config.Config{
+		Type: "split_indented",
+		Settings: map[string]interface{}{
+			"source": "$.log",
+			"target": "$.record",
+		},
+	}
+	tf, err := newSplitIndented(context.Background(), cfg)
+	if err != nil {
+		t.Fatalf("failed to create split_indented transform: %v", err)
+	}
+
+	msg := message.New().SetData([]byte(`{"log":"a\n  b\nc"}`))
+	results, err := tf.Transform(context.Background(), msg)
+	if err != nil {
+		t.Fatalf("transform failed: %v", err)
+	}
+	if len(results) != 2 {
+		t.Fatalf("expected 2 messages, got %d", len(results))
+	}
+	if got := results[0].GetValue("$.record").String(); got != "a\n  b" {
+		t.Errorf("expected %q, got %q", "a\n  b", got)
+	}
+	if got := results[1].GetValue("$.record").String(); got != "c" {
+		t.Errorf("expected %q, got %q", "c", got)
+	}
+}
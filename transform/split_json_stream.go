@@ -0,0 +1,114 @@
+package transform
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/jshlbrd/vibestation/config"
+	"github.com/jshlbrd/vibestation/message"
+)
+
+type SplitJSONStreamConfig struct {
+	ID string `json:"id"`
+}
+
+func (c *SplitJSONStreamConfig) Decode(in interface{}) error {
+	return config.Decode(in, c)
+}
+
+func newSplitJSONStream(_ context.Context, cfg config.Config) (*SplitJSONStream, error) {
+	conf := SplitJSONStreamConfig{}
+	if err := conf.Decode(cfg.Settings); err != nil {
+		return nil, fmt.Errorf("transform split_json_stream: %v", err)
+	}
+	if conf.ID == "" {
+		conf.ID = "split_json_stream"
+	}
+
+	var sourcePath string
+	if v, ok := cfg.Settings["source"]; ok {
+		if s, ok := v.(string); ok {
+			sourcePath = s
+		}
+	}
+
+	var targetPath string
+	if v, ok := cfg.Settings["target"]; ok {
+		if s, ok := v.(string); ok {
+			targetPath = s
+		}
+	}
+
+	tf := SplitJSONStream{
+		conf:       conf,
+		settings:   cfg.Settings,
+		sourcePath: sourcePath,
+		targetPath: targetPath,
+	}
+	return &tf, nil
+}
+
+// SplitJSONStream emits one message per top-level value in a stream of
+// concatenated JSON values (e.g. `{...}{...}`).
+type SplitJSONStream struct {
+	conf       SplitJSONStreamConfig
+	settings   map[string]interface{}
+	sourcePath string
+	targetPath string
+}
+
+func (tf *SplitJSONStream) Transform(ctx context.Context, msg *message.Message) ([]*message.Message, error) {
+	if msg.IsControl() {
+		return []*message.Message{msg}, nil
+	}
+
+	var inputData []byte
+	if tf.sourcePath != "" {
+		val := msg.GetValue(tf.sourcePath)
+		if val.Exists() {
+			inputData = val.Bytes()
+		}
+	}
+	if inputData == nil {
+		inputData = msg.Data()
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(inputData))
+	dec.UseNumber()
+
+	var result []*message.Message
+	for {
+		var raw json.RawMessage
+		offset := dec.InputOffset()
+		if err := dec.Decode(&raw); err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, fmt.Errorf("transform %s: invalid JSON value at offset %d: %v", tf.conf.ID, offset, err)
+		}
+
+		var newMsg *message.Message
+		if tf.targetPath != "" {
+			var value interface{}
+			if err := json.Unmarshal(raw, &value); err != nil {
+				return nil, fmt.Errorf("transform %s: %v", tf.conf.ID, err)
+			}
+			newMsg = message.New().SetData([]byte("{}"))
+			if err := newMsg.SetValue(tf.targetPath, value); err != nil {
+				return nil, fmt.Errorf("transform %s: failed to set target: %v", tf.conf.ID, err)
+			}
+		} else {
+			newMsg = message.New().SetData([]byte(raw))
+		}
+		result = append(result, newMsg)
+	}
+
+	return result, nil
+}
+
+func (tf *SplitJSONStream) String() string {
+	b, _ := json.Marshal(tf.conf)
+	return string(b)
+}
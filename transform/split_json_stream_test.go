@@ -0,0 +1,76 @@
+package transform
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jshlbrd/vibestation/config"
+	"github.com/jshlbrd/vibestation/message"
+)
+
+func TestSplitJSONStream_Concatenated(t *testing.T) {
+	tf, err := newSplitJSONStream(context.Background(), config.Config{Type: "split_json_stream"})
+	if err != nil {
+		t.Fatalf("failed to create split_json_stream transform: %v", err)
+	}
+
+	msg := message.New().SetData([]byte("{\"a\":1}{\"b\":{\"c\":2}}\n  "))
+	results, err := tf.Transform(context.Background(), msg)
+	if err != nil {
+		t.Fatalf("transform failed: %v", err)
+	}
+	if len(results) != 2 {
+		t.Fatalf("expected 2 results, got %d", len(results))
+	}
+
+	expected := []string{`{"a":1}`, `{"b":{"c":2}}`}
+	for i, r := range results {
+		if string(r.Data()) != expected[i] {
+			t.Errorf("expected %s, got %s", expected[i], string(r.Data()))
+		}
+	}
+}
+
+func TestSplitJSONStream_Target(t *testing.T) {
+	cfg := config.Config{
+		Type: "split_json_stream",
+		Settings: map[string]interface{}{
+			"source": "$.stream",
+			"target": "$.event",
+		},
+	}
+	tf, err := newSplitJSONStream(context.Background(), cfg)
+	if err != nil {
+		t.Fatalf("failed to create split_json_stream transform: %v", err)
+	}
+
+	msg := message.New().SetData([]byte(`{"stream":"{\"a\":1} {\"a\":2}"}`))
+	results, err := tf.Transform(context.Background(), msg)
+	if err != nil {
+		t.Fatalf("transform failed: %v", err)
+	}
+	if len(results) != 2 {
+		t.Fatalf("expected 2 results, got %d", len(results))
+	}
+	for i, r := range results {
+		if got := r.GetValue("$.event.a").Int(); got != int64(i+1) {
+			t.Errorf("expected event.a=%d, got %d", i+1, got)
+		}
+	}
+}
+
+func TestSplitJSONStream_Malformed(t *testing.T) {
+	tf, err := newSplitJSONStream(context.Background(), config.Config{Type: "split_json_stream"})
+	if err != nil {
+		t.Fatalf("failed to create split_json_stream transform: %v", err)
+	}
+
+	msg := message.New().SetData([]byte(`{"a":1}{"b":`))
+	results, err := tf.Transform(context.Background(), msg)
+	if err == nil {
+		t.Fatal("expected error for malformed stream, got nil")
+	}
+	if results != nil {
+		t.Errorf("expected no messages on error, got %v", results)
+	}
+}
@@ -0,0 +1,83 @@
+package transform
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/jshlbrd/vibestation/config"
+	"github.com/jshlbrd/vibestation/message"
+)
+
+type SplitPointerConfig struct {
+	// Pointer is an RFC 6901 JSON Pointer (e.g. /events) to the array that
+	// is split.
+	Pointer string `json:"pointer"`
+	ID      string `json:"id"`
+}
+
+func (c *SplitPointerConfig) Decode(in interface{}) error {
+	return config.Decode(in, c)
+}
+
+func newSplitPointer(_ context.Context, cfg config.Config) (*SplitPointer, error) {
+	conf := SplitPointerConfig{}
+	if err := conf.Decode(cfg.Settings); err != nil {
+		return nil, fmt.Errorf("transform split_pointer: %v", err)
+	}
+	if conf.ID == "" {
+		conf.ID = "split_pointer"
+	}
+
+	pointer, err := message.NewJSONPointer(conf.Pointer)
+	if err != nil {
+		return nil, fmt.Errorf("transform %s: pointer: %v", conf.ID, err)
+	}
+
+	tf := SplitPointer{
+		conf:     conf,
+		settings: cfg.Settings,
+		pointer:  pointer,
+	}
+	return &tf, nil
+}
+
+// SplitPointer emits one message per element of the array at a JSON
+// Pointer. Each element is written to the message data as JSON.
+type SplitPointer struct {
+	conf     SplitPointerConfig
+	settings map[string]interface{}
+	pointer  *message.JSONPath
+}
+
+func (tf *SplitPointer) Transform(ctx context.Context, msg *message.Message) ([]*message.Message, error) {
+	if msg.IsControl() {
+		return []*message.Message{msg}, nil
+	}
+
+	val, err := tf.pointer.Get(msg.Data())
+	if err != nil {
+		return nil, fmt.Errorf("transform %s: %v", tf.conf.ID, err)
+	}
+
+	arr, ok := val.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("transform %s: pointer %q is not an array", tf.conf.ID, tf.conf.Pointer)
+	}
+
+	result := make([]*message.Message, 0, len(arr))
+	for _, elem := range arr {
+		b, err := json.Marshal(elem)
+		if err != nil {
+			return nil, fmt.Errorf("transform %s: %v", tf.conf.ID, err)
+		}
+		result = append(result, message.New().SetData(b))
+	}
+
+	return result, nil
+}
+
+func (tf *SplitPointer) String() string {
+	b, _ := json.Marshal(tf.conf)
+	return string(b)
+}
@@ -0,0 +1,77 @@
+package transform
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jshlbrd/vibestation/config"
+	"github.com/jshlbrd/vibestation/message"
+)
+
+func TestSplitPointer(t *testing.T) {
+	tests := []struct {
+		name     string
+		pointer  string
+		data     string
+		expected []string
+	}{
+		{
+			"events",
+			"/events",
+			`{"events":[{"id":1},{"id":2},"three"]}`,
+			[]string{`{"id":1}`, `{"id":2}`, `"three"`},
+		},
+		{
+			"escaped key",
+			"/batch~1v1/items",
+			`{"batch/v1":{"items":[1,2]}}`,
+			[]string{"1", "2"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cfg := config.Config{
+				Type:     "split_pointer",
+				Settings: map[string]interface{}{"pointer": test.pointer},
+			}
+			tf, err := newSplitPointer(context.Background(), cfg)
+			if err != nil {
+				t.Fatalf("failed to create split_pointer transform: %v", err)
+			}
+
+			results, err := tf.Transform(context.Background(), message.New().SetData([]byte(test.data)))
+			if err != nil {
+				t.Fatalf("transform failed: %v", err)
+			}
+			if len(results) != len(test.expected) {
+				t.Fatalf("expected %d results, got %d", len(test.expected), len(results))
+			}
+			for i, r := range results {
+				if string(r.Data()) != test.expected[i] {
+					t.Errorf("result %d: expected %s, got %s", i, test.expected[i], string(r.Data()))
+				}
+			}
+		})
+	}
+}
+
+func TestSplitPointer_Errors(t *testing.T) {
+	if _, err := newSplitPointer(context.Background(), config.Config{
+		Type:     "split_pointer",
+		Settings: map[string]interface{}{"pointer": "events"},
+	}); err == nil {
+		t.Fatal("expected error for invalid pointer, got nil")
+	}
+
+	tf, err := newSplitPointer(context.Background(), config.Config{
+		Type:     "split_pointer",
+		Settings: map[string]interface{}{"pointer": "/events"},
+	})
+	if err != nil {
+		t.Fatalf("failed to create split_pointer transform: %v", err)
+	}
+	if _, err := tf.Transform(context.Background(), message.New().SetData([]byte(`{"events":{}}`))); err == nil {
+		t.Fatal("expected error for non-array value, got nil")
+	}
+}
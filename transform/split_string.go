@@ -12,22 +12,26 @@ import (
 
 type SplitStringConfig struct {
 	Separator string `json:"separator"`
+	// MultiSeparators splits on any of several separators. If set, then
+	// Separator is ignored.
+	MultiSeparators []string `json:"multi_separators"`
+	// TrimParts removes leading and trailing whitespace from each part.
+	// Parts that are empty after trimming are skipped.
+	TrimParts bool   `json:"trim_parts"`
 	ID        string `json:"id"`
 }
 
 func (c *SplitStringConfig) Decode(in interface{}) error {
-	if in == nil {
-		return nil
-	}
-	b, err := json.Marshal(in)
-	if err != nil {
-		return err
-	}
-	return json.Unmarshal(b, c)
+	return config.Decode(in, c)
 }
 
 func (c *SplitStringConfig) Validate() error {
-	if c.Separator == "" {
+	for _, sep := range c.MultiSeparators {
+		if sep == "" {
+			return fmt.Errorf("multi_separators: empty separator")
+		}
+	}
+	if c.Separator == "" && len(c.MultiSeparators) == 0 {
 		return fmt.Errorf("separator: missing required option")
 	}
 	return nil
@@ -62,9 +66,14 @@ func newSplitString(_ context.Context, cfg config.Config) (*SplitString, error)
 	if err := conf.Validate(); err != nil {
 		return nil, fmt.Errorf("transform %s: %v", conf.ID, err)
 	}
+	var separators [][]byte
+	for _, sep := range conf.MultiSeparators {
+		separators = append(separators, []byte(sep))
+	}
 	tf := SplitString{
 		conf:       conf,
 		separator:  []byte(separator),
+		separators: separators,
 		settings:   cfg.Settings,
 		sourcePath: sourcePath,
 		targetPath: targetPath,
@@ -75,6 +84,7 @@ func newSplitString(_ context.Context, cfg config.Config) (*SplitString, error)
 type SplitString struct {
 	conf       SplitStringConfig
 	separator  []byte
+	separators [][]byte
 	settings   map[string]interface{}
 	sourcePath string
 	targetPath string
@@ -94,9 +104,17 @@ func (tf *SplitString) Transform(ctx context.Context, msg *message.Message) ([]*
 	if inputData == nil {
 		inputData = msg.Data()
 	}
-	parts := bytes.Split(inputData, tf.separator)
+	var parts [][]byte
+	if len(tf.separators) > 0 {
+		parts = splitAny(inputData, tf.separators)
+	} else {
+		parts = bytes.Split(inputData, tf.separator)
+	}
 	var result []*message.Message
 	for _, part := range parts {
+		if tf.conf.TrimParts {
+			part = bytes.TrimSpace(part)
+		}
 		if len(part) == 0 {
 			continue
 		}
@@ -119,3 +137,27 @@ func (tf *SplitString) String() string {
 	b, _ := json.Marshal(tf.conf)
 	return string(b)
 }
+
+// splitAny splits data on every occurrence of any separator. When several
+// separators match at the same position, the longest one is used.
+func splitAny(data []byte, separators [][]byte) [][]byte {
+	var parts [][]byte
+	start := 0
+	for i := 0; i < len(data); {
+		var match int
+		for _, sep := range separators {
+			if len(sep) > match && bytes.HasPrefix(data[i:], sep) {
+				match = len(sep)
+			}
+		}
+		if match == 0 {
+			i++
+			continue
+		}
+
+		parts = append(parts, data[start:i])
+		i += match
+		start = i
+	}
+	return append(parts, data[start:])
+}
@@ -36,6 +36,77 @@ func TestSplitString_Basic(t *testing.T) {
 	}
 }
 
+func TestSplitString_MultiSeparators(t *testing.T) {
+	cfg := config.Config{
+		Type: "split_string",
+		Settings: map[string]interface{}{
+			"multi_separators": []interface{}{",", ";", ";;"},
+		},
+	}
+	ts, err := newSplitString(context.Background(), cfg)
+	if err != nil {
+		t.Fatalf("failed to create split_string transform: %v", err)
+	}
+
+	tests := []struct {
+		data     string
+		expected []string
+	}{
+		{"a,b;c", []string{"a", "b", "c"}},
+		{"a;;b,,c;", []string{"a", "b", "c"}},
+	}
+
+	for _, test := range tests {
+		results, err := ts.Transform(context.Background(), message.New().SetData([]byte(test.data)))
+		if err != nil {
+			t.Fatalf("transform failed: %v", err)
+		}
+		var got []string
+		for _, r := range results {
+			got = append(got, string(r.Data()))
+		}
+		if !reflect.DeepEqual(got, test.expected) {
+			t.Errorf("%s: expected %v, got %v", test.data, test.expected, got)
+		}
+	}
+}
+
+func TestSplitString_TrimParts(t *testing.T) {
+	cfg := config.Config{
+		Type: "split_string",
+		Settings: map[string]interface{}{
+			"separator":  ",",
+			"trim_parts": true,
+		},
+	}
+	ts, err := newSplitString(context.Background(), cfg)
+	if err != nil {
+		t.Fatalf("failed to create split_string transform: %v", err)
+	}
+
+	tests := []struct {
+		data     string
+		expected []string
+	}{
+		{" a , b ", []string{"a", "b"}},
+		{"a,  ,\tb\n,", []string{"a", "b"}},
+	}
+
+	for _, test := range tests {
+		results, err := ts.Transform(context.Background(), message.New().SetData([]byte(test.data)))
+		if err != nil {
+			t.Fatalf("transform failed: %v", err)
+		}
+		var got []string
+		for _, r := range results {
+			got = append(got, string(r.Data()))
+		}
+		if !reflect.DeepEqual(got, test.expected) {
+			t.Errorf("%q: expected %v, got %v", test.data, test.expected, got)
+		}
+	}
+}
+
 func TestSplitString_SourceTarget(t *testing.T) {
 	cfg := config.Config{
 		Type: "split_string",
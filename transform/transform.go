@@ -4,6 +4,7 @@ package transform
 import (
 	"context"
 	"fmt"
+	"io"
 
 	"github.com/jshlbrd/vibestation/config"
 	"github.com/jshlbrd/vibestation/message"
@@ -15,6 +16,12 @@ type Transformer interface {
 	Transform(context.Context, *message.Message) ([]*message.Message, error)
 }
 
+// ReaderTransformer is implemented by transforms that can process a stream
+// of data without buffering all of it in memory.
+type ReaderTransformer interface {
+	TransformReader(context.Context, io.Reader) (io.ReadCloser, error)
+}
+
 // Factory can be used to implement custom transform factory functions.
 type Factory func(context.Context, config.Config) (Transformer, error)
 
@@ -31,19 +38,195 @@ func New(ctx context.Context, cfg config.Config) (Transformer, error) {
 		return newDecodeBase64(ctx, cfg)
 	case "lowercase_string":
 		return newLowercaseString(ctx, cfg)
-	case "assign":
-		source, _ := cfg.Settings["source"].(string)
-		target, _ := cfg.Settings["target"].(string)
+	case "verify_hmac":
+		return newVerifyHMAC(ctx, cfg)
+	case "decode_jwt":
+		return newDecodeJWT(ctx, cfg)
+	case "split_json_stream":
+		return newSplitJSONStream(ctx, cfg)
+	case "array_to_ndjson":
+		return newArrayToNDJSON(ctx, cfg)
+	case "rotate_array":
+		return newRotateArray(ctx, cfg)
+	case "unique_array":
+		return newUniqueArray(ctx, cfg)
+	case "array_length":
+		return newArrayLength(ctx, cfg)
+	case "prefix_metadata":
+		return newPrefixMetadata(ctx, cfg)
+	case "on_control":
+		return newOnControl(ctx, cfg)
+	case "split_first":
+		return newSplitFirst(ctx, cfg)
+	case "ip_classify":
+		return newIPClassify(ctx, cfg)
+	case "parse_url":
+		return newParseURL(ctx, cfg)
+	case "crc32":
+		return newCRC32(ctx, cfg)
+	case "detect_encoding":
+		return newDetectEncoding(ctx, cfg)
+	case "latin1_to_utf8":
+		return newLatin1ToUTF8(ctx, cfg)
+	case "truncate":
+		return newTruncate(ctx, cfg)
+	case "decode_base32":
+		return newDecodeBase32(ctx, cfg)
+	case "encode_base32":
+		return newEncodeBase32(ctx, cfg)
+	case "apply_json_patch":
+		return newApplyJSONPatch(ctx, cfg)
+	case "merge_patch":
+		return newMergePatch(ctx, cfg)
+	case "collect_object":
+		return newCollectObject(ctx, cfg)
+	case "route":
+		return newRoute(ctx, cfg)
+	case "sample":
+		return newSample(ctx, cfg)
+	case "rate_limit":
+		return newRateLimit(ctx, cfg)
+	case "parse_clf":
+		return newParseCLF(ctx, cfg)
+	case "parse_syslog":
+		return newParseSyslog(ctx, cfg)
+	case "field_math":
+		return newFieldMath(ctx, cfg)
+	case "set_if":
+		return newSetIf(ctx, cfg)
+	case "redact_keys":
+		return newRedactKeys(ctx, cfg)
+	case "hash_keys":
+		return newHashKeys(ctx, cfg)
+	case "debounce":
+		return newDebounce(ctx, cfg)
+	case "dedupe_window":
+		return newDedupeWindow(ctx, cfg)
+	case "metrics":
+		return newMetrics(ctx, cfg)
+	case "parse_duration":
+		return newParseDuration(ctx, cfg)
+	case "humanize_bytes":
+		return newHumanizeBytes(ctx, cfg)
+	case "percentile":
+		return newPercentile(ctx, cfg)
+	case "pivot":
+		return newPivot(ctx, cfg)
+	case "unpivot":
+		return newUnpivot(ctx, cfg)
+	case "compress_gzip":
+		return newCompressGzip(ctx, cfg)
+	case "split_csv_records":
+		return newSplitCSVRecords(ctx, cfg)
+	case "flatten_array":
+		return newFlattenArray(ctx, cfg)
+	case "zip_arrays":
+		return newZipArrays(ctx, cfg)
+	case "reduce_array":
+		return newReduceArray(ctx, cfg)
+	case "allow_keys":
+		return newAllowKeys(ctx, cfg)
+	case "deny_keys":
+		return newDenyKeys(ctx, cfg)
+	case "format_string":
+		return newFormatString(ctx, cfg)
+	case "split_pointer":
+		return newSplitPointer(ctx, cfg)
+	case "assert_json":
+		return newAssertJSON(ctx, cfg)
+	case "envelope":
+		return newEnvelope(ctx, cfg)
+	case "promote":
+		return newPromote(ctx, cfg)
+	case "split_indented":
+		return newSplitIndented(ctx, cfg)
+	case "normalize_whitespace":
+		return newNormalizeWhitespace(ctx, cfg)
+	case "yaml_to_json":
+		return newYAMLToJSON(ctx, cfg)
+	case "json_to_yaml":
+		return newJSONToYAML(ctx, cfg)
+	case "ini_to_json":
+		return newINIToJSON(ctx, cfg)
+	case "edit_distance":
+		return newEditDistance(ctx, cfg)
+	case "parse_time_auto":
+		return newParseTimeAuto(ctx, cfg)
+	case "send_labeled":
+		return newSendLabeled(ctx, cfg)
+	case "set_ops":
+		return newSetOps(ctx, cfg)
+	case "chunk_array":
+		return newChunkArray(ctx, cfg)
+	case "field_lengths":
+		return newFieldLengths(ctx, cfg)
+	case "sequence_id":
+		return newSequenceID(ctx, cfg)
+	case "snapshot_to_meta":
+		return newSnapshotToMeta(ctx, cfg)
+	case "restore_from_meta":
+		return newRestoreFromMeta(ctx, cfg)
+	case "diff_previous":
+		return newDiffPrevious(ctx, cfg)
+	case "enrich_json":
+		return newEnrichJSON(ctx, cfg)
+	case "assert_range":
+		return newAssertRange(ctx, cfg)
+	case "ensure_array":
+		return newEnsureArray(ctx, cfg)
+	case "drop_blank":
+		return newDropBlank(ctx, cfg)
+	case "rename_case":
+		return newRenameCase(ctx, cfg)
+	case "sign_ed25519":
+		return newSignEd25519(ctx, cfg)
+	case "verify_ed25519":
+		return newVerifyEd25519(ctx, cfg)
+	case "encode_base64":
+		return newEncodeBase64(ctx, cfg)
+	case "parse_mime":
+		return newParseMIME(ctx, cfg)
+	case "ewma":
+		return newEWMA(ctx, cfg)
+	case "trim_string":
+		return newTrimString(ctx, cfg)
+	case "replace_string":
+		return newReplaceString(ctx, cfg)
+	case "require_text":
+		return newRequireText(ctx, cfg)
+	case "chunk_text":
+		return newChunkText(ctx, cfg)
+	case "canonicalize_json":
+		return newCanonicalizeJSON(ctx, cfg)
+	case "cut_field":
+		return newCutField(ctx, cfg)
+	case "normalize_bool":
+		return newNormalizeBool(ctx, cfg)
+	case "assign", "direct_assign", "direct_assignment":
+		source, ok := cfg.Settings["source"].(string)
+		if !ok || source == "" {
+			return nil, fmt.Errorf("transform %s: source: missing required option", cfg.Type)
+		}
+		target, ok := cfg.Settings["target"].(string)
+		if !ok || target == "" {
+			return nil, fmt.Errorf("transform %s: target: missing required option", cfg.Type)
+		}
 		return newDirectAssignTransformer(source, target), nil
 	case "direct_delete":
-		path, _ := cfg.Settings["path"].(string)
+		path, ok := cfg.Settings["path"].(string)
+		if !ok || path == "" {
+			return nil, fmt.Errorf("transform %s: path: missing required option", cfg.Type)
+		}
 		target, _ := cfg.Settings["target"].(string)
 		if target != "" {
 			return newDirectDeleteTransformerWithTarget(path, target), nil
 		}
 		return newDirectDeleteTransformer(path), nil
 	case "delete":
-		path, _ := cfg.Settings["source"].(string)
+		path, ok := cfg.Settings["source"].(string)
+		if !ok || path == "" {
+			return nil, fmt.Errorf("transform %s: source: missing required option", cfg.Type)
+		}
 		target, _ := cfg.Settings["target"].(string)
 		if target != "" {
 			return newDirectDeleteTransformerWithTarget(path, target), nil
@@ -54,8 +237,99 @@ func New(ctx context.Context, cfg config.Config) (Transformer, error) {
 	}
 }
 
+// ErrorKey is the metadata path where ApplyWithErrorCapture records transform errors.
+const ErrorKey = "meta.$._error"
+
 // Apply applies one or more transform functions to one or more messages.
 func Apply(ctx context.Context, tf []Transformer, msgs ...*message.Message) ([]*message.Message, error) {
+	return apply(ctx, tf, false, msgs...)
+}
+
+// ApplyWithErrorCapture applies one or more transform functions to one or more
+// messages. Unlike Apply, if a transform fails on a data message, then the error
+// is recorded in the message metadata at ErrorKey and the message continues down
+// the pipeline so that later transforms can handle it.
+func ApplyWithErrorCapture(ctx context.Context, tf []Transformer, msgs ...*message.Message) ([]*message.Message, error) {
+	return apply(ctx, tf, true, msgs...)
+}
+
+// ApplyTrace applies one or more transform functions to one or more messages
+// and also returns a trace that contains a copy of the messages produced by
+// each transform, in order. Messages in the trace are cloned so that later
+// transforms do not change them.
+func ApplyTrace(ctx context.Context, tf []Transformer, msgs ...*message.Message) ([]*message.Message, [][]*message.Message, error) {
+	resultMsgs := make([]*message.Message, len(msgs))
+	copy(resultMsgs, msgs)
+
+	// Every transform gets a stage in the trace, even if an earlier stage
+	// dropped all messages.
+	trace := make([][]*message.Message, 0, len(tf))
+	for i := 0; i < len(tf); i++ {
+		var nextResultMsgs []*message.Message
+		for _, m := range resultMsgs {
+			rMsgs, err := tf[i].Transform(ctx, m)
+			if err != nil {
+				return nil, trace, err
+			}
+			nextResultMsgs = append(nextResultMsgs, rMsgs...)
+		}
+		resultMsgs = nextResultMsgs
+
+		stage := make([]*message.Message, 0, len(resultMsgs))
+		for _, m := range resultMsgs {
+			stage = append(stage, m.Clone())
+		}
+		trace = append(trace, stage)
+	}
+
+	return resultMsgs, trace, nil
+}
+
+// ApplyReader streams r through one or more transform functions. Every
+// transform must implement ReaderTransformer, and data is passed between them
+// incrementally as the returned reader is read. The caller is responsible for
+// closing the returned reader, which also closes the reader of every transform.
+func ApplyReader(ctx context.Context, tf []Transformer, r io.Reader) (io.ReadCloser, error) {
+	chain := &readerChain{Reader: r}
+	for _, t := range tf {
+		rt, ok := t.(ReaderTransformer)
+		if !ok {
+			chain.Close()
+			return nil, fmt.Errorf("transform %T: streaming is not supported", t)
+		}
+
+		rc, err := rt.TransformReader(ctx, chain.Reader)
+		if err != nil {
+			chain.Close()
+			return nil, err
+		}
+
+		chain.Reader = rc
+		chain.closers = append(chain.closers, rc)
+	}
+
+	return chain, nil
+}
+
+// readerChain reads from the last reader in a chain of transforms and closes
+// every reader in the chain.
+type readerChain struct {
+	io.Reader
+	closers []io.Closer
+}
+
+func (c *readerChain) Close() error {
+	var err error
+	for i := len(c.closers) - 1; i >= 0; i-- {
+		if cerr := c.closers[i].Close(); cerr != nil && err == nil {
+			err = cerr
+		}
+	}
+
+	return err
+}
+
+func apply(ctx context.Context, tf []Transformer, capture bool, msgs ...*message.Message) ([]*message.Message, error) {
 	resultMsgs := make([]*message.Message, len(msgs))
 	copy(resultMsgs, msgs)
 
@@ -63,6 +337,14 @@ func Apply(ctx context.Context, tf []Transformer, msgs ...*message.Message) ([]*
 		var nextResultMsgs []*message.Message
 		for _, m := range resultMsgs {
 			rMsgs, err := tf[i].Transform(ctx, m)
+			if err != nil && capture && !m.IsControl() {
+				if err := m.SetValue(ErrorKey, err.Error()); err != nil {
+					return nil, err
+				}
+
+				nextResultMsgs = append(nextResultMsgs, m)
+				continue
+			}
 			if err != nil {
 				// We immediately return if a transform hits an unrecoverable
 				// error on a message.
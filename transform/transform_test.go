@@ -0,0 +1,212 @@
+package transform
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"io"
+	"testing"
+
+	"github.com/jshlbrd/vibestation/config"
+	"github.com/jshlbrd/vibestation/message"
+)
+
+func TestApplyWithErrorCapture(t *testing.T) {
+	ctx := context.Background()
+
+	decode, err := New(ctx, config.Config{
+		Type:     "decode_base64",
+		Settings: map[string]interface{}{"source": "$.encoded", "target": "$.decoded"},
+	})
+	if err != nil {
+		t.Fatalf("failed to create decode_base64 transform: %v", err)
+	}
+	assign, err := New(ctx, config.Config{
+		Type:     "assign",
+		Settings: map[string]interface{}{"source": ErrorKey, "target": "$.error"},
+	})
+	if err != nil {
+		t.Fatalf("failed to create assign transform: %v", err)
+	}
+	tforms := []Transformer{decode, assign}
+
+	good := message.New().SetData([]byte(`{"encoded":"dGVzdA=="}`))
+	bad := message.New().SetData([]byte(`{"encoded":"not_base64!"}`))
+
+	// Without capture, the pipeline stops on the first error.
+	if _, err := Apply(ctx, tforms, bad); err == nil {
+		t.Fatal("expected Apply to return an error, got nil")
+	}
+
+	bad = message.New().SetData([]byte(`{"encoded":"not_base64!"}`))
+	results, err := ApplyWithErrorCapture(ctx, tforms, good, bad)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(results) != 2 {
+		t.Fatalf("expected 2 results, got %d", len(results))
+	}
+
+	if got := results[0].GetValue("$.decoded").String(); got != "test" {
+		t.Errorf("expected decoded %q, got %q", "test", got)
+	}
+	if results[0].GetValue("$.error").Exists() {
+		t.Errorf("expected no error on valid message, got %s", results[0].GetValue("$.error").String())
+	}
+
+	if !results[1].GetValue(ErrorKey).Exists() {
+		t.Fatal("expected error to be captured in metadata")
+	}
+	if got := results[1].GetValue("$.error").String(); got != results[1].GetValue(ErrorKey).String() {
+		t.Errorf("expected downstream stage to read captured error, got %q", got)
+	}
+}
+
+func TestApplyTrace(t *testing.T) {
+	ctx := context.Background()
+
+	split, err := New(ctx, config.Config{
+		Type:     "split_string",
+		Settings: map[string]interface{}{"separator": ","},
+	})
+	if err != nil {
+		t.Fatalf("failed to create split_string transform: %v", err)
+	}
+	lower, err := New(ctx, config.Config{Type: "lowercase_string"})
+	if err != nil {
+		t.Fatalf("failed to create lowercase_string transform: %v", err)
+	}
+	sample, err := New(ctx, config.Config{
+		Type:     "sample",
+		Settings: map[string]interface{}{"rate": 0.0},
+	})
+	if err != nil {
+		t.Fatalf("failed to create sample transform: %v", err)
+	}
+
+	results, trace, err := ApplyTrace(ctx, []Transformer{split, lower, sample}, message.New().SetData([]byte("A,B,C")))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(results) != 0 {
+		t.Errorf("expected 0 results, got %d", len(results))
+	}
+
+	expected := []int{3, 3, 0}
+	if len(trace) != len(expected) {
+		t.Fatalf("expected %d trace stages, got %d", len(expected), len(trace))
+	}
+	for i, want := range expected {
+		if len(trace[i]) != want {
+			t.Errorf("stage %d: expected %d messages, got %d", i, want, len(trace[i]))
+		}
+	}
+
+	// The first stage is cloned, so the later lowercase stage does not change it.
+	if got := string(trace[0][0].Data()); got != "A" {
+		t.Errorf("expected stage 0 data %q, got %q", "A", got)
+	}
+	if got := string(trace[1][0].Data()); got != "a" {
+		t.Errorf("expected stage 1 data %q, got %q", "a", got)
+	}
+}
+
+func TestNew_DirectAssignment(t *testing.T) {
+	ctx := context.Background()
+
+	tf, err := New(ctx, config.Config{
+		Type:     "direct_assignment",
+		Settings: map[string]interface{}{"source": "$.a", "target": "$.b"},
+	})
+	if err != nil {
+		t.Fatalf("failed to create direct_assignment transform: %v", err)
+	}
+	if _, ok := tf.(*DirectAssignTransformer); !ok {
+		t.Fatalf("expected *DirectAssignTransformer, got %T", tf)
+	}
+
+	invalid := []map[string]interface{}{
+		nil,
+		{"target": "$.b"},
+		{"source": "$.a"},
+		{"source": 1, "target": "$.b"},
+		{"source": "$.a", "target": true},
+	}
+	for _, settings := range invalid {
+		if _, err := New(ctx, config.Config{Type: "direct_assignment", Settings: settings}); err == nil {
+			t.Errorf("expected error for settings %v, got nil", settings)
+		}
+	}
+}
+
+func TestNew_Delete(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []config.Config{
+		{Type: "delete", Settings: map[string]interface{}{"source": "$.a"}},
+		{Type: "delete", Settings: map[string]interface{}{"source": "$.a", "target": "$.b"}},
+		{Type: "direct_delete", Settings: map[string]interface{}{"path": "$.a"}},
+	}
+	for _, cfg := range tests {
+		tf, err := New(ctx, cfg)
+		if err != nil {
+			t.Fatalf("failed to create %s transform: %v", cfg.Type, err)
+		}
+		if _, ok := tf.(*DirectDeleteTransformer); !ok {
+			t.Fatalf("expected *DirectDeleteTransformer, got %T", tf)
+		}
+	}
+
+	invalid := []config.Config{
+		{Type: "delete"},
+		{Type: "delete", Settings: map[string]interface{}{"source": 1}},
+		{Type: "direct_delete", Settings: map[string]interface{}{"source": "$.a"}},
+	}
+	for _, cfg := range invalid {
+		if _, err := New(ctx, cfg); err == nil {
+			t.Errorf("expected error for %s with settings %v, got nil", cfg.Type, cfg.Settings)
+		}
+	}
+}
+
+func TestApplyReader(t *testing.T) {
+	ctx := context.Background()
+
+	tf, err := New(ctx, config.Config{Type: "decompress_gzip"})
+	if err != nil {
+		t.Fatalf("failed to create decompress_gzip transform: %v", err)
+	}
+
+	// The payload is compressed twice, so each transform in the chain
+	// removes one layer.
+	var inner, outer bytes.Buffer
+	gz := gzip.NewWriter(&inner)
+	gz.Write([]byte("streamed data"))
+	gz.Close()
+	gz = gzip.NewWriter(&outer)
+	gz.Write(inner.Bytes())
+	gz.Close()
+
+	r, err := ApplyReader(ctx, []Transformer{tf, tf}, &outer)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	out, err := io.ReadAll(r)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := r.Close(); err != nil {
+		t.Fatalf("unexpected error on close: %v", err)
+	}
+	if string(out) != "streamed data" {
+		t.Errorf("expected %q, got %q", "streamed data", string(out))
+	}
+
+	lower, err := New(ctx, config.Config{Type: "lowercase_string"})
+	if err != nil {
+		t.Fatalf("failed to create lowercase_string transform: %v", err)
+	}
+	if _, err := ApplyReader(ctx, []Transformer{tf, lower}, bytes.NewReader(outer.Bytes())); err == nil {
+		t.Fatal("expected error for transform without streaming support, got nil")
+	}
+}
@@ -0,0 +1,136 @@
+package transform
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/jshlbrd/vibestation/config"
+	"github.com/jshlbrd/vibestation/message"
+)
+
+type TrimStringConfig struct {
+	// Cutset is the set of characters that are removed. If not set, then
+	// Unicode whitespace is removed.
+	Cutset string `json:"cutset"`
+	// Mode is the side that is trimmed, one of both (default), left, or right.
+	Mode string `json:"mode"`
+	ID   string `json:"id"`
+}
+
+func (c *TrimStringConfig) Decode(in interface{}) error {
+	return config.Decode(in, c)
+}
+
+func (c *TrimStringConfig) Validate() error {
+	switch c.Mode {
+	case "both", "left", "right":
+	default:
+		return fmt.Errorf("mode: unsupported value %q", c.Mode)
+	}
+	return nil
+}
+
+func newTrimString(_ context.Context, cfg config.Config) (*TrimString, error) {
+	conf := TrimStringConfig{}
+	if err := conf.Decode(cfg.Settings); err != nil {
+		return nil, fmt.Errorf("transform trim_string: %v", err)
+	}
+	if conf.ID == "" {
+		conf.ID = "trim_string"
+	}
+	if conf.Mode == "" {
+		conf.Mode = "both"
+	}
+	if err := conf.Validate(); err != nil {
+		return nil, fmt.Errorf("transform %s: %v", conf.ID, err)
+	}
+
+	var sourcePath string
+	if v, ok := cfg.Settings["source"]; ok {
+		if s, ok := v.(string); ok {
+			sourcePath = s
+		}
+	}
+
+	var targetPath string
+	if v, ok := cfg.Settings["target"]; ok {
+		if s, ok := v.(string); ok {
+			targetPath = s
+		}
+	}
+
+	tf := TrimString{
+		conf:       conf,
+		settings:   cfg.Settings,
+		sourcePath: sourcePath,
+		targetPath: targetPath,
+	}
+	return &tf, nil
+}
+
+// TrimString removes leading and/or trailing characters from a string.
+type TrimString struct {
+	conf       TrimStringConfig
+	settings   map[string]interface{}
+	sourcePath string
+	targetPath string
+}
+
+func (tf *TrimString) Transform(ctx context.Context, msg *message.Message) ([]*message.Message, error) {
+	if msg.IsControl() {
+		return []*message.Message{msg}, nil
+	}
+
+	var inputData []byte
+	if tf.sourcePath != "" {
+		val := msg.GetValue(tf.sourcePath)
+		if val.Exists() {
+			inputData = val.Bytes()
+		}
+	}
+	if inputData == nil {
+		inputData = msg.Data()
+	}
+
+	trimmed := tf.trim(string(inputData))
+
+	if tf.targetPath != "" {
+		if err := msg.SetValue(tf.targetPath, trimmed); err != nil {
+			return nil, fmt.Errorf("transform %s: failed to set target: %v", tf.conf.ID, err)
+		}
+	} else {
+		msg.SetData([]byte(trimmed))
+	}
+
+	return []*message.Message{msg}, nil
+}
+
+func (tf *TrimString) String() string {
+	b, _ := json.Marshal(tf.conf)
+	return string(b)
+}
+
+func (tf *TrimString) trim(s string) string {
+	if tf.conf.Cutset == "" {
+		switch tf.conf.Mode {
+		case "left":
+			return strings.TrimLeftFunc(s, unicode.IsSpace)
+		case "right":
+			return strings.TrimRightFunc(s, unicode.IsSpace)
+		default:
+			return strings.TrimFunc(s, unicode.IsSpace)
+		}
+	}
+
+	switch tf.conf.Mode {
+	case "left":
+		return strings.TrimLeft(s, tf.conf.Cutset)
+	case "right":
+		return strings.TrimRight(s, tf.conf.Cutset)
+	default:
+		return strings.Trim(s, tf.conf.Cutset)
+	}
+}
@@ -0,0 +1,90 @@
+package transform
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jshlbrd/vibestation/config"
+	"github.com/jshlbrd/vibestation/message"
+)
+
+func TestTrimString(t *testing.T) {
+	tests := []struct {
+		name     string
+		settings map[string]interface{}
+		input    string
+		expected string
+	}{
+		{"whitespace both", map[string]interface{}{}, " \t hello \n", "hello"},
+		{"whitespace left", map[string]interface{}{"mode": "left"}, "  hello  ", "hello  "},
+		{"whitespace right", map[string]interface{}{"mode": "right"}, "  hello  ", "  hello"},
+		{"cutset both", map[string]interface{}{"cutset": "-*"}, "*-hello-*", "hello"},
+		{"cutset left", map[string]interface{}{"cutset": "0", "mode": "left"}, "00420", "420"},
+		{"cutset right", map[string]interface{}{"cutset": ".", "mode": "right"}, "end...", "end"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tf, err := newTrimString(context.Background(), config.Config{Type: "trim_string", Settings: tt.settings})
+			if err != nil {
+				t.Fatalf("failed to create trim_string transform: %v", err)
+			}
+
+			results, err := tf.Transform(context.Background(), message.New().SetData([]byte(tt.input)))
+			if err != nil {
+				t.Fatalf("transform failed: %v", err)
+			}
+			if got := string(results[0].Data()); got != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestTrimString_SourceTarget(t *testing.T) {
+	cfg := config.Config{
+		Type: "trim_string",
+		Settings: map[string]interface{}{
+			"source": "$.raw",
+			"target": "$.clean",
+		},
+	}
+	tf, err := newTrimString(context.Background(), cfg)
+	if err != nil {
+		t.Fatalf("failed to create trim_string transform: %v", err)
+	}
+
+	results, err := tf.Transform(context.Background(), message.New().SetData([]byte(`{"raw":"  a b  "}`)))
+	if err != nil {
+		t.Fatalf("transform failed: %v", err)
+	}
+	if got := results[0].GetValue("$.clean").String(); got != "a b" {
+		t.Errorf("expected %q, got %q", "a b", got)
+	}
+}
+
+func TestTrimString_ControlMessage(t *testing.T) {
+	tf, err := newTrimString(context.Background(), config.Config{Type: "trim_string"})
+	if err != nil {
+		t.Fatalf("failed to create trim_string transform: %v", err)
+	}
+
+	msg := message.New().AsControl()
+	results, err := tf.Transform(context.Background(), msg)
+	if err != nil {
+		t.Fatalf("transform failed: %v", err)
+	}
+	if len(results) != 1 || results[0] != msg {
+		t.Error("expected control message to pass through untouched")
+	}
+}
+
+func TestTrimString_InvalidMode(t *testing.T) {
+	cfg := config.Config{
+		Type:     "trim_string",
+		Settings: map[string]interface{}{"mode": "middle"},
+	}
+	if _, err := newTrimString(context.Background(), cfg); err == nil {
+		t.Fatal("expected error for invalid mode, got nil")
+	}
+}
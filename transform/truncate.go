@@ -0,0 +1,142 @@
+package transform
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"unicode/utf8"
+
+	"github.com/jshlbrd/vibestation/config"
+	"github.com/jshlbrd/vibestation/message"
+)
+
+type TruncateConfig struct {
+	// Length is the maximum length of the data, measured in Unit.
+	Length int `json:"length"`
+	// Unit is either "bytes" (default) or "runes".
+	Unit string `json:"unit"`
+	// Ellipsis is appended to the data when it is truncated.
+	Ellipsis string `json:"ellipsis"`
+	ID       string `json:"id"`
+}
+
+func (c *TruncateConfig) Decode(in interface{}) error {
+	return config.Decode(in, c)
+}
+
+func (c *TruncateConfig) Validate() error {
+	if c.Length <= 0 {
+		return fmt.Errorf("length: must be greater than 0")
+	}
+	if c.Unit != "bytes" && c.Unit != "runes" {
+		return fmt.Errorf("unit: unsupported value %q", c.Unit)
+	}
+	return nil
+}
+
+func newTruncate(_ context.Context, cfg config.Config) (*Truncate, error) {
+	conf := TruncateConfig{}
+	if err := conf.Decode(cfg.Settings); err != nil {
+		return nil, fmt.Errorf("transform truncate: %v", err)
+	}
+
+	id := "truncate"
+	if v, ok := cfg.Settings["id"]; ok {
+		if s, ok := v.(string); ok && s != "" {
+			id = s
+		}
+	}
+	conf.ID = id
+
+	if conf.Unit == "" {
+		conf.Unit = "bytes"
+	}
+	if err := conf.Validate(); err != nil {
+		return nil, fmt.Errorf("transform %s: %v", conf.ID, err)
+	}
+
+	var sourcePath string
+	if v, ok := cfg.Settings["source"]; ok {
+		if s, ok := v.(string); ok {
+			sourcePath = s
+		}
+	}
+
+	var targetPath string
+	if v, ok := cfg.Settings["target"]; ok {
+		if s, ok := v.(string); ok {
+			targetPath = s
+		}
+	}
+
+	tf := Truncate{
+		conf:       conf,
+		sourcePath: sourcePath,
+		targetPath: targetPath,
+		settings:   cfg.Settings,
+	}
+
+	return &tf, nil
+}
+
+type Truncate struct {
+	conf       TruncateConfig
+	sourcePath string
+	targetPath string
+	settings   map[string]interface{}
+}
+
+func (tf *Truncate) Transform(ctx context.Context, msg *message.Message) ([]*message.Message, error) {
+	if msg.IsControl() {
+		return []*message.Message{msg}, nil
+	}
+
+	var inputData []byte
+	if tf.sourcePath != "" {
+		val := msg.GetValue(tf.sourcePath)
+		if val.Exists() {
+			inputData = val.Bytes()
+		}
+	}
+	if inputData == nil {
+		inputData = msg.Data()
+	}
+
+	truncated := truncate(string(inputData), tf.conf.Length, tf.conf.Unit == "runes", tf.conf.Ellipsis)
+
+	if tf.targetPath != "" {
+		err := msg.SetValue(tf.targetPath, truncated)
+		if err != nil {
+			return nil, fmt.Errorf("transform %s: failed to set target: %v", tf.conf.ID, err)
+		}
+	} else {
+		msg.SetData([]byte(truncated))
+	}
+
+	return []*message.Message{msg}, nil
+}
+
+func (tf *Truncate) String() string {
+	b, _ := json.Marshal(tf.conf)
+	return string(b)
+}
+
+// truncate shortens s to at most length bytes (or runes) and appends the
+// ellipsis if s was shortened. Byte truncation never splits a multi-byte
+// rune, so the result may be shorter than length.
+func truncate(s string, length int, runes bool, ellipsis string) string {
+	if runes {
+		if utf8.RuneCountInString(s) <= length {
+			return s
+		}
+		return string([]rune(s)[:length]) + ellipsis
+	}
+
+	if len(s) <= length {
+		return s
+	}
+	for length > 0 && !utf8.RuneStart(s[length]) {
+		length--
+	}
+	return s[:length] + ellipsis
+}
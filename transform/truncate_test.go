@@ -0,0 +1,96 @@
+package transform
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jshlbrd/vibestation/config"
+	"github.com/jshlbrd/vibestation/message"
+)
+
+func TestTruncate(t *testing.T) {
+	tests := []struct {
+		name     string
+		settings map[string]interface{}
+		input    string
+		expected string
+	}{
+		{
+			"under limit",
+			map[string]interface{}{"length": 10, "ellipsis": "..."},
+			"short",
+			"short",
+		},
+		{
+			"over limit",
+			map[string]interface{}{"length": 5, "ellipsis": "..."},
+			"hello world",
+			"hello...",
+		},
+		{
+			"runes",
+			map[string]interface{}{"length": 3, "unit": "runes"},
+			"héllo",
+			"hél",
+		},
+		{
+			"bytes on rune boundary",
+			map[string]interface{}{"length": 2},
+			"héllo",
+			"h",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cfg := config.Config{
+				Type:     "truncate",
+				Settings: test.settings,
+			}
+			tf, err := newTruncate(context.Background(), cfg)
+			if err != nil {
+				t.Fatalf("failed to create truncate transform: %v", err)
+			}
+
+			msg := message.New().SetData([]byte(test.input))
+			results, err := tf.Transform(context.Background(), msg)
+			if err != nil {
+				t.Fatalf("transform failed: %v", err)
+			}
+			if string(results[0].Data()) != test.expected {
+				t.Errorf("expected %q, got %q", test.expected, string(results[0].Data()))
+			}
+		})
+	}
+}
+
+func TestTruncate_Target(t *testing.T) {
+	cfg := config.Config{
+		Type: "truncate",
+		Settings: map[string]interface{}{
+			"source":   "$.msg",
+			"target":   "$.msg",
+			"length":   4,
+			"ellipsis": "…",
+		},
+	}
+	tf, err := newTruncate(context.Background(), cfg)
+	if err != nil {
+		t.Fatalf("failed to create truncate transform: %v", err)
+	}
+
+	msg := message.New().SetData([]byte(`{"id":1,"msg":"truncate me"}`))
+	results, err := tf.Transform(context.Background(), msg)
+	if err != nil {
+		t.Fatalf("transform failed: %v", err)
+	}
+	if got := results[0].GetValue("$.msg").String(); got != "trun…" {
+		t.Errorf("expected %q, got %q", "trun…", got)
+	}
+}
+
+func TestTruncate_InvalidLength(t *testing.T) {
+	if _, err := newTruncate(context.Background(), config.Config{Type: "truncate"}); err == nil {
+		t.Fatal("expected error for missing length, got nil")
+	}
+}
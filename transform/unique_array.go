@@ -0,0 +1,113 @@
+package transform
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/jshlbrd/vibestation/config"
+	"github.com/jshlbrd/vibestation/message"
+)
+
+type UniqueArrayConfig struct {
+	ID string `json:"id"`
+}
+
+func (c *UniqueArrayConfig) Decode(in interface{}) error {
+	return config.Decode(in, c)
+}
+
+func newUniqueArray(_ context.Context, cfg config.Config) (*UniqueArray, error) {
+	conf := UniqueArrayConfig{}
+	if err := conf.Decode(cfg.Settings); err != nil {
+		return nil, fmt.Errorf("transform unique_array: %v", err)
+	}
+	if conf.ID == "" {
+		conf.ID = "unique_array"
+	}
+
+	sourcePath := "$"
+	if v, ok := cfg.Settings["source"]; ok {
+		if s, ok := v.(string); ok && s != "" {
+			sourcePath = s
+		}
+	}
+
+	var targetPath string
+	if v, ok := cfg.Settings["target"]; ok {
+		if s, ok := v.(string); ok {
+			targetPath = s
+		}
+	}
+
+	tf := UniqueArray{
+		conf:       conf,
+		settings:   cfg.Settings,
+		sourcePath: sourcePath,
+		targetPath: targetPath,
+	}
+	return &tf, nil
+}
+
+type UniqueArray struct {
+	conf       UniqueArrayConfig
+	settings   map[string]interface{}
+	sourcePath string
+	targetPath string
+}
+
+func (tf *UniqueArray) Transform(ctx context.Context, msg *message.Message) ([]*message.Message, error) {
+	if msg.IsControl() {
+		return []*message.Message{msg}, nil
+	}
+
+	val := msg.GetValue(tf.sourcePath)
+	if !val.IsArray() {
+		return nil, fmt.Errorf("transform %s: source %s is not an array", tf.conf.ID, tf.sourcePath)
+	}
+
+	unique, err := uniqueArray(val.Array())
+	if err != nil {
+		return nil, fmt.Errorf("transform %s: %v", tf.conf.ID, err)
+	}
+
+	if tf.targetPath != "" {
+		if err := msg.SetValue(tf.targetPath, unique); err != nil {
+			return nil, fmt.Errorf("transform %s: failed to set target: %v", tf.conf.ID, err)
+		}
+	} else {
+		b, err := json.Marshal(unique)
+		if err != nil {
+			return nil, fmt.Errorf("transform %s: %v", tf.conf.ID, err)
+		}
+		msg.SetData(b)
+	}
+
+	return []*message.Message{msg}, nil
+}
+
+func (tf *UniqueArray) String() string {
+	b, _ := json.Marshal(tf.conf)
+	return string(b)
+}
+
+// uniqueArray removes duplicate items, preserving the order in which they
+// were first seen. Items are compared by their JSON encoding, which sorts
+// object keys, so equivalent objects are treated as duplicates.
+func uniqueArray(items []message.Value) ([]interface{}, error) {
+	seen := make(map[string]struct{})
+	result := make([]interface{}, 0, len(items))
+	for _, item := range items {
+		b, err := json.Marshal(item.Value())
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := seen[string(b)]; ok {
+			continue
+		}
+		seen[string(b)] = struct{}{}
+		result = append(result, item.Value())
+	}
+
+	return result, nil
+}
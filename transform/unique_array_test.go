@@ -0,0 +1,62 @@
+package transform
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jshlbrd/vibestation/config"
+	"github.com/jshlbrd/vibestation/message"
+)
+
+func TestUniqueArray(t *testing.T) {
+	tests := []struct {
+		name     string
+		data     string
+		expected string
+	}{
+		{"scalars", `{"items":[1,"a",1,2,"a",true,true]}`, `[1,"a",2,true]`},
+		{"objects", `{"items":[{"a":1,"b":2},{"b":2,"a":1},{"a":2}]}`, `[{"a":1,"b":2},{"a":2}]`},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cfg := config.Config{
+				Type: "unique_array",
+				Settings: map[string]interface{}{
+					"source": "$.items",
+					"target": "$.items",
+				},
+			}
+			tf, err := newUniqueArray(context.Background(), cfg)
+			if err != nil {
+				t.Fatalf("failed to create unique_array transform: %v", err)
+			}
+
+			msg := message.New().SetData([]byte(test.data))
+			results, err := tf.Transform(context.Background(), msg)
+			if err != nil {
+				t.Fatalf("transform failed: %v", err)
+			}
+
+			if got := results[0].GetValue("$.items").String(); got != test.expected {
+				t.Errorf("expected %s, got %s", test.expected, got)
+			}
+		})
+	}
+}
+
+func TestUniqueArray_NotArray(t *testing.T) {
+	cfg := config.Config{
+		Type:     "unique_array",
+		Settings: map[string]interface{}{"source": "$.items"},
+	}
+	tf, err := newUniqueArray(context.Background(), cfg)
+	if err != nil {
+		t.Fatalf("failed to create unique_array transform: %v", err)
+	}
+
+	msg := message.New().SetData([]byte(`{"items":"a"}`))
+	if _, err := tf.Transform(context.Background(), msg); err == nil {
+		t.Fatal("expected error for non-array source, got nil")
+	}
+}
@@ -0,0 +1,118 @@
+package transform
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/jshlbrd/vibestation/config"
+	"github.com/jshlbrd/vibestation/message"
+)
+
+type UnpivotConfig struct {
+	// KeyField is the field in each element that holds the object key.
+	// Defaults to key.
+	KeyField string `json:"key_field"`
+	// ValueField is the field in each element that holds the object value.
+	// Defaults to value.
+	ValueField string `json:"value_field"`
+	ID         string `json:"id"`
+}
+
+func (c *UnpivotConfig) Decode(in interface{}) error {
+	return config.Decode(in, c)
+}
+
+func newUnpivot(_ context.Context, cfg config.Config) (*Unpivot, error) {
+	conf := UnpivotConfig{}
+	if err := conf.Decode(cfg.Settings); err != nil {
+		return nil, fmt.Errorf("transform unpivot: %v", err)
+	}
+	if conf.ID == "" {
+		conf.ID = "unpivot"
+	}
+	if conf.KeyField == "" {
+		conf.KeyField = "key"
+	}
+	if conf.ValueField == "" {
+		conf.ValueField = "value"
+	}
+
+	sourcePath := "$"
+	if v, ok := cfg.Settings["source"]; ok {
+		if s, ok := v.(string); ok && s != "" {
+			sourcePath = s
+		}
+	}
+
+	var targetPath string
+	if v, ok := cfg.Settings["target"]; ok {
+		if s, ok := v.(string); ok {
+			targetPath = s
+		}
+	}
+
+	tf := Unpivot{
+		conf:       conf,
+		settings:   cfg.Settings,
+		sourcePath: sourcePath,
+		targetPath: targetPath,
+	}
+	return &tf, nil
+}
+
+// Unpivot converts an object into an array of key-value objects, such as
+// {"a":1,"b":2} into [{"key":"a","value":1},{"key":"b","value":2}]. Elements
+// are sorted by key. This is the inverse of Pivot.
+type Unpivot struct {
+	conf       UnpivotConfig
+	settings   map[string]interface{}
+	sourcePath string
+	targetPath string
+}
+
+func (tf *Unpivot) Transform(ctx context.Context, msg *message.Message) ([]*message.Message, error) {
+	if msg.IsControl() {
+		return []*message.Message{msg}, nil
+	}
+
+	val := msg.GetValue(tf.sourcePath)
+	if _, ok := val.Value().(map[string]interface{}); !ok {
+		return nil, fmt.Errorf("transform %s: source %s is not an object", tf.conf.ID, tf.sourcePath)
+	}
+
+	obj := val.Map()
+	keys := make([]string, 0, len(obj))
+	for k := range obj {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	result := make([]interface{}, 0, len(keys))
+	for _, k := range keys {
+		result = append(result, map[string]interface{}{
+			tf.conf.KeyField:   k,
+			tf.conf.ValueField: obj[k].Value(),
+		})
+	}
+
+	if tf.targetPath != "" {
+		if err := msg.SetValue(tf.targetPath, result); err != nil {
+			return nil, fmt.Errorf("transform %s: failed to set target: %v", tf.conf.ID, err)
+		}
+	} else {
+		b, err := json.Marshal(result)
+		if err != nil {
+			return nil, fmt.Errorf("transform %s: %v", tf.conf.ID, err)
+		}
+		msg.SetData(b)
+	}
+
+	return []*message.Message{msg}, nil
+}
+
+func (tf *Unpivot) String() string {
+	b, _ := json.Marshal(tf.conf)
+	return string(b)
+}
@@ -0,0 +1,66 @@
+package transform
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jshlbrd/vibestation/config"
+	"github.com/jshlbrd/vibestation/message"
+)
+
+func TestUnpivot(t *testing.T) {
+	cfg := config.Config{
+		Type: "unpivot",
+		Settings: map[string]interface{}{
+			"source": "$.object",
+			"target": "$.pairs",
+		},
+	}
+	tf, err := newUnpivot(context.Background(), cfg)
+	if err != nil {
+		t.Fatalf("failed to create unpivot transform: %v", err)
+	}
+
+	msg := message.New().SetData([]byte(`{"object":{"b":2,"a":1}}`))
+	results, err := tf.Transform(context.Background(), msg)
+	if err != nil {
+		t.Fatalf("transform failed: %v", err)
+	}
+
+	expected := `[{"key":"a","value":1},{"key":"b","value":2}]`
+	if got := results[0].GetValue("$.pairs").String(); got != expected {
+		t.Errorf("expected %s, got %s", expected, got)
+	}
+}
+
+func TestUnpivot_RoundTrip(t *testing.T) {
+	unpivot, err := newUnpivot(context.Background(), config.Config{Type: "unpivot"})
+	if err != nil {
+		t.Fatalf("failed to create unpivot transform: %v", err)
+	}
+	pivot, err := newPivot(context.Background(), config.Config{Type: "pivot"})
+	if err != nil {
+		t.Fatalf("failed to create pivot transform: %v", err)
+	}
+
+	input := `{"a":1,"b":{"c":[true,null]},"d":"e"}`
+	results, err := Apply(context.Background(), []Transformer{unpivot, pivot}, message.New().SetData([]byte(input)))
+	if err != nil {
+		t.Fatalf("transform failed: %v", err)
+	}
+
+	if got := string(results[0].Data()); got != input {
+		t.Errorf("expected %s, got %s", input, got)
+	}
+}
+
+func TestUnpivot_NotObject(t *testing.T) {
+	tf, err := newUnpivot(context.Background(), config.Config{Type: "unpivot"})
+	if err != nil {
+		t.Fatalf("failed to create unpivot transform: %v", err)
+	}
+
+	if _, err := tf.Transform(context.Background(), message.New().SetData([]byte(`[1,2]`))); err == nil {
+		t.Fatal("expected error for non-object source, got nil")
+	}
+}
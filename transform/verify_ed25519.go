@@ -0,0 +1,123 @@
+package transform
+
+import (
+	"context"
+	"crypto/ed25519"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/jshlbrd/vibestation/config"
+	"github.com/jshlbrd/vibestation/message"
+)
+
+type VerifyEd25519Config struct {
+	// Key is the base64-encoded public key.
+	Key string `json:"key"`
+	// Signature is the JSON path to the base64-encoded signature.
+	Signature string `json:"signature"`
+	// Mode determines what happens to messages with an invalid signature,
+	// either "error" (default) or "drop".
+	Mode string `json:"mode"`
+	ID   string `json:"id"`
+}
+
+func (c *VerifyEd25519Config) Decode(in interface{}) error {
+	return config.Decode(in, c)
+}
+
+func (c *VerifyEd25519Config) Validate() error {
+	if c.Key == "" {
+		return fmt.Errorf("key: missing required option")
+	}
+	if c.Signature == "" {
+		return fmt.Errorf("signature: missing required option")
+	}
+	if c.Mode != "error" && c.Mode != "drop" {
+		return fmt.Errorf("mode: unsupported value %q", c.Mode)
+	}
+	return nil
+}
+
+func newVerifyEd25519(_ context.Context, cfg config.Config) (*VerifyEd25519, error) {
+	conf := VerifyEd25519Config{}
+	if err := conf.Decode(cfg.Settings); err != nil {
+		return nil, fmt.Errorf("transform verify_ed25519: %v", err)
+	}
+	if conf.ID == "" {
+		conf.ID = "verify_ed25519"
+	}
+	if conf.Mode == "" {
+		conf.Mode = "error"
+	}
+	if err := conf.Validate(); err != nil {
+		return nil, fmt.Errorf("transform %s: %v", conf.ID, err)
+	}
+
+	key, err := base64.StdEncoding.DecodeString(conf.Key)
+	if err != nil {
+		return nil, fmt.Errorf("transform %s: key: %v", conf.ID, err)
+	}
+	if len(key) != ed25519.PublicKeySize {
+		return nil, fmt.Errorf("transform %s: key: invalid length %d", conf.ID, len(key))
+	}
+
+	var sourcePath string
+	if v, ok := cfg.Settings["source"]; ok {
+		if s, ok := v.(string); ok {
+			sourcePath = s
+		}
+	}
+
+	tf := VerifyEd25519{
+		conf:       conf,
+		settings:   cfg.Settings,
+		sourcePath: sourcePath,
+		publicKey:  ed25519.PublicKey(key),
+	}
+	return &tf, nil
+}
+
+// VerifyEd25519 checks the Ed25519 signature of the data against a public
+// key.
+type VerifyEd25519 struct {
+	conf       VerifyEd25519Config
+	settings   map[string]interface{}
+	sourcePath string
+	publicKey  ed25519.PublicKey
+}
+
+func (tf *VerifyEd25519) Transform(ctx context.Context, msg *message.Message) ([]*message.Message, error) {
+	if msg.IsControl() {
+		return []*message.Message{msg}, nil
+	}
+
+	var inputData []byte
+	if tf.sourcePath != "" {
+		val := msg.GetValue(tf.sourcePath)
+		if val.Exists() {
+			inputData = val.Bytes()
+		}
+	}
+	if inputData == nil {
+		inputData = msg.Data()
+	}
+
+	// A missing or malformed signature is treated the same as a mismatch.
+	sig, err := base64.StdEncoding.DecodeString(strings.TrimSpace(msg.GetValue(tf.conf.Signature).String()))
+	if err == nil && ed25519.Verify(tf.publicKey, inputData, sig) {
+		return []*message.Message{msg}, nil
+	}
+
+	if tf.conf.Mode == "drop" {
+		return nil, nil
+	}
+
+	return nil, fmt.Errorf("transform %s: signature mismatch", tf.conf.ID)
+}
+
+func (tf *VerifyEd25519) String() string {
+	b, _ := json.Marshal(tf.conf)
+	return string(b)
+}
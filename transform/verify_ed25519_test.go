@@ -0,0 +1,90 @@
+package transform
+
+import (
+	"context"
+	"crypto/ed25519"
+	"encoding/base64"
+	"testing"
+
+	"github.com/jshlbrd/vibestation/config"
+	"github.com/jshlbrd/vibestation/message"
+)
+
+func newTestEd25519Pipeline(t *testing.T, mode string) (*SignEd25519, *VerifyEd25519) {
+	t.Helper()
+
+	sign, err := newSignEd25519(context.Background(), config.Config{
+		Type: "sign_ed25519",
+		Settings: map[string]interface{}{
+			"key":    base64.StdEncoding.EncodeToString(testEd25519Seed),
+			"source": "$.payload",
+			"target": "$.sig",
+		},
+	})
+	if err != nil {
+		t.Fatalf("failed to create sign_ed25519 transform: %v", err)
+	}
+
+	pub := ed25519.NewKeyFromSeed(testEd25519Seed).Public().(ed25519.PublicKey)
+	verify, err := newVerifyEd25519(context.Background(), config.Config{
+		Type: "verify_ed25519",
+		Settings: map[string]interface{}{
+			"key":       base64.StdEncoding.EncodeToString(pub),
+			"source":    "$.payload",
+			"signature": "$.sig",
+			"mode":      mode,
+		},
+	})
+	if err != nil {
+		t.Fatalf("failed to create verify_ed25519 transform: %v", err)
+	}
+
+	return sign, verify
+}
+
+func TestVerifyEd25519_RoundTrip(t *testing.T) {
+	sign, verify := newTestEd25519Pipeline(t, "error")
+
+	results, err := Apply(context.Background(), []Transformer{sign, verify}, message.New().SetData([]byte(`{"payload":"hello"}`)))
+	if err != nil {
+		t.Fatalf("apply failed: %v", err)
+	}
+	if len(results) != 1 {
+		t.Fatalf("expected 1 message, got %d", len(results))
+	}
+}
+
+func TestVerifyEd25519_Tampered(t *testing.T) {
+	sign, verify := newTestEd25519Pipeline(t, "error")
+
+	signed, err := sign.Transform(context.Background(), message.New().SetData([]byte(`{"payload":"hello"}`)))
+	if err != nil {
+		t.Fatalf("transform failed: %v", err)
+	}
+	if err := signed[0].SetValue("$.payload", "goodbye"); err != nil {
+		t.Fatalf("failed to tamper with payload: %v", err)
+	}
+
+	if _, err := verify.Transform(context.Background(), signed[0]); err == nil {
+		t.Fatal("expected error for tampered payload, got nil")
+	}
+
+	_, drop := newTestEd25519Pipeline(t, "drop")
+	results, err := drop.Transform(context.Background(), signed[0])
+	if err != nil {
+		t.Fatalf("transform failed: %v", err)
+	}
+	if len(results) != 0 {
+		t.Errorf("expected tampered message to be dropped, got %d messages", len(results))
+	}
+}
+
+func TestVerifyEd25519_MissingSignature(t *testing.T) {
+	cfg := config.Config{
+		Type:     "verify_ed25519",
+		Settings: map[string]interface{}{"key": base64.StdEncoding.EncodeToString(make([]byte, ed25519.PublicKeySize))},
+	}
+	if _, err := newVerifyEd25519(context.Background(), cfg); err == nil {
+		t.Fatal("expected error for missing signature, got nil")
+	}
+}
@@ -0,0 +1,145 @@
+package transform
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"hash"
+	"strings"
+
+	"github.com/jshlbrd/vibestation/config"
+	"github.com/jshlbrd/vibestation/message"
+)
+
+type VerifyHMACConfig struct {
+	// Key is the secret key used to compute the HMAC.
+	Key string `json:"key"`
+	// Algorithm is the hash algorithm used by the HMAC. Defaults to sha256.
+	Algorithm string `json:"algorithm"`
+	// Signature is the JSON path to the hex-encoded signature.
+	Signature string `json:"signature"`
+	// Mode determines what happens to messages with a mismatched signature,
+	// either "error" (default) or "drop".
+	Mode string `json:"mode"`
+	ID   string `json:"id"`
+}
+
+func (c *VerifyHMACConfig) Decode(in interface{}) error {
+	return config.Decode(in, c)
+}
+
+func (c *VerifyHMACConfig) Validate() error {
+	if c.Key == "" {
+		return fmt.Errorf("key: missing required option")
+	}
+	if c.Signature == "" {
+		return fmt.Errorf("signature: missing required option")
+	}
+	if c.Mode != "error" && c.Mode != "drop" {
+		return fmt.Errorf("mode: unsupported value %q", c.Mode)
+	}
+	return nil
+}
+
+func newVerifyHMAC(_ context.Context, cfg config.Config) (*VerifyHMAC, error) {
+	conf := VerifyHMACConfig{}
+	if err := conf.Decode(cfg.Settings); err != nil {
+		return nil, fmt.Errorf("transform verify_hmac: %v", err)
+	}
+	if conf.ID == "" {
+		conf.ID = "verify_hmac"
+	}
+	if conf.Algorithm == "" {
+		conf.Algorithm = "sha256"
+	}
+	if conf.Mode == "" {
+		conf.Mode = "error"
+	}
+	if err := conf.Validate(); err != nil {
+		return nil, fmt.Errorf("transform %s: %v", conf.ID, err)
+	}
+
+	hashFunc, err := newHashFunc(conf.Algorithm)
+	if err != nil {
+		return nil, fmt.Errorf("transform %s: %v", conf.ID, err)
+	}
+
+	var sourcePath string
+	if v, ok := cfg.Settings["source"]; ok {
+		if s, ok := v.(string); ok {
+			sourcePath = s
+		}
+	}
+
+	tf := VerifyHMAC{
+		conf:       conf,
+		hashFunc:   hashFunc,
+		settings:   cfg.Settings,
+		sourcePath: sourcePath,
+	}
+	return &tf, nil
+}
+
+type VerifyHMAC struct {
+	conf       VerifyHMACConfig
+	hashFunc   func() hash.Hash
+	settings   map[string]interface{}
+	sourcePath string
+}
+
+func (tf *VerifyHMAC) Transform(ctx context.Context, msg *message.Message) ([]*message.Message, error) {
+	if msg.IsControl() {
+		return []*message.Message{msg}, nil
+	}
+
+	var inputData []byte
+	if tf.sourcePath != "" {
+		val := msg.GetValue(tf.sourcePath)
+		if val.Exists() {
+			inputData = val.Bytes()
+		}
+	}
+	if inputData == nil {
+		inputData = msg.Data()
+	}
+
+	mac := hmac.New(tf.hashFunc, []byte(tf.conf.Key))
+	mac.Write(inputData)
+	expected := mac.Sum(nil)
+
+	// A missing or malformed signature is treated the same as a mismatch.
+	sig, err := hex.DecodeString(strings.TrimSpace(msg.GetValue(tf.conf.Signature).String()))
+	if err == nil && hmac.Equal(sig, expected) {
+		return []*message.Message{msg}, nil
+	}
+
+	if tf.conf.Mode == "drop" {
+		return nil, nil
+	}
+
+	return nil, fmt.Errorf("transform %s: signature mismatch", tf.conf.ID)
+}
+
+func (tf *VerifyHMAC) String() string {
+	b, _ := json.Marshal(tf.conf)
+	return string(b)
+}
+
+// newHashFunc returns the hash constructor for the named algorithm.
+func newHashFunc(algorithm string) (func() hash.Hash, error) {
+	switch strings.ToLower(algorithm) {
+	case "sha1":
+		return sha1.New, nil
+	case "sha256":
+		return sha256.New, nil
+	case "sha512":
+		return sha512.New, nil
+	default:
+		return nil, fmt.Errorf("algorithm: unsupported value %q", algorithm)
+	}
+}
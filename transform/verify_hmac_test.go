@@ -0,0 +1,94 @@
+package transform
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"testing"
+
+	"github.com/jshlbrd/vibestation/config"
+	"github.com/jshlbrd/vibestation/message"
+)
+
+func testHMACSignature(key, data string) string {
+	mac := hmac.New(sha256.New, []byte(key))
+	mac.Write([]byte(data))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func TestVerifyHMAC_Valid(t *testing.T) {
+	cfg := config.Config{
+		Type: "verify_hmac",
+		Settings: map[string]interface{}{
+			"key":       "secret",
+			"source":    "$.body",
+			"signature": "$.sig",
+		},
+	}
+	tf, err := newVerifyHMAC(context.Background(), cfg)
+	if err != nil {
+		t.Fatalf("failed to create verify_hmac transform: %v", err)
+	}
+
+	data := fmt.Sprintf(`{"body":"hello","sig":%q}`, testHMACSignature("secret", "hello"))
+	msg := message.New().SetData([]byte(data))
+
+	msgs, err := tf.Transform(context.Background(), msg)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(msgs) != 1 {
+		t.Fatalf("expected 1 message, got %d", len(msgs))
+	}
+}
+
+func TestVerifyHMAC_Tampered(t *testing.T) {
+	tests := []struct {
+		mode    string
+		wantErr bool
+	}{
+		{"error", true},
+		{"drop", false},
+	}
+
+	for _, test := range tests {
+		cfg := config.Config{
+			Type: "verify_hmac",
+			Settings: map[string]interface{}{
+				"key":       "secret",
+				"source":    "$.body",
+				"signature": "$.sig",
+				"mode":      test.mode,
+			},
+		}
+		tf, err := newVerifyHMAC(context.Background(), cfg)
+		if err != nil {
+			t.Fatalf("failed to create verify_hmac transform: %v", err)
+		}
+
+		data := fmt.Sprintf(`{"body":"tampered","sig":%q}`, testHMACSignature("secret", "hello"))
+		msg := message.New().SetData([]byte(data))
+
+		msgs, err := tf.Transform(context.Background(), msg)
+		if (err != nil) != test.wantErr {
+			t.Errorf("mode %s: expected error %v, got %v", test.mode, test.wantErr, err)
+		}
+		if len(msgs) != 0 {
+			t.Errorf("mode %s: expected 0 messages, got %d", test.mode, len(msgs))
+		}
+	}
+}
+
+func TestVerifyHMAC_MissingKey(t *testing.T) {
+	cfg := config.Config{
+		Type: "verify_hmac",
+		Settings: map[string]interface{}{
+			"signature": "$.sig",
+		},
+	}
+	if _, err := newVerifyHMAC(context.Background(), cfg); err == nil {
+		t.Fatal("expected error for missing key, got nil")
+	}
+}
@@ -0,0 +1,122 @@
+package transform
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/jshlbrd/vibestation/config"
+	"github.com/jshlbrd/vibestation/message"
+)
+
+type YAMLToJSONConfig struct {
+	ID string `json:"id"`
+}
+
+func (c *YAMLToJSONConfig) Decode(in interface{}) error {
+	return config.Decode(in, c)
+}
+
+func newYAMLToJSON(_ context.Context, cfg config.Config) (*YAMLToJSON, error) {
+	conf := YAMLToJSONConfig{}
+	if err := conf.Decode(cfg.Settings); err != nil {
+		return nil, fmt.Errorf("transform yaml_to_json: %v", err)
+	}
+	if conf.ID == "" {
+		conf.ID = "yaml_to_json"
+	}
+	var sourcePath string
+	if v, ok := cfg.Settings["source"]; ok {
+		if s, ok := v.(string); ok {
+			sourcePath = s
+		}
+	}
+
+	tf := YAMLToJSON{
+		conf:       conf,
+		settings:   cfg.Settings,
+		sourcePath: sourcePath,
+	}
+	return &tf, nil
+}
+
+// YAMLToJSON converts YAML to JSON. Multi-document YAML emits one message
+// per document.
+type YAMLToJSON struct {
+	conf       YAMLToJSONConfig
+	settings   map[string]interface{}
+	sourcePath string
+}
+
+func (tf *YAMLToJSON) Transform(ctx context.Context, msg *message.Message) ([]*message.Message, error) {
+	if msg.IsControl() {
+		return []*message.Message{msg}, nil
+	}
+
+	var inputData []byte
+	if tf.sourcePath != "" {
+		val := msg.GetValue(tf.sourcePath)
+		if val.Exists() {
+			inputData = val.Bytes()
+		}
+	}
+	if inputData == nil {
+		inputData = msg.Data()
+	}
+
+	var result []*message.Message
+	dec := yaml.NewDecoder(bytes.NewReader(inputData))
+	for {
+		var doc interface{}
+		err := dec.Decode(&doc)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("transform %s: %v", tf.conf.ID, err)
+		}
+
+		b, err := json.Marshal(yamlToJSONValue(doc))
+		if err != nil {
+			return nil, fmt.Errorf("transform %s: %v", tf.conf.ID, err)
+		}
+		result = append(result, message.New().SetData(b))
+	}
+
+	return result, nil
+}
+
+func (tf *YAMLToJSON) String() string {
+	b, _ := json.Marshal(tf.conf)
+	return string(b)
+}
+
+// yamlToJSONValue converts decoded YAML into values that can be marshalled
+// as JSON. Maps with non-string keys have their keys formatted as strings.
+func yamlToJSONValue(v interface{}) interface{} {
+	switch val := v.(type) {
+	case map[string]interface{}:
+		for k, elem := range val {
+			val[k] = yamlToJSONValue(elem)
+		}
+		return val
+	case map[interface{}]interface{}:
+		m := make(map[string]interface{}, len(val))
+		for k, elem := range val {
+			m[fmt.Sprint(k)] = yamlToJSONValue(elem)
+		}
+		return m
+	case []interface{}:
+		for i, elem := range val {
+			val[i] = yamlToJSONValue(elem)
+		}
+		return val
+	default:
+		return val
+	}
+}
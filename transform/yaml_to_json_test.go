@@ -0,0 +1,64 @@
+package transform
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jshlbrd/vibestation/config"
+	"github.com/jshlbrd/vibestation/message"
+)
+
+func TestYAMLToJSON(t *testing.T) {
+	tf, err := newYAMLToJSON(context.Background(), config.Config{Type: "yaml_to_json"})
+	if err != nil {
+		t.Fatalf("failed to create yaml_to_json transform: %v", err)
+	}
+
+	input := "server:\n  host: localhost\n  ports:\n    - 80\n    - 443\n  tls: true\n"
+	results, err := tf.Transform(context.Background(), message.New().SetData([]byte(input)))
+	if err != nil {
+		t.Fatalf("transform failed: %v", err)
+	}
+	if len(results) != 1 {
+		t.Fatalf("expected 1 message, got %d", len(results))
+	}
+
+	expected := `{"server":{"host":"localhost","ports":[80,443],"tls":true}}`
+	if got := string(results[0].Data()); got != expected {
+		t.Errorf("expected %s, got %s", expected, got)
+	}
+}
+
+func TestYAMLToJSON_MultiDocument(t *testing.T) {
+	tf, err := newYAMLToJSON(context.Background(), config.Config{Type: "yaml_to_json"})
+	if err != nil {
+		t.Fatalf("failed to create yaml_to_json transform: %v", err)
+	}
+
+	input := "a: 1\n---\nb: 2\n---\n1: one\n"
+	results, err := tf.Transform(context.Background(), message.New().SetData([]byte(input)))
+	if err != nil {
+		t.Fatalf("transform failed: %v", err)
+	}
+
+	expected := []string{`{"a":1}`, `{"b":2}`, `{"1":"one"}`}
+	if len(results) != len(expected) {
+		t.Fatalf("expected %d messages, got %d", len(expected), len(results))
+	}
+	for i, want := range expected {
+		if got := string(results[i].Data()); got != want {
+			t.Errorf("message %d: expected %s, got %s", i, want, got)
+		}
+	}
+}
+
+func TestYAMLToJSON_Invalid(t *testing.T) {
+	tf, err := newYAMLToJSON(context.Background(), config.Config{Type: "yaml_to_json"})
+	if err != nil {
+		t.Fatalf("failed to create yaml_to_json transform: %v", err)
+	}
+
+	if _, err := tf.Transform(context.Background(), message.New().SetData([]byte("a: [1, 2"))); err == nil {
+		t.Fatal("expected error for invalid YAML, got nil")
+	}
+}
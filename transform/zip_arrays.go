@@ -0,0 +1,137 @@
+package transform
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/jshlbrd/vibestation/config"
+	"github.com/jshlbrd/vibestation/message"
+)
+
+type ZipArraysConfig struct {
+	// Keys is the JSON path to the array of keys.
+	Keys string `json:"keys"`
+	// Values is the JSON path to the array of values.
+	Values string `json:"values"`
+	// Format is either object ({"key":k,"value":v}, the default) or pair
+	// ([k,v]).
+	Format string `json:"format"`
+	// Strict returns an error if the arrays have different lengths,
+	// otherwise the result is truncated to the shorter array.
+	Strict bool   `json:"strict"`
+	ID     string `json:"id"`
+}
+
+func (c *ZipArraysConfig) Decode(in interface{}) error {
+	return config.Decode(in, c)
+}
+
+func (c *ZipArraysConfig) Validate() error {
+	if c.Keys == "" {
+		return fmt.Errorf("keys: missing required option")
+	}
+	if c.Values == "" {
+		return fmt.Errorf("values: missing required option")
+	}
+	switch c.Format {
+	case "object", "pair":
+	default:
+		return fmt.Errorf("format: unsupported value %q", c.Format)
+	}
+	return nil
+}
+
+func newZipArrays(_ context.Context, cfg config.Config) (*ZipArrays, error) {
+	conf := ZipArraysConfig{}
+	if err := conf.Decode(cfg.Settings); err != nil {
+		return nil, fmt.Errorf("transform zip_arrays: %v", err)
+	}
+	if conf.ID == "" {
+		conf.ID = "zip_arrays"
+	}
+	if conf.Format == "" {
+		conf.Format = "object"
+	}
+	if err := conf.Validate(); err != nil {
+		return nil, fmt.Errorf("transform %s: %v", conf.ID, err)
+	}
+
+	var targetPath string
+	if v, ok := cfg.Settings["target"]; ok {
+		if s, ok := v.(string); ok {
+			targetPath = s
+		}
+	}
+
+	tf := ZipArrays{
+		conf:       conf,
+		settings:   cfg.Settings,
+		targetPath: targetPath,
+	}
+	return &tf, nil
+}
+
+// ZipArrays combines two parallel arrays into a single array of key-value
+// objects or pairs.
+type ZipArrays struct {
+	conf       ZipArraysConfig
+	settings   map[string]interface{}
+	targetPath string
+}
+
+func (tf *ZipArrays) Transform(ctx context.Context, msg *message.Message) ([]*message.Message, error) {
+	if msg.IsControl() {
+		return []*message.Message{msg}, nil
+	}
+
+	keys, ok := msg.GetValue(tf.conf.Keys).Value().([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("transform %s: keys %s is not an array", tf.conf.ID, tf.conf.Keys)
+	}
+	values, ok := msg.GetValue(tf.conf.Values).Value().([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("transform %s: values %s is not an array", tf.conf.ID, tf.conf.Values)
+	}
+
+	if len(keys) != len(values) && tf.conf.Strict {
+		return nil, fmt.Errorf("transform %s: length mismatch: %d keys and %d values", tf.conf.ID, len(keys), len(values))
+	}
+
+	n := len(keys)
+	if len(values) < n {
+		n = len(values)
+	}
+
+	result := make([]interface{}, n)
+	for i := 0; i < n; i++ {
+		if tf.conf.Format == "pair" {
+			result[i] = []interface{}{keys[i], values[i]}
+			continue
+		}
+
+		result[i] = map[string]interface{}{
+			"key":   keys[i],
+			"value": values[i],
+		}
+	}
+
+	if tf.targetPath != "" {
+		if err := msg.SetValue(tf.targetPath, result); err != nil {
+			return nil, fmt.Errorf("transform %s: failed to set target: %v", tf.conf.ID, err)
+		}
+	} else {
+		b, err := json.Marshal(result)
+		if err != nil {
+			return nil, fmt.Errorf("transform %s: %v", tf.conf.ID, err)
+		}
+		msg.SetData(b)
+	}
+
+	return []*message.Message{msg}, nil
+}
+
+func (tf *ZipArrays) String() string {
+	b, _ := json.Marshal(tf.conf)
+	return string(b)
+}
@@ -0,0 +1,82 @@
+package transform
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jshlbrd/vibestation/config"
+	"github.com/jshlbrd/vibestation/message"
+)
+
+func TestZipArrays(t *testing.T) {
+	tests := []struct {
+		format   string
+		expected string
+	}{
+		{"object", `[{"key":"a","value":1},{"key":"b","value":2}]`},
+		{"pair", `[["a",1],["b",2]]`},
+	}
+
+	for _, test := range tests {
+		t.Run(test.format, func(t *testing.T) {
+			cfg := config.Config{
+				Type: "zip_arrays",
+				Settings: map[string]interface{}{
+					"keys":   "$.keys",
+					"values": "$.values",
+					"format": test.format,
+					"target": "$.zipped",
+				},
+			}
+			tf, err := newZipArrays(context.Background(), cfg)
+			if err != nil {
+				t.Fatalf("failed to create zip_arrays transform: %v", err)
+			}
+
+			msg := message.New().SetData([]byte(`{"keys":["a","b"],"values":[1,2]}`))
+			results, err := tf.Transform(context.Background(), msg)
+			if err != nil {
+				t.Fatalf("transform failed: %v", err)
+			}
+
+			if got := results[0].GetValue("$.zipped").String(); got != test.expected {
+				t.Errorf("expected %s, got %s", test.expected, got)
+			}
+		})
+	}
+}
+
+func TestZipArrays_Mismatch(t *testing.T) {
+	tests := []struct {
+		strict   bool
+		expected string
+		wantErr  bool
+	}{
+		{false, `[{"key":"a","value":1}]`, false},
+		{true, "", true},
+	}
+
+	for _, test := range tests {
+		cfg := config.Config{
+			Type: "zip_arrays",
+			Settings: map[string]interface{}{
+				"keys":   "$.keys",
+				"values": "$.values",
+				"strict": test.strict,
+			},
+		}
+		tf, err := newZipArrays(context.Background(), cfg)
+		if err != nil {
+			t.Fatalf("failed to create zip_arrays transform: %v", err)
+		}
+
+		msg := message.New().SetData([]byte(`{"keys":["a","b"],"values":[1]}`))
+		results, err := tf.Transform(context.Background(), msg)
+		if (err != nil) != test.wantErr {
+			t.Fatalf("strict %v: expected error %v, got %v", test.strict, test.wantErr, err)
+		}
+		if err == nil && string(results[0].Data()) != test.expected {
+			t.Errorf("expected %s, got %s", test.expected, string(results[0].Data()))
+		}
+	}
+}
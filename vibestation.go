@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 
 	"github.com/jshlbrd/vibestation/config"
 	"github.com/jshlbrd/vibestation/message"
@@ -17,14 +18,32 @@ var errNoTransforms = fmt.Errorf("no transforms configured")
 type Config struct {
 	// Transforms contains a list of data transformations that are executed.
 	Transforms []config.Config `json:"transforms"`
+	// CaptureErrors records transform errors in message metadata instead of
+	// stopping the pipeline. See transform.ApplyWithErrorCapture.
+	CaptureErrors bool `json:"capture_errors"`
+}
+
+// Merge returns a new configuration with the transforms from other appended
+// after the transforms from c. Error capture is enabled if it is enabled in
+// either configuration.
+func (c Config) Merge(other Config) Config {
+	tforms := make([]config.Config, 0, len(c.Transforms)+len(other.Transforms))
+	tforms = append(tforms, c.Transforms...)
+	tforms = append(tforms, other.Transforms...)
+
+	return Config{
+		Transforms:    tforms,
+		CaptureErrors: c.CaptureErrors || other.CaptureErrors,
+	}
 }
 
 // Vibestation provides access to data transformation functions.
 type Vibestation struct {
 	cfg Config
 
-	factory transform.Factory
-	tforms  []transform.Transformer
+	factory  transform.Factory
+	tforms   []transform.Transformer
+	snapshot bool
 }
 
 // New returns a new Vibestation instance.
@@ -62,14 +81,43 @@ func WithTransformFactory(fac transform.Factory) func(*Vibestation) {
 	}
 }
 
+// WithSnapshot clones messages before they are transformed, so the messages
+// passed to Transform are never modified. If a transform fails mid-pipeline,
+// then the original messages can be retried.
+func WithSnapshot() func(*Vibestation) {
+	return func(v *Vibestation) {
+		v.snapshot = true
+	}
+}
+
 // Transform runs the configured data transformation functions on the
 // provided messages.
 //
 // This is safe to use concurrently.
 func (v *Vibestation) Transform(ctx context.Context, msg ...*message.Message) ([]*message.Message, error) {
+	if v.snapshot {
+		clones := make([]*message.Message, len(msg))
+		for i, m := range msg {
+			clones[i] = m.Clone()
+		}
+		msg = clones
+	}
+
+	if v.cfg.CaptureErrors {
+		return transform.ApplyWithErrorCapture(ctx, v.tforms, msg...)
+	}
+
 	return transform.Apply(ctx, v.tforms, msg...)
 }
 
+// TransformReader streams r through the configured data transformation
+// functions without buffering the data in memory. Every transform must support
+// streaming, and the CaptureErrors and snapshot options do not apply. The
+// caller is responsible for closing the returned reader.
+func (v *Vibestation) TransformReader(ctx context.Context, r io.Reader) (io.ReadCloser, error) {
+	return transform.ApplyReader(ctx, v.tforms, r)
+}
+
 // String returns a JSON representation of the configuration.
 func (v *Vibestation) String() string {
 	b, err := json.Marshal(v.cfg)
@@ -1,8 +1,11 @@
 package vibestation
 
 import (
+	"bytes"
+	"compress/gzip"
 	"context"
 	"encoding/json"
+	"io"
 	"reflect"
 	"testing"
 
@@ -126,3 +129,174 @@ func TestVibestationDirectAssignment(t *testing.T) {
 		t.Errorf("Expected %s, got %s", expected, data)
 	}
 }
+
+func TestVibestationDelete(t *testing.T) {
+	transformMaps, err := config.NewParser().Parse("delete($.foo)")
+	if err != nil {
+		t.Fatalf("Failed to parse SUB script: %v", err)
+	}
+
+	var transforms []config.Config
+	for _, tmap := range transformMaps {
+		settings := make(map[string]interface{})
+		for k, v := range tmap {
+			if k != "type" {
+				settings[k] = v
+			}
+		}
+		transforms = append(transforms, config.Config{
+			Type:     tmap["type"].(string),
+			Settings: settings,
+		})
+	}
+
+	vibe, err := New(context.Background(), Config{Transforms: transforms})
+	if err != nil {
+		t.Fatalf("Failed to create vibestation: %v", err)
+	}
+
+	msg := message.New().SetData([]byte(`{"foo":"bar","baz":1}`))
+	result, err := vibe.Transform(context.Background(), msg)
+	if err != nil {
+		t.Fatalf("Transform failed: %v", err)
+	}
+	if len(result) != 1 {
+		t.Fatalf("Expected 1 result message, got %d", len(result))
+	}
+
+	// Without a target, the deleted value is stored at $.deleted_value.
+	data := string(result[0].Data())
+	expected := `{"baz":1,"deleted_value":"bar"}`
+	if !jsonEqual(data, expected) {
+		t.Errorf("Expected %s, got %s", expected, data)
+	}
+}
+
+func TestVibestationCaptureErrors(t *testing.T) {
+	cfg := Config{
+		Transforms: []config.Config{
+			{
+				Type:     "decode_base64",
+				Settings: map[string]interface{}{"source": "$.encoded"},
+			},
+		},
+		CaptureErrors: true,
+	}
+
+	vibe, err := New(context.Background(), cfg)
+	if err != nil {
+		t.Fatalf("Failed to create vibestation: %v", err)
+	}
+
+	msg := message.New().SetData([]byte(`{"encoded":"not_base64!"}`))
+	result, err := vibe.Transform(context.Background(), msg)
+	if err != nil {
+		t.Fatalf("Transform failed: %v", err)
+	}
+	if len(result) != 1 {
+		t.Fatalf("Expected 1 result message, got %d", len(result))
+	}
+	if !result[0].GetValue("meta.$._error").Exists() {
+		t.Error("Expected error to be captured in metadata")
+	}
+}
+
+func TestConfigMerge(t *testing.T) {
+	base := Config{
+		Transforms: []config.Config{
+			{Type: "lowercase_string"},
+		},
+	}
+	env := Config{
+		Transforms: []config.Config{
+			{
+				Type:     "truncate",
+				Settings: map[string]interface{}{"length": 5},
+			},
+		},
+	}
+
+	cfg := base.Merge(env)
+	if len(cfg.Transforms) != 2 {
+		t.Fatalf("Expected 2 transforms, got %d", len(cfg.Transforms))
+	}
+	if len(base.Transforms) != 1 {
+		t.Errorf("Expected base config to be unchanged, got %d transforms", len(base.Transforms))
+	}
+
+	vibe, err := New(context.Background(), cfg)
+	if err != nil {
+		t.Fatalf("Failed to create vibestation: %v", err)
+	}
+
+	msg := message.New().SetData([]byte("HELLO WORLD"))
+	result, err := vibe.Transform(context.Background(), msg)
+	if err != nil {
+		t.Fatalf("Transform failed: %v", err)
+	}
+
+	// The base transform runs before the merged transform.
+	if got := string(result[0].Data()); got != "hello" {
+		t.Errorf("Expected hello, got %s", got)
+	}
+}
+
+func TestVibestationWithSnapshot(t *testing.T) {
+	cfg := Config{
+		Transforms: []config.Config{
+			{Type: "lowercase_string"},
+			{
+				Type:     "decode_base64",
+				Settings: map[string]interface{}{"source": "$.encoded"},
+			},
+		},
+	}
+
+	vibe, err := New(context.Background(), cfg, WithSnapshot())
+	if err != nil {
+		t.Fatalf("Failed to create vibestation: %v", err)
+	}
+
+	input := `{"encoded":"NOT_BASE64!"}`
+	msg := message.New().SetData([]byte(input))
+	if _, err := vibe.Transform(context.Background(), msg); err == nil {
+		t.Fatal("Expected error from decode_base64, got nil")
+	}
+
+	// The first transform lowercased a clone, so the input is unmodified.
+	if got := string(msg.Data()); got != input {
+		t.Errorf("Expected input to be unmodified, got %s", got)
+	}
+}
+
+func TestVibestationTransformReader(t *testing.T) {
+	cfg := Config{
+		Transforms: []config.Config{
+			{Type: "decompress_gzip"},
+		},
+	}
+
+	vibe, err := New(context.Background(), cfg)
+	if err != nil {
+		t.Fatalf("Failed to create vibestation: %v", err)
+	}
+
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	gz.Write([]byte("hello world"))
+	gz.Close()
+
+	r, err := vibe.TransformReader(context.Background(), &buf)
+	if err != nil {
+		t.Fatalf("TransformReader failed: %v", err)
+	}
+	defer r.Close()
+
+	out, err := io.ReadAll(r)
+	if err != nil {
+		t.Fatalf("Read failed: %v", err)
+	}
+	if string(out) != "hello world" {
+		t.Errorf("Expected %q, got %q", "hello world", string(out))
+	}
+}